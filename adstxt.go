@@ -0,0 +1,97 @@
+package openrtb
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// AdsTxtRelationship describes the type of account relationship declared in an ads.txt record.
+type AdsTxtRelationship string
+
+// AdsTxtRelationship values.
+const (
+	AdsTxtDirect   AdsTxtRelationship = "DIRECT"
+	AdsTxtReseller AdsTxtRelationship = "RESELLER"
+)
+
+// AdsTxtRecord is a single data record of an ads.txt or app-ads.txt file.
+type AdsTxtRecord struct {
+	Domain          string             // Canonical domain of the advertising system, matches SupplyChainNode.ASI
+	PublisherID     string             // Seller account ID within the advertising system, matches SupplyChainNode.SID
+	Relationship    AdsTxtRelationship // Type of account relationship
+	CertAuthorityID string             // Optional certification authority ID of the advertising system
+}
+
+// AdsTxt is a parsed ads.txt or app-ads.txt file.
+type AdsTxt struct {
+	Records   []AdsTxtRecord
+	Variables map[string][]string // Variable declarations by upper-case name, e.g. CONTACT, SUBDOMAIN, OWNERDOMAIN
+}
+
+// ParseAdsTxt parses an ads.txt or app-ads.txt file. Malformed lines are skipped, as
+// required by the specification.
+func ParseAdsTxt(r io.Reader) (*AdsTxt, error) {
+	txt := &AdsTxt{Variables: make(map[string][]string)}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i > -1 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		if i := strings.IndexByte(line, '='); i > -1 && !strings.Contains(line[:i], ",") {
+			name := strings.ToUpper(strings.TrimSpace(line[:i]))
+			txt.Variables[name] = append(txt.Variables[name], strings.TrimSpace(line[i+1:]))
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) < 3 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		rec := AdsTxtRecord{
+			Domain:       strings.ToLower(fields[0]),
+			PublisherID:  fields[1],
+			Relationship: AdsTxtRelationship(strings.ToUpper(fields[2])),
+		}
+		if rec.Domain == "" || rec.PublisherID == "" || (rec.Relationship != AdsTxtDirect && rec.Relationship != AdsTxtReseller) {
+			continue
+		}
+		if len(fields) > 3 {
+			rec.CertAuthorityID = fields[3]
+		}
+		txt.Records = append(txt.Records, rec)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return txt, nil
+}
+
+// Find returns the record authorizing the given advertising system domain and seller account ID,
+// preferring DIRECT over RESELLER records. Returns nil if the seller is not authorized.
+func (txt *AdsTxt) Find(domain, publisherID string) *AdsTxtRecord {
+	var found *AdsTxtRecord
+	for i := range txt.Records {
+		rec := &txt.Records[i]
+		if rec.PublisherID != publisherID || !strings.EqualFold(rec.Domain, domain) {
+			continue
+		}
+		if rec.Relationship == AdsTxtDirect {
+			return rec
+		}
+		if found == nil {
+			found = rec
+		}
+	}
+	return found
+}
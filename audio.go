@@ -20,7 +20,7 @@ type Audio struct {
 	StartDelay     StartDelay          `json:"startdelay,omitempty"`   // Indicates the start delay in seconds
 	PoDid          int                 `json:"podid,omitempty"`        // Unique identifier indicating that an impression opportunity belongs to a video ad pod. If multiple impression opportunities within a bid request share the same podid, this indicates that those impression opportunities belong to the same video ad pod
 	PodSeq         int                 `json:"podseq,omitempty"`       // The sequence (position) of the video ad pod within a content     stream
-	RqdDurs        int                 `json:"rqddurs,omitempty"`      // Precise acceptable durations for video creatives in seconds. This field specifically targets the Live TV use case where non-exact ad durations would result in undesirable ‘dead air’. This field is mutually exclusive with minduration and maxduration; if rqddurs is specified, minduration and maxduration must not be specified and vice versa
+	RqdDurs        []int               `json:"rqddurs,omitempty"`      // Precise acceptable durations for video creatives in seconds. This field specifically targets the Live TV use case where non-exact ad durations would result in undesirable ‘dead air’. This field is mutually exclusive with minduration and maxduration; if rqddurs is specified, minduration and maxduration must not be specified and vice versa. A single number, as decoded into the former int type, is accepted by DecodeLenient with CoerceSingleArrays
 	Sequence       int                 `json:"sequence,omitempty"`     // Default: 1
 	SlotInPod      int                 `json:"slotinpod,omitempty"`    // For video ad pods, this value indicates that the seller can guarantee delivery against the indicated slot position in the pod.
 	MinCPMPerSec   float32             `json:"mincpmpersec,omitempty"` // Minimum CPM per second. This is a price floor for the “dynamic” portion of a video ad pod, relative to the duration of bids an advertiser may submit.
@@ -49,7 +49,7 @@ type Bid struct {
 	HeightRatio        int                 `json:"hratio,omitempty"`         // Relative height of the creative when expressing size as a ratio.
 	Exp                int                 `json:"exp,omitempty"`            // Advisory as to the number of seconds the bidder is willing to wait between the auction and the actual impression.
 	Duration           int                 `json:"dur,omitempty"`            // Duration of the video or audio creative in seconds.
-	MarkupType         int                 `json:"mtype,omitempty"`          // Type of the creative markup so that it can properly be associated with the right sub-object of the BidRequest.Imp.
+	MarkupType         MediaType           `json:"mtype,omitempty"`          // Type of the creative markup so that it can properly be associated with the right sub-object of the BidRequest.Imp.
 	SlotInPod          int                 `json:"slotinpod,omitempty"`      // Indicates that the bid response is only eligible for a specific position within a video or audio ad pod
 	Ext                json.RawMessage     `json:"ext,omitempty"`
 }
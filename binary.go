@@ -0,0 +1,180 @@
+package openrtb
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Binary encoding errors
+var (
+	ErrBinaryTruncated   = errors.New("openrtb: truncated binary data")
+	ErrBinaryUnsupported = errors.New("openrtb: unsupported binary data type")
+	ErrBinaryKey         = errors.New("openrtb: binary map key is not a string")
+	ErrBinaryDepth       = errors.New("openrtb: binary data nested too deeply")
+	ErrBinaryTrailing    = errors.New("openrtb: unexpected data after binary value")
+)
+
+// maxBinaryDepth limits the nesting of decoded binary data.
+const maxBinaryDepth = 1000
+
+// Object is implemented by BidRequest, BidResponse and all of their child objects.
+type Object interface {
+	// AppendJSON appends the JSON encoding of the object to dst.
+	AppendJSON(dst []byte) ([]byte, error)
+	// DecodeJSON decodes the object from JSON data.
+	DecodeJSON(data []byte) error
+
+	decodeJSON(r *jsonReader)
+}
+
+// The binary encodings are transcoded from and to the JSON representation of objects, so
+// that they share its attribute names and Ext values are encoded as native maps and arrays.
+// Numbers without fraction or exponent are encoded as integers, all others as floats.
+
+// binaryEncoder appends values in a binary format.
+type binaryEncoder interface {
+	null(b []byte) []byte
+	bool(b []byte, v bool) []byte
+	int(b []byte, v int64) []byte
+	uint(b []byte, v uint64) []byte
+	float(b []byte, v float64) []byte
+	string(b []byte, s []byte) []byte
+	array(b []byte, n int) []byte  // appends an array header of n elements
+	object(b []byte, n int) []byte // appends a map header of n pairs
+}
+
+func marshalBinary(v Object, enc binaryEncoder) ([]byte, error) {
+	data, err := v.AppendJSON(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := jsonReader{data: data}
+	b := appendBinary(make([]byte, 0, len(data)), &r, enc)
+	if err := r.finish(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// appendBinary transcodes the next JSON value.
+func appendBinary(b []byte, r *jsonReader, enc binaryEncoder) []byte {
+	var hdr [9]byte
+
+	switch c := r.peek(); {
+	case c == '{':
+		start, n := len(b), 0
+		for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+			b = enc.string(b, key)
+			b = appendBinary(b, r, enc)
+			n++
+		}
+		return insertHeader(b, start, enc.object(hdr[:0], n))
+	case c == '[':
+		start, n := len(b), 0
+		for ok := r.firstElem(); ok; ok = r.nextElem() {
+			b = appendBinary(b, r, enc)
+			n++
+		}
+		return insertHeader(b, start, enc.array(hdr[:0], n))
+	case c == '"':
+		return enc.string(b, r.stringBytes())
+	case c == 't':
+		r.literal("true")
+		return enc.bool(b, true)
+	case c == 'f':
+		r.literal("false")
+		return enc.bool(b, false)
+	case c == 'n':
+		r.literal("null")
+		return enc.null(b)
+	case c == '-' || c >= '0' && c <= '9':
+		return appendBinaryNumber(b, r, enc)
+	}
+	r.fail(ErrJSONSyntax)
+	return b
+}
+
+func appendBinaryNumber(b []byte, r *jsonReader, enc binaryEncoder) []byte {
+	lit := string(r.number())
+	if r.err != nil {
+		return b
+	}
+
+	isInt := true
+	for i := 0; i < len(lit); i++ {
+		if c := lit[i]; c == '.' || c == 'e' || c == 'E' {
+			isInt = false
+			break
+		}
+	}
+	if isInt {
+		if n, err := strconv.ParseInt(lit, 10, 64); err == nil {
+			return enc.int(b, n)
+		}
+		if n, err := strconv.ParseUint(lit, 10, 64); err == nil {
+			return enc.uint(b, n)
+		}
+	}
+
+	f, err := strconv.ParseFloat(lit, 64)
+	if err != nil {
+		r.fail(r.numError(err))
+		return b
+	}
+	return enc.float(b, f)
+}
+
+// insertHeader inserts a container header in front of its elements starting at pos.
+func insertHeader(b []byte, pos int, hdr []byte) []byte {
+	n := len(b)
+	b = append(b, hdr...)
+	copy(b[pos+len(hdr):], b[pos:n])
+	copy(b[pos:], hdr)
+	return b
+}
+
+// isFloat32 returns true if the value can be encoded as a single precision float without loss.
+func isFloat32(f float64) bool {
+	return float64(float32(f)) == f || math.IsNaN(f)
+}
+
+// binaryWriter writes decoded binary values as JSON.
+type binaryWriter struct {
+	jsonWriter
+	depth int
+}
+
+func (w *binaryWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *binaryWriter) separator(i int) {
+	if i != 0 {
+		w.b = append(w.b, ',')
+	}
+}
+
+func (w *binaryWriter) key(s []byte) {
+	w.string(string(s))
+	w.b = append(w.b, ':')
+}
+
+// enter increases the nesting depth, returns false if it is too deep.
+func (w *binaryWriter) enter() bool {
+	if w.depth++; w.depth > maxBinaryDepth {
+		w.fail(ErrBinaryDepth)
+		return false
+	}
+	return true
+}
+
+func unmarshalBinary(w *binaryWriter, v Object) error {
+	if w.err != nil {
+		return w.err
+	}
+	return v.DecodeJSON(w.b)
+}
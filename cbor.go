@@ -0,0 +1,311 @@
+package openrtb
+
+import (
+	"math"
+)
+
+// MarshalCBOR encodes an object as CBOR (RFC 8949), using the attribute names of its JSON encoding.
+// It is transcoded from the output of AppendJSON, so it costs a JSON encoding and parse on top
+// of writing CBOR.
+func MarshalCBOR(v Object) ([]byte, error) {
+	return marshalBinary(v, cborEncoder{})
+}
+
+// UnmarshalCBOR decodes an object from CBOR data. Tags are ignored, byte strings are not supported.
+// The data is transcoded to JSON, which is then decoded with DecodeJSON.
+func UnmarshalCBOR(data []byte, v Object) error {
+	d := cborDecoder{data: data}
+	w := binaryWriter{jsonWriter: jsonWriter{b: make([]byte, 0, 2*len(data))}}
+	d.value(&w)
+	if w.err == nil && d.pos != len(d.data) {
+		w.fail(ErrBinaryTrailing)
+	}
+	return unmarshalBinary(&w, v)
+}
+
+// CBOR major types
+const (
+	cborUint   = 0 << 5
+	cborNegint = 1 << 5
+	cborBytes  = 2 << 5
+	cborText   = 3 << 5
+	cborArray  = 4 << 5
+	cborMap    = 5 << 5
+	cborTag    = 6 << 5
+	cborSimple = 7 << 5
+
+	cborIndefinite = 31
+	cborBreak      = 0xff
+)
+
+type cborEncoder struct{}
+
+func (cborEncoder) null(b []byte) []byte { return append(b, cborSimple|22) }
+
+func (cborEncoder) bool(b []byte, v bool) []byte {
+	if v {
+		return append(b, cborSimple|21)
+	}
+	return append(b, cborSimple|20)
+}
+
+func (cborEncoder) int(b []byte, v int64) []byte {
+	if v < 0 {
+		return appendCBORHeader(b, cborNegint, uint64(-1-v))
+	}
+	return appendCBORHeader(b, cborUint, uint64(v))
+}
+
+func (cborEncoder) uint(b []byte, v uint64) []byte {
+	return appendCBORHeader(b, cborUint, v)
+}
+
+func (cborEncoder) float(b []byte, v float64) []byte {
+	if isFloat32(v) {
+		return appendBigEndian(append(b, cborSimple|26), uint64(math.Float32bits(float32(v))), 4)
+	}
+	return appendBigEndian(append(b, cborSimple|27), math.Float64bits(v), 8)
+}
+
+func (cborEncoder) string(b []byte, s []byte) []byte {
+	return append(appendCBORHeader(b, cborText, uint64(len(s))), s...)
+}
+
+func (cborEncoder) array(b []byte, n int) []byte {
+	return appendCBORHeader(b, cborArray, uint64(n))
+}
+
+func (cborEncoder) object(b []byte, n int) []byte {
+	return appendCBORHeader(b, cborMap, uint64(n))
+}
+
+func appendCBORHeader(b []byte, major byte, v uint64) []byte {
+	switch {
+	case v < 24:
+		return append(b, major|byte(v))
+	case v <= math.MaxUint8:
+		return append(b, major|24, byte(v))
+	case v <= math.MaxUint16:
+		return appendBigEndian(append(b, major|25), v, 2)
+	case v <= math.MaxUint32:
+		return appendBigEndian(append(b, major|26), v, 4)
+	}
+	return appendBigEndian(append(b, major|27), v, 8)
+}
+
+// --------------------------------------------------------------------
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) read(w *binaryWriter, n uint64) []byte {
+	if uint64(len(d.data)-d.pos) < n {
+		w.fail(ErrBinaryTruncated)
+		d.pos = len(d.data)
+		return nil
+	}
+	p := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return p
+}
+
+// header reads the initial byte and the argument of the next item, info is cborIndefinite
+// for indefinite length items.
+func (d *cborDecoder) header(w *binaryWriter) (major byte, info byte, arg uint64) {
+	p := d.read(w, 1)
+	if p == nil {
+		return 0, 0, 0
+	}
+
+	major, info = p[0]&0xe0, p[0]&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info)
+	case info <= 27:
+		p = d.read(w, 1<<(info-24))
+		for _, c := range p {
+			arg = arg<<8 | uint64(c)
+		}
+		return major, info, arg
+	case info == cborIndefinite:
+		return major, info, 0
+	}
+	w.fail(ErrBinaryUnsupported)
+	return major, info, 0
+}
+
+// more returns true if an indefinite length container has more items, consuming the break.
+func (d *cborDecoder) more(w *binaryWriter) bool {
+	if d.pos >= len(d.data) {
+		w.fail(ErrBinaryTruncated)
+		return false
+	}
+	if d.data[d.pos] == cborBreak {
+		d.pos++
+		return false
+	}
+	return true
+}
+
+// length validates a definite container length against the remaining data.
+func (d *cborDecoder) length(w *binaryWriter, n uint64, perElem uint64) int {
+	if n > uint64(len(d.data)-d.pos)/perElem {
+		w.fail(ErrBinaryTruncated)
+		return 0
+	}
+	return int(n)
+}
+
+// value decodes the next value and writes it as JSON.
+func (d *cborDecoder) value(w *binaryWriter) {
+	if w.err != nil {
+		return
+	}
+
+	major, info, arg := d.header(w)
+	if w.err != nil {
+		return
+	}
+	indefinite := info == cborIndefinite
+
+	switch major {
+	case cborUint:
+		w.uint(arg)
+	case cborNegint:
+		if arg > math.MaxInt64 {
+			w.fail(ErrJSONOutOfRange)
+			return
+		}
+		w.int(-1 - int64(arg))
+	case cborText:
+		if s, ok := d.text(w, info, arg); ok {
+			w.string(string(s))
+		}
+	case cborArray:
+		if !w.enter() {
+			return
+		}
+		w.b = append(w.b, '[')
+		if indefinite {
+			for i := 0; d.more(w) && w.err == nil; i++ {
+				w.separator(i)
+				d.value(w)
+			}
+		} else {
+			n := d.length(w, arg, 1)
+			for i := 0; i < n && w.err == nil; i++ {
+				w.separator(i)
+				d.value(w)
+			}
+		}
+		w.b = append(w.b, ']')
+		w.depth--
+	case cborMap:
+		if !w.enter() {
+			return
+		}
+		w.b = append(w.b, '{')
+		if indefinite {
+			for i := 0; d.more(w) && w.err == nil; i++ {
+				w.separator(i)
+				d.key(w)
+				d.value(w)
+			}
+		} else {
+			n := d.length(w, arg, 2)
+			for i := 0; i < n && w.err == nil; i++ {
+				w.separator(i)
+				d.key(w)
+				d.value(w)
+			}
+		}
+		w.b = append(w.b, '}')
+		w.depth--
+	case cborTag:
+		if indefinite {
+			w.fail(ErrBinaryUnsupported)
+			return
+		}
+		d.value(w)
+	case cborSimple:
+		d.simple(w, info, arg)
+	default:
+		w.fail(ErrBinaryUnsupported)
+	}
+}
+
+func (d *cborDecoder) simple(w *binaryWriter, info byte, arg uint64) {
+	switch info {
+	case 20:
+		w.b = append(w.b, "false"...)
+	case 21:
+		w.b = append(w.b, "true"...)
+	case 22, 23: // null, undefined
+		w.null()
+	case 25:
+		w.float(float64(halfToFloat32(uint16(arg))), 64)
+	case 26:
+		w.float(float64(math.Float32frombits(uint32(arg))), 64)
+	case 27:
+		w.float(math.Float64frombits(arg), 64)
+	default:
+		w.fail(ErrBinaryUnsupported)
+	}
+}
+
+// text reads a text string, concatenating the chunks of indefinite length strings.
+func (d *cborDecoder) text(w *binaryWriter, info byte, arg uint64) ([]byte, bool) {
+	if info != cborIndefinite {
+		s := d.read(w, arg)
+		return s, w.err == nil
+	}
+
+	var s []byte
+	for d.more(w) {
+		major, info, arg := d.header(w)
+		if w.err != nil {
+			return nil, false
+		}
+		if major != cborText || info == cborIndefinite {
+			w.fail(ErrBinaryUnsupported)
+			return nil, false
+		}
+		s = append(s, d.read(w, arg)...)
+	}
+	return s, w.err == nil
+}
+
+func (d *cborDecoder) key(w *binaryWriter) {
+	major, info, arg := d.header(w)
+	if w.err != nil {
+		return
+	}
+	if major != cborText {
+		w.fail(ErrBinaryKey)
+		return
+	}
+	if s, ok := d.text(w, info, arg); ok {
+		w.key(s)
+	}
+}
+
+// halfToFloat32 converts an IEEE 754 half precision float.
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+
+	switch exp {
+	case 0: // zero and subnormals
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1f: // infinity and NaN
+		return math.Float32frombits(sign | 0xff<<23 | frac<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | frac<<13)
+}
@@ -0,0 +1,38 @@
+package openrtb
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMarshalCBOR_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 500; n++ {
+		assertBinaryRoundTrip(t, rng, MarshalCBOR, UnmarshalCBOR)
+	}
+}
+
+func FuzzMarshalCBOR(f *testing.F) {
+	f.Add(int64(0))
+	f.Add(int64(1))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		assertBinaryRoundTrip(t, rand.New(rand.NewSource(seed)), MarshalCBOR, UnmarshalCBOR)
+	})
+}
+
+func FuzzUnmarshalCBOR(f *testing.F) {
+	for _, s := range []string{goldenRequest, `{"id":"1","imp":[{"id":"1","ext":{"a":[null,true,1.5,-3]}}]}`} {
+		var req BidRequest
+		if err := req.DecodeJSON([]byte(s)); err != nil {
+			f.Fatal(err)
+		}
+		data, err := MarshalCBOR(&req)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		assertBinaryDecode(t, data, MarshalCBOR, UnmarshalCBOR)
+	})
+}
@@ -0,0 +1,254 @@
+package openrtb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultVersion is the OpenRTB version declared in the x-openrtb-version header by default.
+const DefaultVersion = "2.6"
+
+// VersionHeader is the HTTP header declaring the OpenRTB version of requests and responses.
+const VersionHeader = "X-Openrtb-Version"
+
+// Bidder client errors
+var (
+	ErrBidderTimeout      = errors.New("openrtb: bidder timed out")
+	ErrBidderStatus       = errors.New("openrtb: unexpected HTTP status from bidder")
+	ErrResponseTooLarge   = errors.New("openrtb: bid response too large")
+	ErrResponseIDMismatch = errors.New("openrtb: bid response ID does not match the request")
+)
+
+// Outcome classifies the result of sending a request to a bidder.
+type Outcome int
+
+// Outcome values.
+const (
+	OutcomeBid             Outcome = iota // The bidder responded with at least one bid
+	OutcomeNoContent                      // The bidder responded with HTTP 204 or an empty body
+	OutcomeNoBid                          // The bidder responded without seat bids, usually with a no-bid reason
+	OutcomeTimeout                        // The deadline expired before a response was received
+	OutcomeInvalidResponse                // The response was not valid JSON or failed validation
+	OutcomeHTTPError                      // The bidder responded with an unexpected HTTP status
+	OutcomeError                          // The request could not be sent or encoded
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeBid:
+		return "bid"
+	case OutcomeNoContent:
+		return "no content"
+	case OutcomeNoBid:
+		return "no bid"
+	case OutcomeTimeout:
+		return "timeout"
+	case OutcomeInvalidResponse:
+		return "invalid response"
+	case OutcomeHTTPError:
+		return "HTTP error"
+	case OutcomeError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// BidderResult is the result of sending a request to a bidder.
+type BidderResult struct {
+	Outcome    Outcome
+	Response   *BidResponse  // Decoded response, set for OutcomeBid and OutcomeNoBid
+	NBR        NBR           // No-bid reason of OutcomeNoBid
+	StatusCode int           // HTTP status code, 0 if no response was received
+	Latency    time.Duration // Time from sending the request until the response was read
+	Err        error         // Cause of OutcomeTimeout, OutcomeInvalidResponse, OutcomeHTTPError and OutcomeError
+}
+
+// Client sends bid requests to a bidder endpoint.
+type Client struct {
+	Endpoint        string        // URL of the bidder
+	HTTPClient      *http.Client  // HTTP client to use, defaults to http.DefaultClient
+	Version         string        // Value of the x-openrtb-version header, defaults to DefaultVersion
+	Gzip            bool          // Compress requests and accept compressed responses
+	NetworkBuffer   time.Duration // Subtracted from TMax to allow for network latency
+	MaxResponseSize int64         // Maximum accepted response size, defaults to 1MB
+	Header          http.Header   // Additional headers, e.g. for authentication
+}
+
+// Send sends a request to the bidder and decodes its response. If the request has a TMax,
+// the context deadline is limited to TMax minus the network buffer from now. If the network
+// buffer leaves no time, the request is not sent and the result is OutcomeTimeout.
+func (c *Client) Send(ctx context.Context, req *BidRequest) *BidderResult {
+	if req.TMax > 0 {
+		timeout := time.Duration(req.TMax)*time.Millisecond - c.NetworkBuffer
+		if timeout <= 0 {
+			return &BidderResult{Outcome: OutcomeTimeout, Err: ErrBidderTimeout}
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	res := c.send(ctx, req)
+	res.Latency = time.Since(start)
+	if res.Outcome == OutcomeError && ctx.Err() == context.DeadlineExceeded {
+		res.Outcome, res.Err = OutcomeTimeout, ErrBidderTimeout
+	}
+	return res
+}
+
+func (c *Client) send(ctx context.Context, req *BidRequest) *BidderResult {
+	body, err := c.encode(req)
+	if err != nil {
+		return &BidderResult{Outcome: OutcomeError, Err: err}
+	}
+
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return &BidderResult{Outcome: OutcomeError, Err: err}
+	}
+	for k, v := range c.Header {
+		hreq.Header[k] = v
+	}
+	version := c.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hreq.Header.Set(VersionHeader, version)
+	if c.Gzip {
+		hreq.Header.Set("Content-Encoding", "gzip")
+		hreq.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	hresp, err := client.Do(hreq)
+	if err != nil {
+		if isTimeout(err) {
+			return &BidderResult{Outcome: OutcomeTimeout, Err: ErrBidderTimeout}
+		}
+		return &BidderResult{Outcome: OutcomeError, Err: err}
+	}
+	defer hresp.Body.Close()
+
+	res := &BidderResult{StatusCode: hresp.StatusCode}
+	switch hresp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		drain(hresp.Body)
+		res.Outcome = OutcomeNoContent
+		return res
+	default:
+		drain(hresp.Body)
+		res.Outcome, res.Err = OutcomeHTTPError, ErrBidderStatus
+		return res
+	}
+
+	data, err := c.read(hresp)
+	switch {
+	case err != nil && isTimeout(err):
+		res.Outcome, res.Err = OutcomeTimeout, ErrBidderTimeout
+		return res
+	case err == ErrResponseTooLarge:
+		res.Outcome, res.Err = OutcomeInvalidResponse, err
+		return res
+	case err != nil:
+		res.Outcome, res.Err = OutcomeError, err
+		return res
+	case len(bytes.TrimSpace(data)) == 0:
+		res.Outcome = OutcomeNoContent
+		return res
+	}
+	c.decode(data, req, res)
+	return res
+}
+
+// maxDrainSize is the maximum number of bytes read from an unused response body, so that
+// the connection can be reused.
+const maxDrainSize = 4 << 10
+
+// drain reads the remainder of a response body up to maxDrainSize.
+func drain(body io.Reader) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainSize))
+}
+
+func (c *Client) encode(req *BidRequest) ([]byte, error) {
+	data, err := req.AppendJSON(nil)
+	if err != nil || !c.Gzip {
+		return data, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Client) read(hresp *http.Response) ([]byte, error) {
+	maxSize := c.MaxResponseSize
+	if maxSize <= 0 {
+		maxSize = 1 << 20
+	}
+
+	var body io.Reader = hresp.Body
+	if hresp.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(hresp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		body = zr
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err == nil && int64(len(data)) > maxSize {
+		return nil, ErrResponseTooLarge
+	}
+	return data, err
+}
+
+// decode classifies a response with HTTP status 200.
+func (c *Client) decode(data []byte, req *BidRequest, res *BidderResult) {
+	resp := new(BidResponse)
+	if err := resp.DecodeJSON(data); err != nil {
+		res.Outcome, res.Err = OutcomeInvalidResponse, err
+		return
+	}
+	res.Response = resp
+
+	switch {
+	case resp.ID != req.ID:
+		res.Outcome, res.Err = OutcomeInvalidResponse, ErrResponseIDMismatch
+	case len(resp.SeatBids) == 0:
+		res.Outcome, res.NBR = OutcomeNoBid, resp.NBR
+	default:
+		if err := resp.Validate(); err != nil {
+			res.Outcome, res.Err = OutcomeInvalidResponse, err
+		} else {
+			res.Outcome = OutcomeBid
+		}
+	}
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
@@ -0,0 +1,139 @@
+package openrtb
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Send(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(VersionHeader) != DefaultVersion || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+		data, _ := io.ReadAll(body)
+		var req BidRequest
+		if err := req.DecodeJSON(data); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch req.ID {
+		case "bid":
+			w.Write([]byte(`{"id":"bid","seatbid":[{"bid":[{"id":"1","impid":"1","price":1.5}]}]}`))
+		case "nobid":
+			w.Write([]byte(`{"id":"nobid","nbr":2}`))
+		case "empty":
+		case "nocontent":
+			w.WriteHeader(http.StatusNoContent)
+		case "mismatch":
+			w.Write([]byte(`{"id":"other"}`))
+		case "invalid":
+			w.Write([]byte(`{"id":`))
+		case "large":
+			w.Write([]byte(`{"id":"large","ext":"` + strings.Repeat("x", 100) + `"}`))
+		case "slow":
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte(`{"id":"slow"}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	for _, gz := range []bool{false, true} {
+		c := &Client{Endpoint: srv.URL, Gzip: gz, MaxResponseSize: 100}
+		for _, tc := range []struct {
+			id      string
+			tmax    int
+			outcome Outcome
+			status  int
+			err     error
+		}{
+			{"bid", 0, OutcomeBid, 200, nil},
+			{"nobid", 0, OutcomeNoBid, 200, nil},
+			{"empty", 0, OutcomeNoContent, 200, nil},
+			{"nocontent", 0, OutcomeNoContent, 204, nil},
+			{"mismatch", 0, OutcomeInvalidResponse, 200, ErrResponseIDMismatch},
+			{"invalid", 0, OutcomeInvalidResponse, 200, ErrJSONSyntax},
+			{"large", 0, OutcomeInvalidResponse, 200, ErrResponseTooLarge},
+			{"slow", 50, OutcomeTimeout, 0, ErrBidderTimeout},
+			{"unavailable", 0, OutcomeHTTPError, 503, ErrBidderStatus},
+		} {
+			res := c.Send(context.Background(), &BidRequest{ID: tc.id, TMax: tc.tmax, Impressions: []Impression{{ID: "1"}}})
+			if res.Outcome != tc.outcome || res.StatusCode != tc.status || res.Err != tc.err {
+				t.Errorf("%s (gzip %v): expected %s, %d, %v, got %s, %d, %v", tc.id, gz, tc.outcome, tc.status, tc.err, res.Outcome, res.StatusCode, res.Err)
+			}
+		}
+	}
+}
+
+func TestClient_Send_networkBuffer(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := &Client{Endpoint: srv.URL, NetworkBuffer: 100 * time.Millisecond}
+	for _, tmax := range []int{50, 100} {
+		res := c.Send(context.Background(), &BidRequest{ID: "1", TMax: tmax})
+		if res.Outcome != OutcomeTimeout || res.Err != ErrBidderTimeout {
+			t.Errorf("tmax %d: expected timeout, got %s, %v", tmax, res.Outcome, res.Err)
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 0 {
+		t.Fatalf("expected no requests, got %d", n)
+	}
+
+	if res := c.Send(context.Background(), &BidRequest{ID: "1", TMax: 500}); res.Outcome != OutcomeNoContent {
+		t.Fatalf("expected no content, got %s, %v", res.Outcome, res.Err)
+	}
+}
+
+// Error responses are drained, so that the connection is reused.
+func TestClient_Send_reuse(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.(http.Flusher).Flush()
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(strings.Repeat("error ", 500)))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c := &Client{Endpoint: srv.URL, HTTPClient: srv.Client()}
+	for i := 0; i < 5; i++ {
+		if res := c.Send(context.Background(), &BidRequest{ID: "1"}); res.Outcome != OutcomeHTTPError {
+			t.Fatalf("expected HTTP error, got %s, %v", res.Outcome, res.Err)
+		}
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Fatalf("expected 1 connection, got %d", n)
+	}
+}
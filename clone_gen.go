@@ -0,0 +1,712 @@
+// Code generated by jsongen; DO NOT EDIT.
+
+package openrtb
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *App) Clone() *App {
+	if x == nil {
+		return nil
+	}
+	c := new(App)
+	x.copyTo(c)
+	return c
+}
+
+func (x *App) copyTo(c *App) {
+	*c = *x
+	c.Inventory.Categories = append(x.Inventory.Categories[:0:0], x.Inventory.Categories...)
+	c.Inventory.SectionCategories = append(x.Inventory.SectionCategories[:0:0], x.Inventory.SectionCategories...)
+	c.Inventory.PageCategories = append(x.Inventory.PageCategories[:0:0], x.Inventory.PageCategories...)
+	if x.Inventory.PrivacyPolicy != nil {
+		c.Inventory.PrivacyPolicy = new(int)
+		*c.Inventory.PrivacyPolicy = *x.Inventory.PrivacyPolicy
+	}
+	c.Inventory.Publisher = x.Inventory.Publisher.Clone()
+	c.Inventory.Content = x.Inventory.Content.Clone()
+	c.Inventory.KeywordArray = append(x.Inventory.KeywordArray[:0:0], x.Inventory.KeywordArray...)
+	c.Inventory.Ext = append(x.Inventory.Ext[:0:0], x.Inventory.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Audio) Clone() *Audio {
+	if x == nil {
+		return nil
+	}
+	c := new(Audio)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Audio) copyTo(c *Audio) {
+	*c = *x
+	c.MIMEs = append(x.MIMEs[:0:0], x.MIMEs...)
+	c.Protocols = append(x.Protocols[:0:0], x.Protocols...)
+	c.RqdDurs = append(x.RqdDurs[:0:0], x.RqdDurs...)
+	c.BlockedAttrs = append(x.BlockedAttrs[:0:0], x.BlockedAttrs...)
+	c.Delivery = append(x.Delivery[:0:0], x.Delivery...)
+	if x.CompanionAds != nil {
+		c.CompanionAds = make([]Banner, len(x.CompanionAds))
+		for i := range x.CompanionAds {
+			x.CompanionAds[i].copyTo(&c.CompanionAds[i])
+		}
+	}
+	c.APIs = append(x.APIs[:0:0], x.APIs...)
+	c.CompanionTypes = append(x.CompanionTypes[:0:0], x.CompanionTypes...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Banner) Clone() *Banner {
+	if x == nil {
+		return nil
+	}
+	c := new(Banner)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Banner) copyTo(c *Banner) {
+	*c = *x
+	if x.Formats != nil {
+		c.Formats = make([]Format, len(x.Formats))
+		for i := range x.Formats {
+			x.Formats[i].copyTo(&c.Formats[i])
+		}
+	}
+	c.BlockedTypes = append(x.BlockedTypes[:0:0], x.BlockedTypes...)
+	c.BlockedAttrs = append(x.BlockedAttrs[:0:0], x.BlockedAttrs...)
+	c.MIMEs = append(x.MIMEs[:0:0], x.MIMEs...)
+	c.ExpDirs = append(x.ExpDirs[:0:0], x.ExpDirs...)
+	c.APIs = append(x.APIs[:0:0], x.APIs...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Bid) Clone() *Bid {
+	if x == nil {
+		return nil
+	}
+	c := new(Bid)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Bid) copyTo(c *Bid) {
+	*c = *x
+	c.AdvDomains = append(x.AdvDomains[:0:0], x.AdvDomains...)
+	c.Categories = append(x.Categories[:0:0], x.Categories...)
+	c.Attrs = append(x.Attrs[:0:0], x.Attrs...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *BidRequest) Clone() *BidRequest {
+	if x == nil {
+		return nil
+	}
+	c := new(BidRequest)
+	x.copyTo(c)
+	return c
+}
+
+func (x *BidRequest) copyTo(c *BidRequest) {
+	*c = *x
+	if x.Impressions != nil {
+		c.Impressions = make([]Impression, len(x.Impressions))
+		for i := range x.Impressions {
+			x.Impressions[i].copyTo(&c.Impressions[i])
+		}
+	}
+	c.Site = x.Site.Clone()
+	c.App = x.App.Clone()
+	c.Device = x.Device.Clone()
+	c.User = x.User.Clone()
+	c.Seats = append(x.Seats[:0:0], x.Seats...)
+	c.BlockedSeats = append(x.BlockedSeats[:0:0], x.BlockedSeats...)
+	c.Currencies = append(x.Currencies[:0:0], x.Currencies...)
+	c.Languages = append(x.Languages[:0:0], x.Languages...)
+	c.BlockedCategories = append(x.BlockedCategories[:0:0], x.BlockedCategories...)
+	c.BlockedAdvDomains = append(x.BlockedAdvDomains[:0:0], x.BlockedAdvDomains...)
+	c.BlockedApps = append(x.BlockedApps[:0:0], x.BlockedApps...)
+	c.Source = x.Source.Clone()
+	c.Regulations = x.Regulations.Clone()
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *BidResponse) Clone() *BidResponse {
+	if x == nil {
+		return nil
+	}
+	c := new(BidResponse)
+	x.copyTo(c)
+	return c
+}
+
+func (x *BidResponse) copyTo(c *BidResponse) {
+	*c = *x
+	if x.SeatBids != nil {
+		c.SeatBids = make([]SeatBid, len(x.SeatBids))
+		for i := range x.SeatBids {
+			x.SeatBids[i].copyTo(&c.SeatBids[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *BrandVersion) Clone() *BrandVersion {
+	if x == nil {
+		return nil
+	}
+	c := new(BrandVersion)
+	x.copyTo(c)
+	return c
+}
+
+func (x *BrandVersion) copyTo(c *BrandVersion) {
+	*c = *x
+	c.Source = append(x.Source[:0:0], x.Source...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Channel) Clone() *Channel {
+	if x == nil {
+		return nil
+	}
+	c := new(Channel)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Channel) copyTo(c *Channel) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Content) Clone() *Content {
+	if x == nil {
+		return nil
+	}
+	c := new(Content)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Content) copyTo(c *Content) {
+	*c = *x
+	c.Producer = x.Producer.Clone()
+	c.Categories = append(x.Categories[:0:0], x.Categories...)
+	c.KeywordArray = append(x.KeywordArray[:0:0], x.KeywordArray...)
+	if x.Data != nil {
+		c.Data = make([]Data, len(x.Data))
+		for i := range x.Data {
+			x.Data[i].copyTo(&c.Data[i])
+		}
+	}
+	c.Network = x.Network.Clone()
+	c.Channel = x.Channel.Clone()
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Data) Clone() *Data {
+	if x == nil {
+		return nil
+	}
+	c := new(Data)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Data) copyTo(c *Data) {
+	*c = *x
+	if x.Segment != nil {
+		c.Segment = make([]Segment, len(x.Segment))
+		for i := range x.Segment {
+			x.Segment[i].copyTo(&c.Segment[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Deal) Clone() *Deal {
+	if x == nil {
+		return nil
+	}
+	c := new(Deal)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Deal) copyTo(c *Deal) {
+	*c = *x
+	c.Seats = append(x.Seats[:0:0], x.Seats...)
+	c.AdvDomains = append(x.AdvDomains[:0:0], x.AdvDomains...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Device) Clone() *Device {
+	if x == nil {
+		return nil
+	}
+	c := new(Device)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Device) copyTo(c *Device) {
+	*c = *x
+	c.Geo = x.Geo.Clone()
+	x.StructuredUserAgent.copyTo(&c.StructuredUserAgent)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *EID) Clone() *EID {
+	if x == nil {
+		return nil
+	}
+	c := new(EID)
+	x.copyTo(c)
+	return c
+}
+
+func (x *EID) copyTo(c *EID) {
+	*c = *x
+	if x.UIDs != nil {
+		c.UIDs = make([]UID, len(x.UIDs))
+		for i := range x.UIDs {
+			x.UIDs[i].copyTo(&c.UIDs[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Format) Clone() *Format {
+	if x == nil {
+		return nil
+	}
+	c := new(Format)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Format) copyTo(c *Format) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Geo) Clone() *Geo {
+	if x == nil {
+		return nil
+	}
+	c := new(Geo)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Geo) copyTo(c *Geo) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Impression) Clone() *Impression {
+	if x == nil {
+		return nil
+	}
+	c := new(Impression)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Impression) copyTo(c *Impression) {
+	*c = *x
+	if x.Metric != nil {
+		c.Metric = make([]*Metric, len(x.Metric))
+		for i := range x.Metric {
+			c.Metric[i] = x.Metric[i].Clone()
+		}
+	}
+	c.Banner = x.Banner.Clone()
+	c.Video = x.Video.Clone()
+	c.Audio = x.Audio.Clone()
+	c.Native = x.Native.Clone()
+	c.PMP = x.PMP.Clone()
+	c.IFrameBusters = append(x.IFrameBusters[:0:0], x.IFrameBusters...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Metric) Clone() *Metric {
+	if x == nil {
+		return nil
+	}
+	c := new(Metric)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Metric) copyTo(c *Metric) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Native) Clone() *Native {
+	if x == nil {
+		return nil
+	}
+	c := new(Native)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Native) copyTo(c *Native) {
+	*c = *x
+	c.Request = append(x.Request[:0:0], x.Request...)
+	c.APIs = append(x.APIs[:0:0], x.APIs...)
+	c.BlockedAttrs = append(x.BlockedAttrs[:0:0], x.BlockedAttrs...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Network) Clone() *Network {
+	if x == nil {
+		return nil
+	}
+	c := new(Network)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Network) copyTo(c *Network) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *PMP) Clone() *PMP {
+	if x == nil {
+		return nil
+	}
+	c := new(PMP)
+	x.copyTo(c)
+	return c
+}
+
+func (x *PMP) copyTo(c *PMP) {
+	*c = *x
+	if x.Deals != nil {
+		c.Deals = make([]Deal, len(x.Deals))
+		for i := range x.Deals {
+			x.Deals[i].copyTo(&c.Deals[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Producer) Clone() *Producer {
+	if x == nil {
+		return nil
+	}
+	c := new(Producer)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Producer) copyTo(c *Producer) {
+	*c = *x
+	c.Categories = append(x.Categories[:0:0], x.Categories...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Publisher) Clone() *Publisher {
+	if x == nil {
+		return nil
+	}
+	c := new(Publisher)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Publisher) copyTo(c *Publisher) {
+	*c = *x
+	c.Categories = append(x.Categories[:0:0], x.Categories...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Regulations) Clone() *Regulations {
+	if x == nil {
+		return nil
+	}
+	c := new(Regulations)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Regulations) copyTo(c *Regulations) {
+	*c = *x
+	c.GPPSID = append(x.GPPSID[:0:0], x.GPPSID...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *SeatBid) Clone() *SeatBid {
+	if x == nil {
+		return nil
+	}
+	c := new(SeatBid)
+	x.copyTo(c)
+	return c
+}
+
+func (x *SeatBid) copyTo(c *SeatBid) {
+	*c = *x
+	if x.Bids != nil {
+		c.Bids = make([]Bid, len(x.Bids))
+		for i := range x.Bids {
+			x.Bids[i].copyTo(&c.Bids[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Segment) Clone() *Segment {
+	if x == nil {
+		return nil
+	}
+	c := new(Segment)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Segment) copyTo(c *Segment) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Site) Clone() *Site {
+	if x == nil {
+		return nil
+	}
+	c := new(Site)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Site) copyTo(c *Site) {
+	*c = *x
+	c.Inventory.Categories = append(x.Inventory.Categories[:0:0], x.Inventory.Categories...)
+	c.Inventory.SectionCategories = append(x.Inventory.SectionCategories[:0:0], x.Inventory.SectionCategories...)
+	c.Inventory.PageCategories = append(x.Inventory.PageCategories[:0:0], x.Inventory.PageCategories...)
+	if x.Inventory.PrivacyPolicy != nil {
+		c.Inventory.PrivacyPolicy = new(int)
+		*c.Inventory.PrivacyPolicy = *x.Inventory.PrivacyPolicy
+	}
+	c.Inventory.Publisher = x.Inventory.Publisher.Clone()
+	c.Inventory.Content = x.Inventory.Content.Clone()
+	c.Inventory.KeywordArray = append(x.Inventory.KeywordArray[:0:0], x.Inventory.KeywordArray...)
+	c.Inventory.Ext = append(x.Inventory.Ext[:0:0], x.Inventory.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Source) Clone() *Source {
+	if x == nil {
+		return nil
+	}
+	c := new(Source)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Source) copyTo(c *Source) {
+	*c = *x
+	c.SupplyChain = x.SupplyChain.Clone()
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *SupplyChain) Clone() *SupplyChain {
+	if x == nil {
+		return nil
+	}
+	c := new(SupplyChain)
+	x.copyTo(c)
+	return c
+}
+
+func (x *SupplyChain) copyTo(c *SupplyChain) {
+	*c = *x
+	if x.Node != nil {
+		c.Node = make([]SupplyChainNode, len(x.Node))
+		for i := range x.Node {
+			x.Node[i].copyTo(&c.Node[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *SupplyChainNode) Clone() *SupplyChainNode {
+	if x == nil {
+		return nil
+	}
+	c := new(SupplyChainNode)
+	x.copyTo(c)
+	return c
+}
+
+func (x *SupplyChainNode) copyTo(c *SupplyChainNode) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *UID) Clone() *UID {
+	if x == nil {
+		return nil
+	}
+	c := new(UID)
+	x.copyTo(c)
+	return c
+}
+
+func (x *UID) copyTo(c *UID) {
+	*c = *x
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *User) Clone() *User {
+	if x == nil {
+		return nil
+	}
+	c := new(User)
+	x.copyTo(c)
+	return c
+}
+
+func (x *User) copyTo(c *User) {
+	*c = *x
+	c.KeywordArray = append(x.KeywordArray[:0:0], x.KeywordArray...)
+	c.Geo = x.Geo.Clone()
+	if x.Data != nil {
+		c.Data = make([]Data, len(x.Data))
+		for i := range x.Data {
+			x.Data[i].copyTo(&c.Data[i])
+		}
+	}
+	if x.Eids != nil {
+		c.Eids = make([]EID, len(x.Eids))
+		for i := range x.Eids {
+			x.Eids[i].copyTo(&c.Eids[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *UserAgent) Clone() *UserAgent {
+	if x == nil {
+		return nil
+	}
+	c := new(UserAgent)
+	x.copyTo(c)
+	return c
+}
+
+func (x *UserAgent) copyTo(c *UserAgent) {
+	*c = *x
+	if x.Browsers != nil {
+		c.Browsers = make([]BrandVersion, len(x.Browsers))
+		for i := range x.Browsers {
+			x.Browsers[i].copyTo(&c.Browsers[i])
+		}
+	}
+	if x.PMPlatform != nil {
+		c.PMPlatform = make([]BrandVersion, len(x.PMPlatform))
+		for i := range x.PMPlatform {
+			x.PMPlatform[i].copyTo(&c.PMPlatform[i])
+		}
+	}
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
+
+// Clone returns a deep copy of the object, which shares no pointers, slices or
+// raw JSON with the original. A nil object results in nil.
+func (x *Video) Clone() *Video {
+	if x == nil {
+		return nil
+	}
+	c := new(Video)
+	x.copyTo(c)
+	return c
+}
+
+func (x *Video) copyTo(c *Video) {
+	*c = *x
+	c.MIMEs = append(x.MIMEs[:0:0], x.MIMEs...)
+	c.Protocols = append(x.Protocols[:0:0], x.Protocols...)
+	c.RqdDurs = append(x.RqdDurs[:0:0], x.RqdDurs...)
+	c.BlockedAttrs = append(x.BlockedAttrs[:0:0], x.BlockedAttrs...)
+	if x.BoxingAllowed != nil {
+		c.BoxingAllowed = new(int)
+		*c.BoxingAllowed = *x.BoxingAllowed
+	}
+	c.PlaybackMethods = append(x.PlaybackMethods[:0:0], x.PlaybackMethods...)
+	c.Delivery = append(x.Delivery[:0:0], x.Delivery...)
+	if x.CompanionAds != nil {
+		c.CompanionAds = make([]Banner, len(x.CompanionAds))
+		for i := range x.CompanionAds {
+			x.CompanionAds[i].copyTo(&c.CompanionAds[i])
+		}
+	}
+	c.APIs = append(x.APIs[:0:0], x.APIs...)
+	c.CompanionTypes = append(x.CompanionTypes[:0:0], x.CompanionTypes...)
+	c.Ext = append(x.Ext[:0:0], x.Ext...)
+}
@@ -0,0 +1,109 @@
+package openrtb
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fillAll sets every exported field of v to a non-zero value derived from n, slices get
+// two elements and pointers are allocated.
+func fillAll(v reflect.Value, n int) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("s" + string(rune('a'+n%26)))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(n + 1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(n + 1))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(n) + 0.5)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillAll(v.Elem(), n+1)
+	case reflect.Slice:
+		if v.Type() == rawMessageType {
+			v.SetBytes([]byte(`{"n":1}`))
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		for i := 0; i < v.Len(); i++ {
+			fillAll(v.Index(i), n+i+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				fillAll(v.Field(i), n+i)
+			}
+		}
+	}
+}
+
+// mutate changes every value reachable from v in place, including the elements of slices
+// and the bytes of raw JSON.
+func mutate(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(v.String() + "x")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(v.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(v.Uint() + 1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(v.Float() + 1)
+	case reflect.Bool:
+		v.SetBool(!v.Bool())
+	case reflect.Ptr:
+		if !v.IsNil() {
+			mutate(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			mutate(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				mutate(v.Field(i))
+			}
+		}
+	}
+}
+
+func TestClone_deep(t *testing.T) {
+	for typ := range protoFields {
+		orig := reflect.New(typ)
+		fillAll(orig.Elem(), 0)
+		want := reflect.New(typ)
+		fillAll(want.Elem(), 0)
+
+		clone := orig.MethodByName("Clone").Call(nil)[0]
+		if !reflect.DeepEqual(orig.Interface(), clone.Interface()) {
+			t.Errorf("%s: clone differs from the original", typ)
+			continue
+		}
+
+		mutate(clone.Elem())
+		if reflect.DeepEqual(clone.Interface(), want.Interface()) {
+			t.Errorf("%s: clone was not mutated", typ)
+		}
+		if !reflect.DeepEqual(orig.Interface(), want.Interface()) {
+			t.Errorf("%s: mutating the clone changed the original", typ)
+		}
+	}
+}
+
+func TestClone_nil(t *testing.T) {
+	var req *BidRequest
+	if req.Clone() != nil {
+		t.Fatal("expected nil")
+	}
+
+	// nil and empty slices are preserved
+	req = &BidRequest{Impressions: []Impression{}, Ext: nil}
+	c := req.Clone()
+	if c.Impressions == nil || c.Seats != nil || c.Ext != nil {
+		t.Fatalf("unexpected clone %+v", c)
+	}
+}
@@ -0,0 +1,291 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrDiffNil is returned when diffing a nil object.
+var ErrDiffNil = errors.New("openrtb: cannot diff nil object")
+
+// ChangeType is the kind of a change found by Diff.
+type ChangeType int
+
+// ChangeType values.
+const (
+	ChangeAdded ChangeType = iota + 1
+	ChangeRemoved
+	ChangeModified
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	}
+	return "unknown"
+}
+
+// Change is a difference between two objects at a single attribute, array element or
+// member of an Ext object.
+type Change struct {
+	Type ChangeType
+	Path string          // JSON Pointer (RFC 6901) of the attribute, e.g. "/imp/0/banner/w"
+	From json.RawMessage // Previous value, nil if added
+	To   json.RawMessage // New value, nil if removed
+}
+
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return "+ " + c.Path + ": " + string(c.To)
+	case ChangeRemoved:
+		return "- " + c.Path + ": " + string(c.From)
+	}
+	return "~ " + c.Path + ": " + string(c.From) + " -> " + string(c.To)
+}
+
+// Changes is the result of Diff.
+type Changes []Change
+
+// String returns the changes in a human-readable form, one per line.
+func (cs Changes) String() string {
+	var b strings.Builder
+	for _, c := range cs {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// JSONPatch returns the changes as a JSON Patch (RFC 6902) document, which transforms
+// the first object passed to Diff into the second.
+func (cs Changes) JSONPatch() []byte {
+	w := jsonWriter{b: []byte{'['}}
+	for _, c := range cs {
+		op := "replace"
+		switch c.Type {
+		case ChangeAdded:
+			op = "add"
+		case ChangeRemoved:
+			op = "remove"
+		}
+
+		w.elem()
+		w.b = append(w.b, '{')
+		w.field(`"op":`)
+		w.string(op)
+		w.field(`"path":`)
+		w.string(c.Path)
+		if c.Type != ChangeRemoved {
+			w.field(`"value":`)
+			w.b = append(w.b, c.To...)
+		}
+		w.b = append(w.b, '}')
+	}
+	return append(w.b, ']')
+}
+
+// Diff compares the JSON representations of two objects, usually two BidRequests or
+// BidResponses, and returns the changes from one to the other. Ext values are compared
+// as JSON as well, object members regardless of their order.
+//
+// Elements of arrays are compared by index, elements added or removed at the end are
+// reported as such. Removed elements are reported in descending order, so that the
+// changes can be applied in sequence.
+func Diff(from, to Object) (Changes, error) {
+	if isNilObject(from) || isNilObject(to) {
+		return nil, ErrDiffNil
+	}
+
+	a, err := from.AppendJSON(nil)
+	if err != nil {
+		return nil, err
+	}
+	b, err := to.AppendJSON(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var d differ
+	d.diff(a, b)
+	return d.changes, nil
+}
+
+func isNilObject(v Object) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// differ compares compact and valid JSON values.
+type differ struct {
+	changes Changes
+	path    []byte // JSON Pointer of the compared values
+}
+
+func (d *differ) diff(a, b []byte) {
+	switch {
+	case a[0] == '{' && b[0] == '{':
+		d.objects(a, b)
+	case a[0] == '[' && b[0] == '[':
+		d.arrays(a, b)
+	case !jsonEqual(a, b):
+		d.change(ChangeModified, a, b)
+	}
+}
+
+func (d *differ) change(t ChangeType, from, to []byte) {
+	d.changes = append(d.changes, Change{
+		Type: t,
+		Path: string(d.path),
+		From: from,
+		To:   to,
+	})
+}
+
+type jsonMember struct {
+	key   string
+	value []byte
+}
+
+// members returns the members of an object, of duplicate keys the last one.
+func members(data []byte) []jsonMember {
+	var ms []jsonMember
+	r := jsonReader{data: data}
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+		k := string(key)
+		v := r.raw()
+		for i := range ms {
+			if ms[i].key == k {
+				ms = append(ms[:i], ms[i+1:]...)
+				break
+			}
+		}
+		ms = append(ms, jsonMember{key: k, value: v})
+	}
+	return ms
+}
+
+func (d *differ) objects(a, b []byte) {
+	ma, mb := members(a), members(b)
+	index := make(map[string][]byte, len(mb))
+	for _, m := range mb {
+		index[m.key] = m.value
+	}
+
+	found := make(map[string]bool, len(ma))
+	for _, m := range ma {
+		found[m.key] = true
+		n := d.enterKey(m.key)
+		if v, ok := index[m.key]; ok {
+			d.diff(m.value, v)
+		} else {
+			d.change(ChangeRemoved, m.value, nil)
+		}
+		d.leave(n)
+	}
+	for _, m := range mb {
+		if !found[m.key] {
+			n := d.enterKey(m.key)
+			d.change(ChangeAdded, nil, m.value)
+			d.leave(n)
+		}
+	}
+}
+
+func (d *differ) arrays(a, b []byte) {
+	ea, eb := elements(a), elements(b)
+	for i := 0; i < len(ea) && i < len(eb); i++ {
+		n := d.enterIndex(i)
+		d.diff(ea[i], eb[i])
+		d.leave(n)
+	}
+	for i := len(ea) - 1; i >= len(eb); i-- {
+		n := d.enterIndex(i)
+		d.change(ChangeRemoved, ea[i], nil)
+		d.leave(n)
+	}
+	for i := len(ea); i < len(eb); i++ {
+		n := d.enterIndex(i)
+		d.change(ChangeAdded, nil, eb[i])
+		d.leave(n)
+	}
+}
+
+func elements(data []byte) [][]byte {
+	var es [][]byte
+	r := jsonReader{data: data}
+	for ok := r.firstElem(); ok; ok = r.nextElem() {
+		es = append(es, r.raw())
+	}
+	return es
+}
+
+func (d *differ) enterKey(key string) int {
+	n := len(d.path)
+	d.path = append(d.path, '/')
+	d.path = appendPointerToken(d.path, key)
+	return n
+}
+
+func (d *differ) enterIndex(i int) int {
+	n := len(d.path)
+	d.path = append(d.path, '/')
+	d.path = strconv.AppendInt(d.path, int64(i), 10)
+	return n
+}
+
+func (d *differ) leave(n int) {
+	d.path = d.path[:n]
+}
+
+// appendPointerToken appends a key escaped as JSON Pointer reference token.
+func appendPointerToken(b []byte, key string) []byte {
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; c {
+		case '~':
+			b = append(b, "~0"...)
+		case '/':
+			b = append(b, "~1"...)
+		default:
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// jsonEqual compares two compact scalar values, or containers of different types.
+// Strings are compared after unescaping and numbers by value.
+func jsonEqual(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	switch {
+	case a[0] == '"' && b[0] == '"':
+		var sa, sb string
+		ra, rb := jsonReader{data: a}, jsonReader{data: b}
+		ra.string(&sa)
+		rb.string(&sb)
+		return sa == sb
+	case isNumber(a[0]) && isNumber(b[0]):
+		fa, ea := strconv.ParseFloat(string(a), 64)
+		fb, eb := strconv.ParseFloat(string(b), 64)
+		return ea == nil && eb == nil && fa == fb
+	}
+	return false
+}
+
+func isNumber(c byte) bool {
+	return c == '-' || c >= '0' && c <= '9'
+}
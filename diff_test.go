@@ -0,0 +1,83 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	from := &BidRequest{
+		ID:          "1",
+		TMax:        100,
+		Impressions: []Impression{{ID: "1", Banner: &Banner{Width: 300}}, {ID: "2"}, {ID: "3"}},
+		Ext:         json.RawMessage(`{"a.b":1,"c/d":{"e~f":true,"g":[1,2]},"h":"x"}`),
+	}
+	to := &BidRequest{
+		ID:          "1",
+		Impressions: []Impression{{ID: "1", Banner: &Banner{Width: 320}}},
+		Site:        &Site{Inventory: Inventory{Domain: "example.com"}},
+		Ext:         json.RawMessage(`{"c/d":{"g":[1,2,3],"e~f":false},"a.b":1.0,"h":"x","i":null}`),
+	}
+
+	changes, err := Diff(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := Changes{
+		{Type: ChangeModified, Path: "/imp/0/banner/w", From: json.RawMessage(`300`), To: json.RawMessage(`320`)},
+		{Type: ChangeRemoved, Path: "/imp/2", From: json.RawMessage(`{"id":"3"}`)},
+		{Type: ChangeRemoved, Path: "/imp/1", From: json.RawMessage(`{"id":"2"}`)},
+		{Type: ChangeRemoved, Path: "/tmax", From: json.RawMessage(`100`)},
+		{Type: ChangeModified, Path: "/ext/c~1d/e~0f", From: json.RawMessage(`true`), To: json.RawMessage(`false`)},
+		{Type: ChangeAdded, Path: "/ext/c~1d/g/2", To: json.RawMessage(`3`)},
+		{Type: ChangeAdded, Path: "/ext/i", To: json.RawMessage(`null`)},
+		{Type: ChangeAdded, Path: "/site", To: json.RawMessage(`{"domain":"example.com"}`)},
+	}
+	if !reflect.DeepEqual(changes, exp) {
+		t.Fatalf("expected\n%v\ngot\n%v", exp, changes)
+	}
+	if s := changes[0].String(); s != "~ /imp/0/banner/w: 300 -> 320" {
+		t.Fatalf("unexpected change %q", s)
+	}
+
+	// the patch transforms one object into the other
+	patched, err := from.ApplyJSONPatch(changes.JSONPatch())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Diff(patched, to); err != nil || len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v, %v", changes, err)
+	}
+
+	if changes, err := Diff(to, to.Clone()); err != nil || len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v, %v", changes, err)
+	}
+}
+
+func TestDiff_nil(t *testing.T) {
+	var req *BidRequest
+	for _, tc := range [][2]Object{
+		{nil, new(BidRequest)},
+		{new(BidRequest), nil},
+		{req, new(BidRequest)},
+		{new(BidRequest), req},
+	} {
+		if _, err := Diff(tc[0], tc[1]); err != ErrDiffNil {
+			t.Errorf("expected %v, got %v", ErrDiffNil, err)
+		}
+	}
+}
+
+func TestChanges_String(t *testing.T) {
+	cs := Changes{
+		{Type: ChangeAdded, Path: "/ext/a.b", To: json.RawMessage(`1`)},
+		{Type: ChangeRemoved, Path: "/tmax", From: json.RawMessage(`100`)},
+	}
+	if s := cs.String(); s != "+ /ext/a.b: 1\n- /tmax: 100\n" {
+		t.Fatalf("unexpected changes %q", s)
+	}
+	if s := string(cs.JSONPatch()); s != `[{"op":"add","path":"/ext/a.b","value":1},{"op":"remove","path":"/tmax"}]` {
+		t.Fatalf("unexpected patch %s", s)
+	}
+}
@@ -0,0 +1,163 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSkipBidder is returned by adapters to exclude a bidder from a fan-out.
+var ErrSkipBidder = errors.New("openrtb: bidder skipped")
+
+// Sender sends bid requests to a bidder, it is implemented by Client.
+type Sender interface {
+	Send(ctx context.Context, req *BidRequest) *BidderResult
+}
+
+// Adapter modifies the copy of a request sent to a single bidder. It returns ErrSkipBidder
+// if the bidder should not receive the request at all.
+type Adapter func(req *BidRequest) error
+
+// FilterSeats restricts the seats of a request to those of a bidder. Seats which are not allowed
+// by Seats (wseat) or blocked by BlockedSeats (bseat) are excluded, the other seats of the bidder
+// are sent in Seats. The bidder is skipped if none of its seats remain.
+func FilterSeats(seats ...string) Adapter {
+	return func(req *BidRequest) error {
+		var allowed []string
+		for _, seat := range seats {
+			if (len(req.Seats) == 0 || containsString(req.Seats, seat)) && !containsString(req.BlockedSeats, seat) {
+				allowed = append(allowed, seat)
+			}
+		}
+		if len(allowed) == 0 {
+			return ErrSkipBidder
+		}
+		req.Seats, req.BlockedSeats = allowed, nil
+		return nil
+	}
+}
+
+// FilterImpressions removes the impressions for which keep returns false. The bidder is
+// skipped if no impressions remain.
+func FilterImpressions(keep func(imp *Impression) bool) Adapter {
+	return func(req *BidRequest) error {
+		imps := req.Impressions[:0]
+		for i := range req.Impressions {
+			if keep(&req.Impressions[i]) {
+				imps = append(imps, req.Impressions[i])
+			}
+		}
+		if len(imps) == 0 {
+			return ErrSkipBidder
+		}
+		req.Impressions = imps
+		return nil
+	}
+}
+
+// ConvertVersion converts requests to the OpenRTB version of a bidder, see BidRequest.ConvertVersion.
+func ConvertVersion(version string) Adapter {
+	return func(req *BidRequest) error {
+		return req.ConvertVersion(version)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// FanOutBidder is a bidder taking part in a fan-out.
+type FanOutBidder struct {
+	Name     string
+	Sender   Sender
+	Adapters []Adapter // Applied in order to the bidder's copy of the request
+}
+
+// FanOutResult is the result of a single bidder.
+type FanOutResult struct {
+	Bidder  string
+	Request *BidRequest   // Request for the bidder, nil if it was skipped or an adapter failed
+	Result  *BidderResult // Result of the bidder, nil if no request was sent
+	Err     error         // ErrSkipBidder or the error of an adapter
+}
+
+// FanOut sends a request to many bidders concurrently.
+type FanOut struct {
+	Bidders       []FanOutBidder
+	NetworkBuffer time.Duration // Subtracted from TMax to allow for returning the merged response
+}
+
+// Run sends a deep copy of the request to each bidder, after applying its adapters, and
+// returns the results in the order of the bidders. It returns when all bidders have responded
+// or the deadline derived from TMax minus the network buffer expired, whichever is first.
+// Bidders which have not responded until then are reported with OutcomeTimeout. If the
+// network buffer leaves no time, no requests are sent and all bidders time out.
+func (f *FanOut) Run(ctx context.Context, req *BidRequest) []FanOutResult {
+	start := time.Now()
+	expired := false
+	if req.TMax > 0 {
+		timeout := time.Duration(req.TMax)*time.Millisecond - f.NetworkBuffer
+		if expired = timeout <= 0; !expired {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	type indexed struct {
+		i   int
+		res *BidderResult
+	}
+	done := make(chan indexed, len(f.Bidders))
+
+	results := make([]FanOutResult, len(f.Bidders))
+	pending := 0
+	for i, b := range f.Bidders {
+		results[i].Bidder = b.Name
+		breq, err := adapt(req, b.Adapters)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Request = breq
+		if expired {
+			results[i].Result = &BidderResult{Outcome: OutcomeTimeout, Err: ErrBidderTimeout}
+			continue
+		}
+
+		pending++
+		go func(i int, s Sender) {
+			done <- indexed{i: i, res: s.Send(ctx, breq)}
+		}(i, b.Sender)
+	}
+
+	for ; pending > 0; pending-- {
+		select {
+		case d := <-done:
+			results[d.i].Result = d.res
+		case <-ctx.Done():
+			for i := range results {
+				if results[i].Request != nil && results[i].Result == nil {
+					results[i].Result = &BidderResult{Outcome: OutcomeTimeout, Latency: time.Since(start), Err: ErrBidderTimeout}
+				}
+			}
+			return results
+		}
+	}
+	return results
+}
+
+func adapt(req *BidRequest, adapters []Adapter) (*BidRequest, error) {
+	out := req.Clone()
+	for _, a := range adapters {
+		if err := a(out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type senderFunc func(ctx context.Context, req *BidRequest) *BidderResult
+
+func (f senderFunc) Send(ctx context.Context, req *BidRequest) *BidderResult { return f(ctx, req) }
+
+func TestFanOut_Run(t *testing.T) {
+	respond := func(delay time.Duration, outcome Outcome) Sender {
+		return senderFunc(func(ctx context.Context, req *BidRequest) *BidderResult {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return &BidderResult{Outcome: OutcomeTimeout, Err: ErrBidderTimeout}
+			}
+			return &BidderResult{Outcome: outcome, Response: &BidResponse{ID: req.ID, Currency: req.Seats[0]}}
+		})
+	}
+	failed := errors.New("failed")
+
+	f := &FanOut{NetworkBuffer: 20 * time.Millisecond, Bidders: []FanOutBidder{
+		{Name: "slow", Sender: respond(500*time.Millisecond, OutcomeBid), Adapters: []Adapter{FilterSeats("s1")}},
+		{Name: "late", Sender: respond(40*time.Millisecond, OutcomeBid), Adapters: []Adapter{FilterSeats("s2")}},
+		{Name: "fast", Sender: respond(0, OutcomeNoBid), Adapters: []Adapter{FilterSeats("s3")}},
+		{Name: "blocked", Sender: respond(0, OutcomeBid), Adapters: []Adapter{FilterSeats("s4")}},
+		{Name: "failed", Sender: respond(0, OutcomeBid), Adapters: []Adapter{func(*BidRequest) error { return failed }}},
+		{Name: "converted", Sender: respond(0, OutcomeBid), Adapters: []Adapter{
+			FilterImpressions(func(imp *Impression) bool { return imp.Video != nil }),
+			ConvertVersion("2.5"),
+			FilterSeats("s5"),
+		}},
+	}}
+	req := &BidRequest{
+		ID:           "1",
+		TMax:         120,
+		Impressions:  []Impression{{ID: "1", Banner: &Banner{}}, {ID: "2", Video: &Video{}}},
+		Regulations:  &Regulations{GDPR: 1},
+		BlockedSeats: []string{"s4"},
+	}
+
+	results := f.Run(context.Background(), req)
+	if len(results) != 6 {
+		t.Fatalf("expected 6 results, got %d", len(results))
+	}
+	for i, tc := range []struct {
+		outcome Outcome
+		seat    string
+		err     error
+	}{
+		{OutcomeTimeout, "s1", nil},
+		{OutcomeBid, "s2", nil},
+		{OutcomeNoBid, "s3", nil},
+		{0, "", ErrSkipBidder},
+		{0, "", failed},
+		{OutcomeBid, "s5", nil},
+	} {
+		res := results[i]
+		if res.Bidder != f.Bidders[i].Name || res.Err != tc.err {
+			t.Errorf("%d: expected bidder %s, error %v, got %s, %v", i, f.Bidders[i].Name, tc.err, res.Bidder, res.Err)
+			continue
+		}
+		if tc.err != nil {
+			if res.Request != nil || res.Result != nil {
+				t.Errorf("%s: expected no request and result, got %+v", res.Bidder, res)
+			}
+			continue
+		}
+		if res.Result == nil || res.Result.Outcome != tc.outcome || !reflect.DeepEqual(res.Request.Seats, []string{tc.seat}) {
+			t.Errorf("%s: expected %v for seat %s, got %+v", res.Bidder, tc.outcome, tc.seat, res)
+		}
+		if tc.outcome != OutcomeTimeout && res.Result.Response.Currency != tc.seat {
+			t.Errorf("%s: unexpected response %+v", res.Bidder, res.Result.Response)
+		}
+	}
+	if results[0].Result.Err != ErrBidderTimeout || results[0].Result.Latency <= 0 {
+		t.Errorf("unexpected timeout result %+v", results[0].Result)
+	}
+
+	// adapters change copies of the request
+	conv := results[5].Request
+	if len(conv.Impressions) != 1 || conv.Impressions[0].ID != "2" || conv.Regulations.GDPR != 0 || string(conv.Regulations.Ext) != `{"gdpr":1}` {
+		t.Errorf("unexpected adapted request %+v", conv)
+	}
+	if len(req.Impressions) != 2 || len(req.Seats) != 0 || req.Regulations.GDPR != 1 || req.Regulations.Ext != nil {
+		t.Errorf("request was modified: %+v", req)
+	}
+}
+
+func TestFanOut_Run_noTime(t *testing.T) {
+	var sent int32
+	sender := senderFunc(func(ctx context.Context, req *BidRequest) *BidderResult {
+		atomic.AddInt32(&sent, 1)
+		return &BidderResult{Outcome: OutcomeNoContent}
+	})
+	f := &FanOut{NetworkBuffer: 100 * time.Millisecond, Bidders: []FanOutBidder{
+		{Name: "a", Sender: sender},
+		{Name: "b", Sender: sender, Adapters: []Adapter{FilterSeats("s1")}},
+	}}
+
+	results := f.Run(context.Background(), &BidRequest{ID: "1", TMax: 100, BlockedSeats: []string{"s1"}})
+	if sent != 0 {
+		t.Fatalf("expected no requests to be sent, got %d", sent)
+	}
+	if res := results[0]; res.Request == nil || res.Result == nil || res.Result.Outcome != OutcomeTimeout || res.Result.Err != ErrBidderTimeout {
+		t.Errorf("expected timeout, got %+v", res)
+	}
+	if res := results[1]; res.Err != ErrSkipBidder || res.Result != nil {
+		t.Errorf("expected skipped bidder, got %+v", res)
+	}
+
+	// without TMax, bidders are only bounded by the context
+	results = f.Run(context.Background(), &BidRequest{ID: "1"})
+	if sent != 2 || results[0].Result.Outcome != OutcomeNoContent || results[1].Result.Outcome != OutcomeNoContent {
+		t.Fatalf("expected both bidders to respond, got %d requests, %+v", sent, results)
+	}
+}
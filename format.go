@@ -9,8 +9,8 @@ import (
 type Format struct {
 	Width       int             `json:"w,omitempty"`      // Width in device independent pixels (DIPS).
 	Height      int             `json:"h,omitempty"`      // Height in device independent pixels (DIPS).
-	WidthRatio  int             `json:"wwratioomitempty"` // Relative width when expressing size as a ratio.
+	WidthRatio  int             `json:"wratio,omitempty"` // Relative width when expressing size as a ratio.
 	HeightRatio int             `json:"hratio,omitempty"` // Relative height when expressing size as a ratio.
-	wmin        int             `json:"wmin,omitempty"`   // The minimum width in device independent pixels (DIPS) at which the ad will be displayed the size is expressed as a ratio.
+	WidthMin    int             `json:"wmin,omitempty"`   // The minimum width in device independent pixels (DIPS) at which the ad will be displayed the size is expressed as a ratio.
 	Ext         json.RawMessage `json:"ext,omitempty"`
 }
@@ -0,0 +1,297 @@
+package openrtb
+
+import (
+	"errors"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrInvalidGPP        = errors.New("openrtb: invalid GPP string")
+	ErrInvalidGPPHeader  = errors.New("openrtb: invalid GPP header")
+	ErrInvalidGPPSection = errors.New("openrtb: invalid GPP section")
+)
+
+// GPPSectionID identifies a section of a Global Privacy Platform string, as registered
+// by the IAB Tech Lab GPP section information list.
+type GPPSectionID int
+
+// GPPSectionID values.
+const (
+	GPPSectionTCFEUv1 GPPSectionID = 1  // EU TCF v1 (deprecated)
+	GPPSectionTCFEUv2 GPPSectionID = 2  // EU TCF v2
+	GPPSectionHeader  GPPSectionID = 3  // GPP header
+	GPPSectionTCFCAv1 GPPSectionID = 5  // Canadian TCF
+	GPPSectionUSPv1   GPPSectionID = 6  // US Privacy (CCPA) string
+	GPPSectionUSNat   GPPSectionID = 7  // US national
+	GPPSectionUSCA    GPPSectionID = 8  // California
+	GPPSectionUSVA    GPPSectionID = 9  // Virginia
+	GPPSectionUSCO    GPPSectionID = 10 // Colorado
+	GPPSectionUSUT    GPPSectionID = 11 // Utah
+	GPPSectionUSCT    GPPSectionID = 12 // Connecticut
+)
+
+// GPP is a decoded Global Privacy Platform string as carried in Regulations.GPP.
+// Sections without a dedicated decoder are retained in their raw encoded form.
+type GPP struct {
+	Version    int                     // Version of the GPP header
+	SectionIDs []GPPSectionID          // IDs of the sections contained, in order
+	Sections   map[GPPSectionID]string // Raw encoded sections by ID
+	TCFEUv2    *TCFConsent             // Decoded TCF EU v2 section, if present
+	USPrivacy  *USPrivacy              // Decoded US Privacy section, if present
+	US         []*GPPUSSection         // Decoded US national and state sections, in order
+}
+
+// ParseGPP decodes a GPP string consisting of a header followed by the encoded sections, separated by '~'.
+func ParseGPP(s string) (*GPP, error) {
+	parts := strings.Split(s, "~")
+
+	r, err := newBitReader(parts[0])
+	if err != nil {
+		return nil, ErrInvalidGPPHeader
+	}
+	if r.int(6) != int(GPPSectionHeader) {
+		return nil, ErrInvalidGPPHeader
+	}
+
+	g := &GPP{Version: r.int(6)}
+	for n := r.int(12); n > 0 && r.err == nil; n-- {
+		isRange := r.bool()
+		start := r.fibonacci()
+		if len(g.SectionIDs) > 0 {
+			start += int(g.SectionIDs[len(g.SectionIDs)-1])
+		}
+		end := start
+		if isRange {
+			end = start + r.fibonacci()
+		}
+		for id := start; id <= end; id++ {
+			g.SectionIDs = append(g.SectionIDs, GPPSectionID(id))
+		}
+	}
+	if r.err != nil {
+		return nil, ErrInvalidGPPHeader
+	}
+	if len(g.SectionIDs) != len(parts)-1 {
+		return nil, ErrInvalidGPP
+	}
+
+	g.Sections = make(map[GPPSectionID]string, len(g.SectionIDs))
+	for i, id := range g.SectionIDs {
+		raw := parts[i+1]
+		g.Sections[id] = raw
+
+		switch id {
+		case GPPSectionTCFEUv2:
+			if g.TCFEUv2, err = ParseTCFConsent(raw); err != nil {
+				return nil, err
+			}
+		case GPPSectionUSPv1:
+			if g.USPrivacy, err = ParseUSPrivacy(raw); err != nil {
+				return nil, err
+			}
+		default:
+			if _, ok := gppUSLayouts[id]; ok {
+				sec, err := parseGPPUSSection(id, raw)
+				if err != nil {
+					return nil, err
+				}
+				g.US = append(g.US, sec)
+			}
+		}
+	}
+	return g, nil
+}
+
+// HasSection returns true if the GPP string contains the given section
+func (g *GPP) HasSection(id GPPSectionID) bool {
+	_, ok := g.Sections[id]
+	return ok
+}
+
+// SaleOptOut returns true if any of the given applicable sections signals an opt-out of the sale
+// of personal data. When no applicable sections are passed, all sections are considered.
+func (g *GPP) SaleOptOut(applicable ...GPPSectionID) bool {
+	if g.USPrivacy != nil && isApplicable(GPPSectionUSPv1, applicable) && g.USPrivacy.IsOptOut() {
+		return true
+	}
+	for _, sec := range g.US {
+		if isApplicable(sec.ID, applicable) && (sec.SaleOptOut == GPPOptedOut || sec.GPC) {
+			return true
+		}
+	}
+	return false
+}
+
+// SharingOptOut returns true if any of the given applicable sections signals an opt-out of the sharing
+// of personal data or of targeted advertising. When no applicable sections are passed, all sections are considered.
+func (g *GPP) SharingOptOut(applicable ...GPPSectionID) bool {
+	for _, sec := range g.US {
+		if isApplicable(sec.ID, applicable) && (sec.SharingOptOut == GPPOptedOut || sec.TargetedAdvertisingOptOut == GPPOptedOut || sec.GPC) {
+			return true
+		}
+	}
+	return false
+}
+
+func isApplicable(id GPPSectionID, applicable []GPPSectionID) bool {
+	if len(applicable) == 0 {
+		return true
+	}
+	for _, a := range applicable {
+		if a == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GPP notice and opt-out field values, shared by the US national and state sections.
+const (
+	GPPNotApplicable = 0 // Not applicable
+	GPPOptedOut      = 1 // Opted out (for opt-out fields), notice provided (for notice fields)
+	GPPDidNotOptOut  = 2 // Did not opt out (for opt-out fields), notice not provided (for notice fields)
+)
+
+// GPPUSSection is a decoded US national (usnat) or US state (usca, usva, usco, usut, usct) GPP section.
+// Fields not defined by a particular section are left zero, i.e. not applicable.
+type GPPUSSection struct {
+	ID                                  GPPSectionID
+	Version                             int
+	SharingNotice                       int
+	SaleOptOutNotice                    int
+	SharingOptOutNotice                 int
+	TargetedAdvertisingOptOutNotice     int
+	SensitiveDataProcessingOptOutNotice int
+	SensitiveDataLimitUseNotice         int
+	SaleOptOut                          int
+	SharingOptOut                       int
+	TargetedAdvertisingOptOut           int
+	SensitiveDataProcessing             []int
+	KnownChildSensitiveDataConsents     []int
+	PersonalDataConsents                int
+	MspaCoveredTransaction              int
+	MspaOptOutOptionMode                int
+	MspaServiceProviderMode             int
+	GPC                                 bool // Global Privacy Control signal, from the optional GPC sub-section
+}
+
+// gppUSField is a field of a US section, accessed through a function returning its address.
+type gppUSField struct {
+	scalar func(s *GPPUSSection) *int   // set for scalar fields
+	list   func(s *GPPUSSection) *[]int // set for list fields
+	count  int                          // number of 2-bit entries of list fields
+}
+
+// Fields of the US sections.
+var (
+	gppSharingNotice                       = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.SharingNotice }}
+	gppSaleOptOutNotice                    = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.SaleOptOutNotice }}
+	gppSharingOptOutNotice                 = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.SharingOptOutNotice }}
+	gppTargetedAdvertisingOptOutNotice     = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.TargetedAdvertisingOptOutNotice }}
+	gppSensitiveDataProcessingOptOutNotice = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.SensitiveDataProcessingOptOutNotice }}
+	gppSensitiveDataLimitUseNotice         = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.SensitiveDataLimitUseNotice }}
+	gppSaleOptOut                          = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.SaleOptOut }}
+	gppSharingOptOut                       = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.SharingOptOut }}
+	gppTargetedAdvertisingOptOut           = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.TargetedAdvertisingOptOut }}
+	gppPersonalDataConsents                = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.PersonalDataConsents }}
+	gppMspaCoveredTransaction              = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.MspaCoveredTransaction }}
+	gppMspaOptOutOptionMode                = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.MspaOptOutOptionMode }}
+	gppMspaServiceProviderMode             = gppUSField{scalar: func(s *GPPUSSection) *int { return &s.MspaServiceProviderMode }}
+)
+
+func gppSensitiveDataProcessing(count int) gppUSField {
+	return gppUSField{list: func(s *GPPUSSection) *[]int { return &s.SensitiveDataProcessing }, count: count}
+}
+
+func gppKnownChildSensitiveDataConsents(count int) gppUSField {
+	return gppUSField{list: func(s *GPPUSSection) *[]int { return &s.KnownChildSensitiveDataConsents }, count: count}
+}
+
+type gppUSLayout struct {
+	fields []gppUSField
+	gpc    bool // supports the GPC sub-section
+}
+
+var gppUSLayouts = map[GPPSectionID]gppUSLayout{
+	GPPSectionUSNat: {gpc: true, fields: []gppUSField{
+		gppSharingNotice, gppSaleOptOutNotice, gppSharingOptOutNotice, gppTargetedAdvertisingOptOutNotice,
+		gppSensitiveDataProcessingOptOutNotice, gppSensitiveDataLimitUseNotice,
+		gppSaleOptOut, gppSharingOptOut, gppTargetedAdvertisingOptOut,
+		gppSensitiveDataProcessing(12), gppKnownChildSensitiveDataConsents(2), gppPersonalDataConsents,
+		gppMspaCoveredTransaction, gppMspaOptOutOptionMode, gppMspaServiceProviderMode,
+	}},
+	GPPSectionUSCA: {gpc: true, fields: []gppUSField{
+		gppSaleOptOutNotice, gppSharingOptOutNotice, gppSensitiveDataLimitUseNotice,
+		gppSaleOptOut, gppSharingOptOut,
+		gppSensitiveDataProcessing(9), gppKnownChildSensitiveDataConsents(2), gppPersonalDataConsents,
+		gppMspaCoveredTransaction, gppMspaOptOutOptionMode, gppMspaServiceProviderMode,
+	}},
+	GPPSectionUSVA: {fields: []gppUSField{
+		gppSharingNotice, gppSaleOptOutNotice, gppTargetedAdvertisingOptOutNotice,
+		gppSaleOptOut, gppTargetedAdvertisingOptOut,
+		gppSensitiveDataProcessing(8), gppKnownChildSensitiveDataConsents(1),
+		gppMspaCoveredTransaction, gppMspaOptOutOptionMode, gppMspaServiceProviderMode,
+	}},
+	GPPSectionUSCO: {gpc: true, fields: []gppUSField{
+		gppSharingNotice, gppSaleOptOutNotice, gppTargetedAdvertisingOptOutNotice,
+		gppSaleOptOut, gppTargetedAdvertisingOptOut,
+		gppSensitiveDataProcessing(7), gppKnownChildSensitiveDataConsents(1),
+		gppMspaCoveredTransaction, gppMspaOptOutOptionMode, gppMspaServiceProviderMode,
+	}},
+	GPPSectionUSUT: {fields: []gppUSField{
+		gppSharingNotice, gppSaleOptOutNotice, gppTargetedAdvertisingOptOutNotice,
+		gppSensitiveDataProcessingOptOutNotice, gppSaleOptOut, gppTargetedAdvertisingOptOut,
+		gppSensitiveDataProcessing(8), gppKnownChildSensitiveDataConsents(1),
+		gppMspaCoveredTransaction, gppMspaOptOutOptionMode, gppMspaServiceProviderMode,
+	}},
+	GPPSectionUSCT: {gpc: true, fields: []gppUSField{
+		gppSharingNotice, gppSaleOptOutNotice, gppTargetedAdvertisingOptOutNotice,
+		gppSaleOptOut, gppTargetedAdvertisingOptOut,
+		gppSensitiveDataProcessing(8), gppKnownChildSensitiveDataConsents(3),
+		gppMspaCoveredTransaction, gppMspaOptOutOptionMode, gppMspaServiceProviderMode,
+	}},
+}
+
+func parseGPPUSSection(id GPPSectionID, s string) (*GPPUSSection, error) {
+	layout := gppUSLayouts[id]
+	parts := strings.Split(s, ".")
+
+	r, err := newBitReader(parts[0])
+	if err != nil {
+		return nil, ErrInvalidGPPSection
+	}
+
+	sec := &GPPUSSection{ID: id, Version: r.int(6)}
+	for _, f := range layout.fields {
+		if f.scalar != nil {
+			*f.scalar(sec) = r.int(2)
+			continue
+		}
+
+		vals := make([]int, f.count)
+		for i := range vals {
+			vals[i] = r.int(2)
+		}
+		*f.list(sec) = vals
+	}
+	if r.err != nil {
+		return nil, ErrInvalidGPPSection
+	}
+
+	if layout.gpc {
+		for _, sub := range parts[1:] {
+			r, err := newBitReader(sub)
+			if err != nil {
+				return nil, ErrInvalidGPPSection
+			}
+			if r.int(2) == 1 {
+				sec.GPC = r.bool()
+			}
+			if r.err != nil {
+				return nil, ErrInvalidGPPSection
+			}
+		}
+	}
+	return sec, nil
+}
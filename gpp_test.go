@@ -0,0 +1,90 @@
+package openrtb
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Examples from the IAB GPP specification.
+func TestParseGPP(t *testing.T) {
+	g, err := ParseGPP("DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Version != 1 || !reflect.DeepEqual(g.SectionIDs, []GPPSectionID{GPPSectionTCFEUv2, GPPSectionUSPv1}) {
+		t.Fatalf("unexpected header: version %d, sections %v", g.Version, g.SectionIDs)
+	}
+	if !g.HasSection(GPPSectionTCFEUv2) || !g.HasSection(GPPSectionUSPv1) || g.HasSection(GPPSectionUSNat) {
+		t.Fatalf("unexpected sections %v", g.Sections)
+	}
+	if g.TCFEUv2 == nil || g.TCFEUv2.CMPID != 31 || g.TCFEUv2.PublisherCC != "DE" {
+		t.Fatalf("unexpected TCF section %+v", g.TCFEUv2)
+	}
+	if g.USPrivacy == nil || g.USPrivacy.String() != "1YNN" {
+		t.Fatalf("unexpected US privacy section %+v", g.USPrivacy)
+	}
+	if g.SaleOptOut() || g.SharingOptOut() {
+		t.Fatal("expected no opt-out")
+	}
+}
+
+func TestParseGPP_usNat(t *testing.T) {
+	g, err := ParseGPP("DBABL~BVVqAAEABgA.QA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.US) != 1 {
+		t.Fatalf("expected one US section, got %d", len(g.US))
+	}
+	exp := &GPPUSSection{
+		ID:                                  GPPSectionUSNat,
+		Version:                             1,
+		SharingNotice:                       1,
+		SaleOptOutNotice:                    1,
+		SharingOptOutNotice:                 1,
+		TargetedAdvertisingOptOutNotice:     1,
+		SensitiveDataProcessingOptOutNotice: 1,
+		SensitiveDataLimitUseNotice:         1,
+		SaleOptOut:                          2,
+		SharingOptOut:                       2,
+		TargetedAdvertisingOptOut:           2,
+		SensitiveDataProcessing:             []int{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0},
+		KnownChildSensitiveDataConsents:     []int{0, 0},
+		PersonalDataConsents:                1,
+		MspaCoveredTransaction:              2,
+	}
+	if !reflect.DeepEqual(g.US[0], exp) {
+		t.Fatalf("expected %+v, got %+v", exp, g.US[0])
+	}
+}
+
+func TestParseGPP_usNatOptOut(t *testing.T) {
+	g, err := ParseGPP("DBABzw~1YNN~BqqQqqqqqqA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.US) != 1 || g.US[0].SensitiveDataProcessing[11] != 2 || g.US[0].MspaServiceProviderMode != 2 {
+		t.Fatalf("unexpected US sections %+v", g.US)
+	}
+	if g.US[0].SaleOptOut != GPPOptedOut || !g.SaleOptOut() || !g.SaleOptOut(GPPSectionUSNat) {
+		t.Fatal("expected sale opt-out")
+	}
+	if g.SaleOptOut(GPPSectionUSPv1) || g.SharingOptOut() {
+		t.Fatal("expected no opt-out of the US privacy section or of sharing")
+	}
+}
+
+func TestParseGPP_invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"~",
+		"DBACNY",
+		"DBABM~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN",
+		"DBABL~B",
+		"DBABL~BVVq!AEABgA",
+	} {
+		if _, err := ParseGPP(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package openrtb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrRequestTooLarge is reported when a bid request exceeds the size limit of a Handler.
+var ErrRequestTooLarge = errors.New("openrtb: bid request too large")
+
+// Bidder responds to bid requests. A nil response, or one without seat bids, is a no-bid.
+type Bidder interface {
+	Bid(ctx context.Context, req *BidRequest) (*BidResponse, error)
+}
+
+// BidderFunc is a function implementing Bidder.
+type BidderFunc func(ctx context.Context, req *BidRequest) (*BidResponse, error)
+
+// Bid implements Bidder
+func (f BidderFunc) Bid(ctx context.Context, req *BidRequest) (*BidResponse, error) {
+	return f(ctx, req)
+}
+
+// HandlerStats describes the handling of a single bid request.
+type HandlerStats struct {
+	Request    *BidRequest   // Decoded request, nil if it could not be decoded
+	Response   *BidResponse  // Response written, nil for 204 and errors
+	StatusCode int           // HTTP status written
+	NBR        NBR           // No-bid reason written, if any
+	Err        error         // Decoding, validation or bidder error
+	Decode     time.Duration // Time spent reading and decoding the request
+	Bid        time.Duration // Time spent in the Bidder
+	Total      time.Duration // Total time until the response was written
+}
+
+// Handler is an http.Handler receiving bid requests for a Bidder.
+//
+// Requests are decoded, gzip-compressed ones as well, and validated. Invalid requests are answered
+// with a no-bid for NBRInvalidRequest, requests which cannot be decoded with 400 Bad Request.
+// The Bidder is called with a context bounded by TMax minus the network buffer, its errors are
+// answered with a no-bid for NBRTechnicalError, as are requests leaving it no time. No-bids are answered with 204 No Content, or with
+// a response carrying only the no-bid reason if one is known.
+type Handler struct {
+	Bidder         Bidder
+	MaxRequestSize int64               // Maximum accepted request size, before and after decompression, defaults to 1MB
+	NetworkBuffer  time.Duration       // Subtracted from TMax to allow for network latency
+	Version        string              // Value of the x-openrtb-version response header, defaults to DefaultVersion
+	Gzip           bool                // Compress responses if the client accepts gzip
+	OnComplete     func(*HandlerStats) // Called after each request, e.g. to record timing
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	stats := new(HandlerStats)
+	h.serve(w, r, stats, start)
+	stats.Total = time.Since(start)
+	if h.OnComplete != nil {
+		h.OnComplete(stats)
+	}
+}
+
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, stats *HandlerStats, start time.Time) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.writeError(w, stats, http.StatusMethodNotAllowed, nil)
+		return
+	}
+
+	req, err := h.decode(r)
+	stats.Decode = time.Since(start)
+	switch {
+	case err == ErrRequestTooLarge:
+		h.writeError(w, stats, http.StatusRequestEntityTooLarge, err)
+		return
+	case err != nil:
+		h.writeError(w, stats, http.StatusBadRequest, err)
+		return
+	}
+	stats.Request = req
+
+	if err := req.Validate(); err != nil {
+		stats.Err = err
+		h.writeNoBid(w, r, stats, NBRInvalidRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if req.TMax > 0 {
+		timeout := time.Duration(req.TMax)*time.Millisecond - h.NetworkBuffer
+		if timeout <= 0 {
+			stats.Err = context.DeadlineExceeded
+			h.writeNoBid(w, r, stats, NBRTechnicalError)
+			return
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	bidStart := time.Now()
+	resp, err := h.Bidder.Bid(ctx, req)
+	stats.Bid = time.Since(bidStart)
+	switch {
+	case err != nil:
+		stats.Err = err
+		h.writeNoBid(w, r, stats, NBRTechnicalError)
+	case resp == nil:
+		h.writeNoBid(w, r, stats, NBRUnknownError)
+	case len(resp.SeatBids) == 0:
+		h.writeNoBid(w, r, stats, resp.NBR)
+	default:
+		if resp.ID == "" {
+			// the response may be shared by the bidder, so fill the ID in a copy
+			cp := *resp
+			cp.ID = req.ID
+			resp = &cp
+		}
+		h.write(w, r, stats, resp)
+	}
+}
+
+func (h *Handler) decode(r *http.Request) (*BidRequest, error) {
+	maxSize := h.MaxRequestSize
+	if maxSize <= 0 {
+		maxSize = 1 << 20
+	}
+
+	var body io.Reader = &sizeLimitReader{r: r.Body, n: maxSize}
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(body)
+		if errors.Is(err, ErrRequestTooLarge) {
+			return nil, ErrRequestTooLarge
+		} else if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		body = &sizeLimitReader{r: zr, n: maxSize}
+	}
+
+	data, err := io.ReadAll(body)
+	if errors.Is(err, ErrRequestTooLarge) {
+		return nil, ErrRequestTooLarge
+	} else if err != nil {
+		return nil, err
+	}
+
+	req := new(BidRequest)
+	if err := req.DecodeJSON(data); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// sizeLimitReader reads up to n bytes and fails with ErrRequestTooLarge if there are more.
+type sizeLimitReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	if l.n -= int64(n); l.n < 0 {
+		return n, ErrRequestTooLarge
+	}
+	return n, err
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, stats *HandlerStats, code int, err error) {
+	stats.StatusCode, stats.Err = code, err
+	http.Error(w, http.StatusText(code), code)
+}
+
+// writeNoBid answers with the no-bid reason, or with 204 if it is unknown, as an
+// unknown reason cannot be told apart from a missing one.
+func (h *Handler) writeNoBid(w http.ResponseWriter, r *http.Request, stats *HandlerStats, nbr NBR) {
+	if nbr == NBRUnknownError {
+		stats.StatusCode = http.StatusNoContent
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	stats.NBR = nbr
+	h.write(w, r, stats, &BidResponse{ID: stats.Request.ID, NBR: nbr})
+}
+
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, stats *HandlerStats, resp *BidResponse) {
+	data, err := resp.AppendJSON(nil)
+	if err != nil {
+		h.writeError(w, stats, http.StatusInternalServerError, err)
+		return
+	}
+
+	version := h.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(VersionHeader, version)
+
+	if h.Gzip && acceptsGzip(r) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			h.writeError(w, stats, http.StatusInternalServerError, err)
+			return
+		}
+		if err := zw.Close(); err != nil {
+			h.writeError(w, stats, http.StatusInternalServerError, err)
+			return
+		}
+		data = buf.Bytes()
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+
+	stats.Response, stats.StatusCode = resp, http.StatusOK
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept-Encoding") {
+		for _, enc := range strings.Split(v, ",") {
+			if name, _, _ := strings.Cut(strings.TrimSpace(enc), ";"); name == "gzip" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package openrtb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const handlerRequest = `{"id":"req","imp":[{"id":"1","banner":{"w":300,"h":250}}],"site":{"id":"site"}}`
+
+func serveHandler(h *Handler, method, body string, header http.Header) (*httptest.ResponseRecorder, *HandlerStats) {
+	var stats *HandlerStats
+	h.OnComplete = func(s *HandlerStats) { stats = s }
+
+	r := httptest.NewRequest(method, "/", strings.NewReader(body))
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w, stats
+}
+
+func gzipString(s string) string {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(s))
+	zw.Close()
+	return buf.String()
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	shared := &BidResponse{SeatBids: []SeatBid{{Bids: []Bid{{ID: "1", ImpID: "1", Price: 1.5}}}}}
+	h := &Handler{Bidder: BidderFunc(func(_ context.Context, req *BidRequest) (*BidResponse, error) {
+		switch req.Site.ID {
+		case "nobid":
+			return &BidResponse{ID: req.ID, NBR: NBRSuspectedNonHuman}, nil
+		case "none":
+			return nil, nil
+		case "error":
+			return nil, errors.New("failed")
+		}
+		return shared, nil
+	})}
+
+	w, stats := serveHandler(h, http.MethodPost, handlerRequest, nil)
+	if w.Code != http.StatusOK || stats.Response == nil || stats.Response.ID != "req" {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body)
+	}
+	if shared.ID != "" {
+		t.Fatalf("bidder response was modified: %+v", shared)
+	}
+	var resp BidResponse
+	if err := resp.DecodeJSON(w.Body.Bytes()); err != nil || resp.ID != "req" || len(resp.SeatBids) != 1 {
+		t.Fatalf("unexpected response %s, %v", w.Body, err)
+	}
+
+	for _, tc := range []struct {
+		site   string
+		status int
+		nbr    NBR
+	}{
+		{"nobid", http.StatusOK, NBRSuspectedNonHuman},
+		{"none", http.StatusNoContent, 0},
+		{"error", http.StatusOK, NBRTechnicalError},
+	} {
+		w, stats := serveHandler(h, http.MethodPost, strings.Replace(handlerRequest, `"id":"site"`, `"id":"`+tc.site+`"`, 1), nil)
+		if w.Code != tc.status || stats.NBR != tc.nbr {
+			t.Errorf("%s: expected %d, %d, got %d, %d", tc.site, tc.status, tc.nbr, w.Code, stats.NBR)
+		}
+	}
+}
+
+func TestHandler_ServeHTTP_errors(t *testing.T) {
+	h := &Handler{
+		Bidder:         BidderFunc(func(context.Context, *BidRequest) (*BidResponse, error) { return nil, nil }),
+		MaxRequestSize: 200,
+	}
+	gz := http.Header{"Content-Encoding": {"gzip"}}
+
+	if w, _ := serveHandler(h, http.MethodGet, "", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+	if w, _ := serveHandler(h, http.MethodPost, `{"id":`, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+	if w, _ := serveHandler(h, http.MethodPost, "not gzip", gz); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid gzip, got %d", w.Code)
+	}
+	if w, _ := serveHandler(h, http.MethodPost, gzipString(handlerRequest), gz); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204 for gzip, got %d", w.Code)
+	}
+
+	large := `{"id":"` + strings.Repeat("x", 300) + `"}`
+	if w, stats := serveHandler(h, http.MethodPost, large, nil); w.Code != http.StatusRequestEntityTooLarge || stats.Err != ErrRequestTooLarge {
+		t.Errorf("expected 413, got %d", w.Code)
+	}
+	// compressed size within the limit, decompressed size over it
+	if w, stats := serveHandler(h, http.MethodPost, gzipString(large), gz); w.Code != http.StatusRequestEntityTooLarge || stats.Err != ErrRequestTooLarge {
+		t.Errorf("expected 413 for decompressed size, got %d", w.Code)
+	}
+	// compressed size over the limit
+	random := make([]byte, 300)
+	for i := range random {
+		random[i] = byte(i*7919>>3) ^ byte(i)
+	}
+	body := gzipString(string(random))
+	if len(body) <= 200 {
+		t.Fatalf("compressed body too small: %d", len(body))
+	}
+	if w, stats := serveHandler(h, http.MethodPost, body, gz); w.Code != http.StatusRequestEntityTooLarge || stats.Err != ErrRequestTooLarge {
+		t.Errorf("expected 413 for compressed size, got %d, %v", w.Code, stats.Err)
+	}
+}
+
+func TestHandler_ServeHTTP_networkBuffer(t *testing.T) {
+	called := false
+	h := &Handler{NetworkBuffer: 100 * time.Millisecond, Bidder: BidderFunc(func(ctx context.Context, req *BidRequest) (*BidResponse, error) {
+		called = true
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) <= 0 {
+			t.Errorf("expected a deadline in the future, got %v, %v", deadline, ok)
+		}
+		return nil, nil
+	})}
+
+	w, stats := serveHandler(h, http.MethodPost, strings.Replace(handlerRequest, `"id":"req"`, `"id":"req","tmax":100`, 1), nil)
+	if called || w.Code != http.StatusOK || stats.NBR != NBRTechnicalError || stats.Err != context.DeadlineExceeded {
+		t.Fatalf("expected no-bid without calling the bidder, got %d %s, %v", w.Code, w.Body, stats.Err)
+	}
+
+	w, _ = serveHandler(h, http.MethodPost, strings.Replace(handlerRequest, `"id":"req"`, `"id":"req","tmax":1000`, 1), nil)
+	if !called || w.Code != http.StatusNoContent {
+		t.Fatalf("expected the bidder to be called, got %d %s", w.Code, w.Body)
+	}
+}
@@ -8,7 +8,7 @@ import (
 // Validation errors
 var (
 	ErrInvalidImpNoID        = errors.New("openrtb: impression ID missing")
-	ErrInvalidImpMultiAssets = errors.New("openrtb: impression has multiple assets") // at least two out of Banner, Video, Native
+	ErrInvalidImpMultiAssets = errors.New("openrtb: impression has multiple assets") // Deprecated: multi-format impressions are valid, it is no longer returned
 )
 
 // Impression or the "imp" object describes the ad position or impression being auctioned. A single bid request
@@ -41,35 +41,23 @@ type Impression struct {
 	Ext                   json.RawMessage `json:"ext,omitempty"`
 }
 
-func (imp *Impression) assetCount() int {
-	n := 0
-	if imp.Banner != nil {
-		n++
-	}
-	if imp.Video != nil {
-		n++
-	}
-	if imp.Native != nil {
-		n++
-	}
-	return n
-}
-
-// Validate the `imp` object
+// Validate the `imp` object. Impressions may offer several media types, bids for
+// them are checked with Bid.CheckMediaType.
 func (imp *Impression) Validate() error {
 	if imp.ID == "" {
 		return ErrInvalidImpNoID
 	}
 
-	if count := imp.assetCount(); count > 1 {
-		return ErrInvalidImpMultiAssets
-	}
-
 	if imp.Video != nil {
 		if err := imp.Video.Validate(); err != nil {
 			return err
 		}
 	}
+	if imp.Audio != nil {
+		if err := imp.Audio.Validate(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,7287 @@
+// Code generated by jsongen; DO NOT EDIT.
+
+package openrtb
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *App) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *App) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *App) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Inventory.ID != "" {
+		w.field("\"id\":")
+		w.string(x.Inventory.ID)
+	}
+	if x.Inventory.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Inventory.Name)
+	}
+	if x.Inventory.Domain != "" {
+		w.field("\"domain\":")
+		w.string(x.Inventory.Domain)
+	}
+	if x.Inventory.CategoryTaxonomies != 0 {
+		w.field("\"cattax\":")
+		w.uint(uint64(x.Inventory.CategoryTaxonomies))
+	}
+	if len(x.Inventory.Categories) != 0 {
+		w.field("\"cat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.Categories {
+			w.elem()
+			w.string(string(x.Inventory.Categories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Inventory.SectionCategories) != 0 {
+		w.field("\"sectioncat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.SectionCategories {
+			w.elem()
+			w.string(string(x.Inventory.SectionCategories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Inventory.PageCategories) != 0 {
+		w.field("\"pagecat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.PageCategories {
+			w.elem()
+			w.string(string(x.Inventory.PageCategories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Inventory.PrivacyPolicy != nil {
+		w.field("\"privacypolicy\":")
+		w.int(int64(*x.Inventory.PrivacyPolicy))
+	}
+	if x.Inventory.Publisher != nil {
+		w.field("\"publisher\":")
+		x.Inventory.Publisher.encodeJSON(w)
+	}
+	if x.Inventory.Content != nil {
+		w.field("\"content\":")
+		x.Inventory.Content.encodeJSON(w)
+	}
+	if x.Inventory.Keywords != "" {
+		w.field("\"keywords\":")
+		w.string(x.Inventory.Keywords)
+	}
+	if len(x.Inventory.KeywordArray) != 0 {
+		w.field("\"kwarray\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.KeywordArray {
+			w.elem()
+			w.string(x.Inventory.KeywordArray[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Inventory.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Inventory.Ext)
+	}
+	if x.Bundle != "" {
+		w.field("\"bundle\":")
+		w.string(x.Bundle)
+	}
+	if x.StoreURL != "" {
+		w.field("\"storeurl\":")
+		w.string(x.StoreURL)
+	}
+	if x.Version != "" {
+		w.field("\"ver\":")
+		w.string(x.Version)
+	}
+	if x.Paid != 0 {
+		w.field("\"paid\":")
+		w.int(int64(x.Paid))
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonAppKeys = []string{"id", "name", "domain", "cattax", "cat", "sectioncat", "pagecat", "privacypolicy", "publisher", "content", "keywords", "kwarray", "ext", "bundle", "storeurl", "ver", "paid"}
+
+func (x *App) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.Inventory.ID)
+		case "name":
+			r.string(&x.Inventory.Name)
+		case "domain":
+			r.string(&x.Inventory.Domain)
+		case "cattax":
+			if n, ok := r.uint(0); ok {
+				x.Inventory.CategoryTaxonomies = uint(n)
+			}
+		case "cat":
+			seen |= 1 << 0
+			if r.null() {
+				x.Inventory.Categories = nil
+			} else {
+				x.Inventory.Categories = x.Inventory.Categories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.Categories)
+					if i < cap(x.Inventory.Categories) {
+						x.Inventory.Categories = x.Inventory.Categories[:i+1]
+					} else {
+						x.Inventory.Categories = append(x.Inventory.Categories, "")
+					}
+					r.string((*string)(&x.Inventory.Categories[i]))
+				}
+				if x.Inventory.Categories == nil {
+					x.Inventory.Categories = []ContentCategory{}
+				}
+			}
+		case "sectioncat":
+			seen |= 1 << 1
+			if r.null() {
+				x.Inventory.SectionCategories = nil
+			} else {
+				x.Inventory.SectionCategories = x.Inventory.SectionCategories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.SectionCategories)
+					if i < cap(x.Inventory.SectionCategories) {
+						x.Inventory.SectionCategories = x.Inventory.SectionCategories[:i+1]
+					} else {
+						x.Inventory.SectionCategories = append(x.Inventory.SectionCategories, "")
+					}
+					r.string((*string)(&x.Inventory.SectionCategories[i]))
+				}
+				if x.Inventory.SectionCategories == nil {
+					x.Inventory.SectionCategories = []ContentCategory{}
+				}
+			}
+		case "pagecat":
+			seen |= 1 << 2
+			if r.null() {
+				x.Inventory.PageCategories = nil
+			} else {
+				x.Inventory.PageCategories = x.Inventory.PageCategories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.PageCategories)
+					if i < cap(x.Inventory.PageCategories) {
+						x.Inventory.PageCategories = x.Inventory.PageCategories[:i+1]
+					} else {
+						x.Inventory.PageCategories = append(x.Inventory.PageCategories, "")
+					}
+					r.string((*string)(&x.Inventory.PageCategories[i]))
+				}
+				if x.Inventory.PageCategories == nil {
+					x.Inventory.PageCategories = []ContentCategory{}
+				}
+			}
+		case "privacypolicy":
+			if r.null() {
+				x.Inventory.PrivacyPolicy = nil
+			} else {
+				if x.Inventory.PrivacyPolicy == nil {
+					x.Inventory.PrivacyPolicy = new(int)
+				}
+				if n, ok := r.int(0); ok {
+					*x.Inventory.PrivacyPolicy = int(n)
+				}
+			}
+		case "publisher":
+			if r.null() {
+				x.Inventory.Publisher = nil
+			} else {
+				if x.Inventory.Publisher == nil {
+					x.Inventory.Publisher = r.alloc.newPublisher()
+				}
+				x.Inventory.Publisher.decodeJSON(r)
+			}
+		case "content":
+			if r.null() {
+				x.Inventory.Content = nil
+			} else {
+				if x.Inventory.Content == nil {
+					x.Inventory.Content = r.alloc.newContent()
+				}
+				x.Inventory.Content.decodeJSON(r)
+			}
+		case "keywords":
+			r.string(&x.Inventory.Keywords)
+		case "kwarray":
+			seen |= 1 << 3
+			if r.null() {
+				x.Inventory.KeywordArray = nil
+			} else {
+				x.Inventory.KeywordArray = x.Inventory.KeywordArray[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.KeywordArray)
+					if i < cap(x.Inventory.KeywordArray) {
+						x.Inventory.KeywordArray = x.Inventory.KeywordArray[:i+1]
+					} else {
+						x.Inventory.KeywordArray = append(x.Inventory.KeywordArray, "")
+					}
+					r.string(&x.Inventory.KeywordArray[i])
+				}
+				if x.Inventory.KeywordArray == nil {
+					x.Inventory.KeywordArray = []string{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 4
+			if raw := r.raw(); raw != nil {
+				x.Inventory.Ext = append(x.Inventory.Ext[:0], raw...)
+			}
+		case "bundle":
+			r.string(&x.Bundle)
+		case "storeurl":
+			r.string(&x.StoreURL)
+		case "ver":
+			r.string(&x.Version)
+		case "paid":
+			if n, ok := r.int(0); ok {
+				x.Paid = int(n)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonAppKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Inventory.Categories) == 0 {
+		x.Inventory.Categories = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Inventory.SectionCategories) == 0 {
+		x.Inventory.SectionCategories = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Inventory.PageCategories) == 0 {
+		x.Inventory.PageCategories = nil
+	}
+	if seen&(1<<3) == 0 && len(x.Inventory.KeywordArray) == 0 {
+		x.Inventory.KeywordArray = nil
+	}
+	if seen&(1<<4) == 0 && len(x.Inventory.Ext) == 0 {
+		x.Inventory.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *App) Reset() {
+	x.Inventory.ID = ""
+	x.Inventory.Name = ""
+	x.Inventory.Domain = ""
+	x.Inventory.CategoryTaxonomies = 0
+	for i, si := 0, x.Inventory.Categories[:cap(x.Inventory.Categories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.Categories = x.Inventory.Categories[:0]
+	for i, si := 0, x.Inventory.SectionCategories[:cap(x.Inventory.SectionCategories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.SectionCategories = x.Inventory.SectionCategories[:0]
+	for i, si := 0, x.Inventory.PageCategories[:cap(x.Inventory.PageCategories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.PageCategories = x.Inventory.PageCategories[:0]
+	x.Inventory.PrivacyPolicy = nil
+	x.Inventory.Publisher = nil
+	x.Inventory.Content = nil
+	x.Inventory.Keywords = ""
+	for i, si := 0, x.Inventory.KeywordArray[:cap(x.Inventory.KeywordArray)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.KeywordArray = x.Inventory.KeywordArray[:0]
+	for i, si := 0, x.Inventory.Ext[:cap(x.Inventory.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Inventory.Ext = x.Inventory.Ext[:0]
+	x.Bundle = ""
+	x.StoreURL = ""
+	x.Version = ""
+	x.Paid = 0
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Audio) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Audio) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Audio) encodeJSON(w *jsonWriter) {
+	x.normalize()
+	w.b = append(w.b, '{')
+	w.field("\"mimes\":")
+	if x.MIMEs == nil {
+		w.null()
+	} else {
+		w.b = append(w.b, '[')
+		for i := range x.MIMEs {
+			w.elem()
+			w.string(x.MIMEs[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.MinDuration != 0 {
+		w.field("\"minduration\":")
+		w.int(int64(x.MinDuration))
+	}
+	if x.MaxDuration != 0 {
+		w.field("\"maxduration\":")
+		w.int(int64(x.MaxDuration))
+	}
+	if x.PodDur != 0 {
+		w.field("\"poddur\":")
+		w.int(int64(x.PodDur))
+	}
+	if len(x.Protocols) != 0 {
+		w.field("\"protocols\":")
+		w.b = append(w.b, '[')
+		for i := range x.Protocols {
+			w.elem()
+			w.int(int64(x.Protocols[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.StartDelay != 0 {
+		w.field("\"startdelay\":")
+		w.int(int64(x.StartDelay))
+	}
+	if x.PoDid != 0 {
+		w.field("\"podid\":")
+		w.int(int64(x.PoDid))
+	}
+	if x.PodSeq != 0 {
+		w.field("\"podseq\":")
+		w.int(int64(x.PodSeq))
+	}
+	if len(x.RqdDurs) != 0 {
+		w.field("\"rqddurs\":")
+		w.b = append(w.b, '[')
+		for i := range x.RqdDurs {
+			w.elem()
+			w.int(int64(x.RqdDurs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Sequence != 0 {
+		w.field("\"sequence\":")
+		w.int(int64(x.Sequence))
+	}
+	if x.SlotInPod != 0 {
+		w.field("\"slotinpod\":")
+		w.int(int64(x.SlotInPod))
+	}
+	if x.MinCPMPerSec != 0 {
+		w.field("\"mincpmpersec\":")
+		w.float(float64(x.MinCPMPerSec), 32)
+	}
+	if len(x.BlockedAttrs) != 0 {
+		w.field("\"battr\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedAttrs {
+			w.elem()
+			w.int(int64(x.BlockedAttrs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.MaxExtended != 0 {
+		w.field("\"maxextended\":")
+		w.int(int64(x.MaxExtended))
+	}
+	if x.MinBitrate != 0 {
+		w.field("\"minbitrate\":")
+		w.int(int64(x.MinBitrate))
+	}
+	if x.MaxBitrate != 0 {
+		w.field("\"maxbitrate\":")
+		w.int(int64(x.MaxBitrate))
+	}
+	if len(x.Delivery) != 0 {
+		w.field("\"delivery\":")
+		w.b = append(w.b, '[')
+		for i := range x.Delivery {
+			w.elem()
+			w.int(int64(x.Delivery[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.CompanionAds) != 0 {
+		w.field("\"companionad\":")
+		w.b = append(w.b, '[')
+		for i := range x.CompanionAds {
+			w.elem()
+			x.CompanionAds[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.APIs) != 0 {
+		w.field("\"api\":")
+		w.b = append(w.b, '[')
+		for i := range x.APIs {
+			w.elem()
+			w.int(int64(x.APIs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.CompanionTypes) != 0 {
+		w.field("\"companiontype\":")
+		w.b = append(w.b, '[')
+		for i := range x.CompanionTypes {
+			w.elem()
+			w.int(int64(x.CompanionTypes[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.MaxSequence != 0 {
+		w.field("\"maxseq\":")
+		w.int(int64(x.MaxSequence))
+	}
+	if x.Feed != 0 {
+		w.field("\"feed\":")
+		w.int(int64(x.Feed))
+	}
+	if x.Stitched != 0 {
+		w.field("\"stitched\":")
+		w.int(int64(x.Stitched))
+	}
+	if x.VolumeNorm != 0 {
+		w.field("\"nvol\":")
+		w.int(int64(x.VolumeNorm))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonAudioKeys = []string{"mimes", "minduration", "maxduration", "poddur", "protocols", "startdelay", "podid", "podseq", "rqddurs", "sequence", "slotinpod", "mincpmpersec", "battr", "maxextended", "minbitrate", "maxbitrate", "delivery", "companionad", "api", "companiontype", "maxseq", "feed", "stitched", "nvol", "ext"}
+
+func (x *Audio) decodeJSON(r *jsonReader) {
+	x.Reset()
+	defer x.normalize()
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "mimes":
+			seen |= 1 << 0
+			if r.null() {
+				x.MIMEs = nil
+			} else {
+				x.MIMEs = x.MIMEs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.MIMEs)
+					if i < cap(x.MIMEs) {
+						x.MIMEs = x.MIMEs[:i+1]
+					} else {
+						x.MIMEs = append(x.MIMEs, "")
+					}
+					r.string(&x.MIMEs[i])
+				}
+				if x.MIMEs == nil {
+					x.MIMEs = []string{}
+				}
+			}
+		case "minduration":
+			if n, ok := r.int(0); ok {
+				x.MinDuration = int(n)
+			}
+		case "maxduration":
+			if n, ok := r.int(0); ok {
+				x.MaxDuration = int(n)
+			}
+		case "poddur":
+			if n, ok := r.int(0); ok {
+				x.PodDur = int(n)
+			}
+		case "protocols":
+			seen |= 1 << 1
+			if r.null() {
+				x.Protocols = nil
+			} else {
+				x.Protocols = x.Protocols[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Protocols)
+					if i < cap(x.Protocols) {
+						x.Protocols = x.Protocols[:i+1]
+					} else {
+						x.Protocols = append(x.Protocols, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.Protocols[i] = Protocol(n)
+						if r.strict && !validProtocol(x.Protocols[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.Protocols == nil {
+					x.Protocols = []Protocol{}
+				}
+			}
+		case "startdelay":
+			if n, ok := r.int(0); ok {
+				x.StartDelay = StartDelay(n)
+			}
+		case "podid":
+			if n, ok := r.int(0); ok {
+				x.PoDid = int(n)
+			}
+		case "podseq":
+			if n, ok := r.int(0); ok {
+				x.PodSeq = int(n)
+			}
+		case "rqddurs":
+			seen |= 1 << 2
+			if r.null() {
+				x.RqdDurs = nil
+			} else {
+				x.RqdDurs = x.RqdDurs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.RqdDurs)
+					if i < cap(x.RqdDurs) {
+						x.RqdDurs = x.RqdDurs[:i+1]
+					} else {
+						x.RqdDurs = append(x.RqdDurs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.RqdDurs[i] = int(n)
+					}
+				}
+				if x.RqdDurs == nil {
+					x.RqdDurs = []int{}
+				}
+			}
+		case "sequence":
+			if n, ok := r.int(0); ok {
+				x.Sequence = int(n)
+			}
+		case "slotinpod":
+			if n, ok := r.int(0); ok {
+				x.SlotInPod = int(n)
+			}
+		case "mincpmpersec":
+			if n, ok := r.float(32); ok {
+				x.MinCPMPerSec = float32(n)
+			}
+		case "battr":
+			seen |= 1 << 3
+			if r.null() {
+				x.BlockedAttrs = nil
+			} else {
+				x.BlockedAttrs = x.BlockedAttrs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedAttrs)
+					if i < cap(x.BlockedAttrs) {
+						x.BlockedAttrs = x.BlockedAttrs[:i+1]
+					} else {
+						x.BlockedAttrs = append(x.BlockedAttrs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.BlockedAttrs[i] = CreativeAttribute(n)
+						if r.strict && !validCreativeAttribute(x.BlockedAttrs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.BlockedAttrs == nil {
+					x.BlockedAttrs = []CreativeAttribute{}
+				}
+			}
+		case "maxextended":
+			if n, ok := r.int(0); ok {
+				x.MaxExtended = int(n)
+			}
+		case "minbitrate":
+			if n, ok := r.int(0); ok {
+				x.MinBitrate = int(n)
+			}
+		case "maxbitrate":
+			if n, ok := r.int(0); ok {
+				x.MaxBitrate = int(n)
+			}
+		case "delivery":
+			seen |= 1 << 4
+			if r.null() {
+				x.Delivery = nil
+			} else {
+				x.Delivery = x.Delivery[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Delivery)
+					if i < cap(x.Delivery) {
+						x.Delivery = x.Delivery[:i+1]
+					} else {
+						x.Delivery = append(x.Delivery, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.Delivery[i] = ContentDelivery(n)
+						if r.strict && !validContentDelivery(x.Delivery[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.Delivery == nil {
+					x.Delivery = []ContentDelivery{}
+				}
+			}
+		case "companionad":
+			seen |= 1 << 5
+			if r.null() {
+				x.CompanionAds = nil
+			} else {
+				x.CompanionAds = x.CompanionAds[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.CompanionAds)
+					if i < cap(x.CompanionAds) {
+						x.CompanionAds = x.CompanionAds[:i+1]
+					} else {
+						x.CompanionAds = append(x.CompanionAds, Banner{})
+					}
+					x.CompanionAds[i].decodeJSON(r)
+				}
+				if x.CompanionAds == nil {
+					x.CompanionAds = []Banner{}
+				}
+			}
+		case "api":
+			seen |= 1 << 6
+			if r.null() {
+				x.APIs = nil
+			} else {
+				x.APIs = x.APIs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.APIs)
+					if i < cap(x.APIs) {
+						x.APIs = x.APIs[:i+1]
+					} else {
+						x.APIs = append(x.APIs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.APIs[i] = APIFramework(n)
+						if r.strict && !validAPIFramework(x.APIs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.APIs == nil {
+					x.APIs = []APIFramework{}
+				}
+			}
+		case "companiontype":
+			seen |= 1 << 7
+			if r.null() {
+				x.CompanionTypes = nil
+			} else {
+				x.CompanionTypes = x.CompanionTypes[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.CompanionTypes)
+					if i < cap(x.CompanionTypes) {
+						x.CompanionTypes = x.CompanionTypes[:i+1]
+					} else {
+						x.CompanionTypes = append(x.CompanionTypes, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.CompanionTypes[i] = CompanionType(n)
+						if r.strict && !validCompanionType(x.CompanionTypes[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.CompanionTypes == nil {
+					x.CompanionTypes = []CompanionType{}
+				}
+			}
+		case "maxseq":
+			if n, ok := r.int(0); ok {
+				x.MaxSequence = int(n)
+			}
+		case "feed":
+			if n, ok := r.int(0); ok {
+				x.Feed = FeedType(n)
+				if r.strict && !validFeedType(x.Feed) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "stitched":
+			if n, ok := r.int(0); ok {
+				x.Stitched = int(n)
+			}
+		case "nvol":
+			if n, ok := r.int(0); ok {
+				x.VolumeNorm = VolumeNorm(n)
+				if r.strict && !validVolumeNorm(x.VolumeNorm) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "ext":
+			seen |= 1 << 8
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonAudioKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.MIMEs) == 0 {
+		x.MIMEs = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Protocols) == 0 {
+		x.Protocols = nil
+	}
+	if seen&(1<<2) == 0 && len(x.RqdDurs) == 0 {
+		x.RqdDurs = nil
+	}
+	if seen&(1<<3) == 0 && len(x.BlockedAttrs) == 0 {
+		x.BlockedAttrs = nil
+	}
+	if seen&(1<<4) == 0 && len(x.Delivery) == 0 {
+		x.Delivery = nil
+	}
+	if seen&(1<<5) == 0 && len(x.CompanionAds) == 0 {
+		x.CompanionAds = nil
+	}
+	if seen&(1<<6) == 0 && len(x.APIs) == 0 {
+		x.APIs = nil
+	}
+	if seen&(1<<7) == 0 && len(x.CompanionTypes) == 0 {
+		x.CompanionTypes = nil
+	}
+	if seen&(1<<8) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Audio) Reset() {
+	for i, si := 0, x.MIMEs[:cap(x.MIMEs)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.MIMEs = x.MIMEs[:0]
+	x.MinDuration = 0
+	x.MaxDuration = 0
+	x.PodDur = 0
+	for i, si := 0, x.Protocols[:cap(x.Protocols)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Protocols = x.Protocols[:0]
+	x.StartDelay = 0
+	x.PoDid = 0
+	x.PodSeq = 0
+	for i, si := 0, x.RqdDurs[:cap(x.RqdDurs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.RqdDurs = x.RqdDurs[:0]
+	x.Sequence = 0
+	x.SlotInPod = 0
+	x.MinCPMPerSec = 0
+	for i, si := 0, x.BlockedAttrs[:cap(x.BlockedAttrs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.BlockedAttrs = x.BlockedAttrs[:0]
+	x.MaxExtended = 0
+	x.MinBitrate = 0
+	x.MaxBitrate = 0
+	for i, si := 0, x.Delivery[:cap(x.Delivery)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Delivery = x.Delivery[:0]
+	for i, si := 0, x.CompanionAds[:cap(x.CompanionAds)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.CompanionAds = x.CompanionAds[:0]
+	for i, si := 0, x.APIs[:cap(x.APIs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.APIs = x.APIs[:0]
+	for i, si := 0, x.CompanionTypes[:cap(x.CompanionTypes)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.CompanionTypes = x.CompanionTypes[:0]
+	x.MaxSequence = 0
+	x.Feed = 0
+	x.Stitched = 0
+	x.VolumeNorm = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Banner) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Banner) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Banner) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if len(x.Formats) != 0 {
+		w.field("\"format\":")
+		w.b = append(w.b, '[')
+		for i := range x.Formats {
+			w.elem()
+			x.Formats[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Width != 0 {
+		w.field("\"w\":")
+		w.int(int64(x.Width))
+	}
+	if x.Height != 0 {
+		w.field("\"h\":")
+		w.int(int64(x.Height))
+	}
+	if len(x.BlockedTypes) != 0 {
+		w.field("\"btype\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedTypes {
+			w.elem()
+			w.int(int64(x.BlockedTypes[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.BlockedAttrs) != 0 {
+		w.field("\"battr\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedAttrs {
+			w.elem()
+			w.int(int64(x.BlockedAttrs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Position != 0 {
+		w.field("\"pos\":")
+		w.int(int64(x.Position))
+	}
+	if len(x.MIMEs) != 0 {
+		w.field("\"mimes\":")
+		w.b = append(w.b, '[')
+		for i := range x.MIMEs {
+			w.elem()
+			w.string(x.MIMEs[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.TopFrame != 0 {
+		w.field("\"topframe\":")
+		w.int(int64(x.TopFrame))
+	}
+	if len(x.ExpDirs) != 0 {
+		w.field("\"expdir\":")
+		w.b = append(w.b, '[')
+		for i := range x.ExpDirs {
+			w.elem()
+			w.int(int64(x.ExpDirs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.APIs) != 0 {
+		w.field("\"api\":")
+		w.b = append(w.b, '[')
+		for i := range x.APIs {
+			w.elem()
+			w.int(int64(x.APIs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.VCM != 0 {
+		w.field("\"vcm\":")
+		w.int(int64(x.VCM))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonBannerKeys = []string{"format", "w", "h", "btype", "battr", "pos", "mimes", "topframe", "expdir", "api", "id", "vcm", "ext"}
+
+func (x *Banner) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "format":
+			seen |= 1 << 0
+			if r.null() {
+				x.Formats = nil
+			} else {
+				x.Formats = x.Formats[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Formats)
+					if i < cap(x.Formats) {
+						x.Formats = x.Formats[:i+1]
+					} else {
+						x.Formats = append(x.Formats, Format{})
+					}
+					x.Formats[i].decodeJSON(r)
+				}
+				if x.Formats == nil {
+					x.Formats = []Format{}
+				}
+			}
+		case "w":
+			if n, ok := r.int(0); ok {
+				x.Width = int(n)
+			}
+		case "h":
+			if n, ok := r.int(0); ok {
+				x.Height = int(n)
+			}
+		case "btype":
+			seen |= 1 << 1
+			if r.null() {
+				x.BlockedTypes = nil
+			} else {
+				x.BlockedTypes = x.BlockedTypes[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedTypes)
+					if i < cap(x.BlockedTypes) {
+						x.BlockedTypes = x.BlockedTypes[:i+1]
+					} else {
+						x.BlockedTypes = append(x.BlockedTypes, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.BlockedTypes[i] = BannerType(n)
+						if r.strict && !validBannerType(x.BlockedTypes[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.BlockedTypes == nil {
+					x.BlockedTypes = []BannerType{}
+				}
+			}
+		case "battr":
+			seen |= 1 << 2
+			if r.null() {
+				x.BlockedAttrs = nil
+			} else {
+				x.BlockedAttrs = x.BlockedAttrs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedAttrs)
+					if i < cap(x.BlockedAttrs) {
+						x.BlockedAttrs = x.BlockedAttrs[:i+1]
+					} else {
+						x.BlockedAttrs = append(x.BlockedAttrs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.BlockedAttrs[i] = CreativeAttribute(n)
+						if r.strict && !validCreativeAttribute(x.BlockedAttrs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.BlockedAttrs == nil {
+					x.BlockedAttrs = []CreativeAttribute{}
+				}
+			}
+		case "pos":
+			if n, ok := r.int(0); ok {
+				x.Position = AdPosition(n)
+				if r.strict && !validAdPosition(x.Position) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "mimes":
+			seen |= 1 << 3
+			if r.null() {
+				x.MIMEs = nil
+			} else {
+				x.MIMEs = x.MIMEs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.MIMEs)
+					if i < cap(x.MIMEs) {
+						x.MIMEs = x.MIMEs[:i+1]
+					} else {
+						x.MIMEs = append(x.MIMEs, "")
+					}
+					r.string(&x.MIMEs[i])
+				}
+				if x.MIMEs == nil {
+					x.MIMEs = []string{}
+				}
+			}
+		case "topframe":
+			if n, ok := r.int(0); ok {
+				x.TopFrame = int(n)
+			}
+		case "expdir":
+			seen |= 1 << 4
+			if r.null() {
+				x.ExpDirs = nil
+			} else {
+				x.ExpDirs = x.ExpDirs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.ExpDirs)
+					if i < cap(x.ExpDirs) {
+						x.ExpDirs = x.ExpDirs[:i+1]
+					} else {
+						x.ExpDirs = append(x.ExpDirs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.ExpDirs[i] = ExpDir(n)
+						if r.strict && !validExpDir(x.ExpDirs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.ExpDirs == nil {
+					x.ExpDirs = []ExpDir{}
+				}
+			}
+		case "api":
+			seen |= 1 << 5
+			if r.null() {
+				x.APIs = nil
+			} else {
+				x.APIs = x.APIs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.APIs)
+					if i < cap(x.APIs) {
+						x.APIs = x.APIs[:i+1]
+					} else {
+						x.APIs = append(x.APIs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.APIs[i] = APIFramework(n)
+						if r.strict && !validAPIFramework(x.APIs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.APIs == nil {
+					x.APIs = []APIFramework{}
+				}
+			}
+		case "id":
+			r.string(&x.ID)
+		case "vcm":
+			if n, ok := r.int(0); ok {
+				x.VCM = int(n)
+			}
+		case "ext":
+			seen |= 1 << 6
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonBannerKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Formats) == 0 {
+		x.Formats = nil
+	}
+	if seen&(1<<1) == 0 && len(x.BlockedTypes) == 0 {
+		x.BlockedTypes = nil
+	}
+	if seen&(1<<2) == 0 && len(x.BlockedAttrs) == 0 {
+		x.BlockedAttrs = nil
+	}
+	if seen&(1<<3) == 0 && len(x.MIMEs) == 0 {
+		x.MIMEs = nil
+	}
+	if seen&(1<<4) == 0 && len(x.ExpDirs) == 0 {
+		x.ExpDirs = nil
+	}
+	if seen&(1<<5) == 0 && len(x.APIs) == 0 {
+		x.APIs = nil
+	}
+	if seen&(1<<6) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Banner) Reset() {
+	for i, si := 0, x.Formats[:cap(x.Formats)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Formats = x.Formats[:0]
+	x.Width = 0
+	x.Height = 0
+	for i, si := 0, x.BlockedTypes[:cap(x.BlockedTypes)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.BlockedTypes = x.BlockedTypes[:0]
+	for i, si := 0, x.BlockedAttrs[:cap(x.BlockedAttrs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.BlockedAttrs = x.BlockedAttrs[:0]
+	x.Position = 0
+	for i, si := 0, x.MIMEs[:cap(x.MIMEs)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.MIMEs = x.MIMEs[:0]
+	x.TopFrame = 0
+	for i, si := 0, x.ExpDirs[:cap(x.ExpDirs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.ExpDirs = x.ExpDirs[:0]
+	for i, si := 0, x.APIs[:cap(x.APIs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.APIs = x.APIs[:0]
+	x.ID = ""
+	x.VCM = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Bid) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Bid) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Bid) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	w.field("\"id\":")
+	w.string(x.ID)
+	w.field("\"impid\":")
+	w.string(x.ImpID)
+	w.field("\"price\":")
+	w.float(float64(x.Price), 64)
+	if x.NoticeURL != "" {
+		w.field("\"nurl\":")
+		w.string(x.NoticeURL)
+	}
+	if x.BillingURL != "" {
+		w.field("\"burl\":")
+		w.string(x.BillingURL)
+	}
+	if x.LossURL != "" {
+		w.field("\"lurl\":")
+		w.string(x.LossURL)
+	}
+	if x.AdMarkup != "" {
+		w.field("\"adm\":")
+		w.string(x.AdMarkup)
+	}
+	if x.AdID != "" {
+		w.field("\"adid\":")
+		w.string(x.AdID)
+	}
+	if len(x.AdvDomains) != 0 {
+		w.field("\"adomain\":")
+		w.b = append(w.b, '[')
+		for i := range x.AdvDomains {
+			w.elem()
+			w.string(x.AdvDomains[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Bundle != "" {
+		w.field("\"bundle\":")
+		w.string(x.Bundle)
+	}
+	if x.ImageURL != "" {
+		w.field("\"iurl\":")
+		w.string(x.ImageURL)
+	}
+	if x.CampaignID != "" {
+		w.field("\"cid\":")
+		w.string(string(x.CampaignID))
+	}
+	if x.CreativeID != "" {
+		w.field("\"crid\":")
+		w.string(x.CreativeID)
+	}
+	if x.Tactic != "" {
+		w.field("\"tactic\":")
+		w.string(x.Tactic)
+	}
+	if x.CategoryTaxonomies != 0 {
+		w.field("\"cattax\":")
+		w.uint(uint64(x.CategoryTaxonomies))
+	}
+	if len(x.Categories) != 0 {
+		w.field("\"cat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Categories {
+			w.elem()
+			w.string(string(x.Categories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Attrs) != 0 {
+		w.field("\"attr\":")
+		w.b = append(w.b, '[')
+		for i := range x.Attrs {
+			w.elem()
+			w.int(int64(x.Attrs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.API != 0 {
+		w.field("\"api\":")
+		w.int(int64(x.API))
+	}
+	if x.Protocol != 0 {
+		w.field("\"protocol\":")
+		w.int(int64(x.Protocol))
+	}
+	if x.MediaRating != 0 {
+		w.field("\"qagmediarating\":")
+		w.int(int64(x.MediaRating))
+	}
+	if x.Language != "" {
+		w.field("\"language\":")
+		w.string(x.Language)
+	}
+	if x.LangB != "" {
+		w.field("\"langb\":")
+		w.string(x.LangB)
+	}
+	if x.DealID != "" {
+		w.field("\"dealid\":")
+		w.string(x.DealID)
+	}
+	if x.Width != 0 {
+		w.field("\"w\":")
+		w.int(int64(x.Width))
+	}
+	if x.Height != 0 {
+		w.field("\"h\":")
+		w.int(int64(x.Height))
+	}
+	if x.WidthRatio != 0 {
+		w.field("\"wratio\":")
+		w.int(int64(x.WidthRatio))
+	}
+	if x.HeightRatio != 0 {
+		w.field("\"hratio\":")
+		w.int(int64(x.HeightRatio))
+	}
+	if x.Exp != 0 {
+		w.field("\"exp\":")
+		w.int(int64(x.Exp))
+	}
+	if x.Duration != 0 {
+		w.field("\"dur\":")
+		w.int(int64(x.Duration))
+	}
+	if x.MarkupType != 0 {
+		w.field("\"mtype\":")
+		w.int(int64(x.MarkupType))
+	}
+	if x.SlotInPod != 0 {
+		w.field("\"slotinpod\":")
+		w.int(int64(x.SlotInPod))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonBidKeys = []string{"id", "impid", "price", "nurl", "burl", "lurl", "adm", "adid", "adomain", "bundle", "iurl", "cid", "crid", "tactic", "cattax", "cat", "attr", "api", "protocol", "qagmediarating", "language", "langb", "dealid", "w", "h", "wratio", "hratio", "exp", "dur", "mtype", "slotinpod", "ext"}
+
+func (x *Bid) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "impid":
+			r.string(&x.ImpID)
+		case "price":
+			if n, ok := r.float(64); ok {
+				x.Price = float64(n)
+			}
+		case "nurl":
+			r.string(&x.NoticeURL)
+		case "burl":
+			r.string(&x.BillingURL)
+		case "lurl":
+			r.string(&x.LossURL)
+		case "adm":
+			r.string(&x.AdMarkup)
+		case "adid":
+			r.string(&x.AdID)
+		case "adomain":
+			seen |= 1 << 0
+			if r.null() {
+				x.AdvDomains = nil
+			} else {
+				x.AdvDomains = x.AdvDomains[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.AdvDomains)
+					if i < cap(x.AdvDomains) {
+						x.AdvDomains = x.AdvDomains[:i+1]
+					} else {
+						x.AdvDomains = append(x.AdvDomains, "")
+					}
+					r.string(&x.AdvDomains[i])
+				}
+				if x.AdvDomains == nil {
+					x.AdvDomains = []string{}
+				}
+			}
+		case "bundle":
+			r.string(&x.Bundle)
+		case "iurl":
+			r.string(&x.ImageURL)
+		case "cid":
+			r.stringOrNumber(&x.CampaignID)
+		case "crid":
+			r.string(&x.CreativeID)
+		case "tactic":
+			r.string(&x.Tactic)
+		case "cattax":
+			if n, ok := r.uint(0); ok {
+				x.CategoryTaxonomies = uint(n)
+			}
+		case "cat":
+			seen |= 1 << 1
+			if r.null() {
+				x.Categories = nil
+			} else {
+				x.Categories = x.Categories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Categories)
+					if i < cap(x.Categories) {
+						x.Categories = x.Categories[:i+1]
+					} else {
+						x.Categories = append(x.Categories, "")
+					}
+					r.string((*string)(&x.Categories[i]))
+				}
+				if x.Categories == nil {
+					x.Categories = []ContentCategory{}
+				}
+			}
+		case "attr":
+			seen |= 1 << 2
+			if r.null() {
+				x.Attrs = nil
+			} else {
+				x.Attrs = x.Attrs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Attrs)
+					if i < cap(x.Attrs) {
+						x.Attrs = x.Attrs[:i+1]
+					} else {
+						x.Attrs = append(x.Attrs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.Attrs[i] = CreativeAttribute(n)
+						if r.strict && !validCreativeAttribute(x.Attrs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.Attrs == nil {
+					x.Attrs = []CreativeAttribute{}
+				}
+			}
+		case "api":
+			if n, ok := r.int(0); ok {
+				x.API = APIFramework(n)
+				if r.strict && !validAPIFramework(x.API) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "protocol":
+			if n, ok := r.int(0); ok {
+				x.Protocol = Protocol(n)
+				if r.strict && !validProtocol(x.Protocol) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "qagmediarating":
+			if n, ok := r.int(0); ok {
+				x.MediaRating = IQGRating(n)
+				if r.strict && !validIQGRating(x.MediaRating) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "language":
+			r.string(&x.Language)
+		case "langb":
+			r.string(&x.LangB)
+		case "dealid":
+			r.string(&x.DealID)
+		case "w":
+			if n, ok := r.int(0); ok {
+				x.Width = int(n)
+			}
+		case "h":
+			if n, ok := r.int(0); ok {
+				x.Height = int(n)
+			}
+		case "wratio":
+			if n, ok := r.int(0); ok {
+				x.WidthRatio = int(n)
+			}
+		case "hratio":
+			if n, ok := r.int(0); ok {
+				x.HeightRatio = int(n)
+			}
+		case "exp":
+			if n, ok := r.int(0); ok {
+				x.Exp = int(n)
+			}
+		case "dur":
+			if n, ok := r.int(0); ok {
+				x.Duration = int(n)
+			}
+		case "mtype":
+			if n, ok := r.int(0); ok {
+				x.MarkupType = MediaType(n)
+				if r.strict && !validMediaType(x.MarkupType) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "slotinpod":
+			if n, ok := r.int(0); ok {
+				x.SlotInPod = int(n)
+			}
+		case "ext":
+			seen |= 1 << 3
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonBidKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.AdvDomains) == 0 {
+		x.AdvDomains = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Categories) == 0 {
+		x.Categories = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Attrs) == 0 {
+		x.Attrs = nil
+	}
+	if seen&(1<<3) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Bid) Reset() {
+	x.ID = ""
+	x.ImpID = ""
+	x.Price = 0
+	x.NoticeURL = ""
+	x.BillingURL = ""
+	x.LossURL = ""
+	x.AdMarkup = ""
+	x.AdID = ""
+	for i, si := 0, x.AdvDomains[:cap(x.AdvDomains)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.AdvDomains = x.AdvDomains[:0]
+	x.Bundle = ""
+	x.ImageURL = ""
+	x.CampaignID = ""
+	x.CreativeID = ""
+	x.Tactic = ""
+	x.CategoryTaxonomies = 0
+	for i, si := 0, x.Categories[:cap(x.Categories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Categories = x.Categories[:0]
+	for i, si := 0, x.Attrs[:cap(x.Attrs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Attrs = x.Attrs[:0]
+	x.API = 0
+	x.Protocol = 0
+	x.MediaRating = 0
+	x.Language = ""
+	x.LangB = ""
+	x.DealID = ""
+	x.Width = 0
+	x.Height = 0
+	x.WidthRatio = 0
+	x.HeightRatio = 0
+	x.Exp = 0
+	x.Duration = 0
+	x.MarkupType = 0
+	x.SlotInPod = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *BidRequest) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *BidRequest) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *BidRequest) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	w.field("\"id\":")
+	w.string(x.ID)
+	if len(x.Impressions) != 0 {
+		w.field("\"imp\":")
+		w.b = append(w.b, '[')
+		for i := range x.Impressions {
+			w.elem()
+			x.Impressions[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Site != nil {
+		w.field("\"site\":")
+		x.Site.encodeJSON(w)
+	}
+	if x.App != nil {
+		w.field("\"app\":")
+		x.App.encodeJSON(w)
+	}
+	if x.Device != nil {
+		w.field("\"device\":")
+		x.Device.encodeJSON(w)
+	}
+	if x.User != nil {
+		w.field("\"user\":")
+		x.User.encodeJSON(w)
+	}
+	if x.Test != 0 {
+		w.field("\"test\":")
+		w.int(int64(x.Test))
+	}
+	w.field("\"at\":")
+	w.int(int64(x.AuctionType))
+	if x.TMax != 0 {
+		w.field("\"tmax\":")
+		w.int(int64(x.TMax))
+	}
+	if len(x.Seats) != 0 {
+		w.field("\"wseat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Seats {
+			w.elem()
+			w.string(x.Seats[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.BlockedSeats) != 0 {
+		w.field("\"bseat\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedSeats {
+			w.elem()
+			w.string(x.BlockedSeats[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.AllImpressions != 0 {
+		w.field("\"allimps\":")
+		w.int(int64(x.AllImpressions))
+	}
+	if len(x.Currencies) != 0 {
+		w.field("\"cur\":")
+		w.b = append(w.b, '[')
+		for i := range x.Currencies {
+			w.elem()
+			w.string(x.Currencies[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Languages) != 0 {
+		w.field("\"wlang\":")
+		w.b = append(w.b, '[')
+		for i := range x.Languages {
+			w.elem()
+			w.string(x.Languages[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.BlockedCategories) != 0 {
+		w.field("\"bcat\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedCategories {
+			w.elem()
+			w.string(string(x.BlockedCategories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.CategoryTaxonomies != 0 {
+		w.field("\"cattax\":")
+		w.int(int64(x.CategoryTaxonomies))
+	}
+	if len(x.BlockedAdvDomains) != 0 {
+		w.field("\"badv\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedAdvDomains {
+			w.elem()
+			w.string(x.BlockedAdvDomains[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.BlockedApps) != 0 {
+		w.field("\"bapp\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedApps {
+			w.elem()
+			w.string(x.BlockedApps[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Source != nil {
+		w.field("\"source\":")
+		x.Source.encodeJSON(w)
+	}
+	if x.Regulations != nil {
+		w.field("\"regs\":")
+		x.Regulations.encodeJSON(w)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonBidRequestKeys = []string{"id", "imp", "site", "app", "device", "user", "test", "at", "tmax", "wseat", "bseat", "allimps", "cur", "wlang", "bcat", "cattax", "badv", "bapp", "source", "regs", "ext"}
+
+func (x *BidRequest) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "imp":
+			seen |= 1 << 0
+			if r.null() {
+				x.Impressions = nil
+			} else {
+				x.Impressions = x.Impressions[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Impressions)
+					if i < cap(x.Impressions) {
+						x.Impressions = x.Impressions[:i+1]
+					} else {
+						x.Impressions = append(x.Impressions, Impression{})
+					}
+					x.Impressions[i].decodeJSON(r)
+				}
+				if x.Impressions == nil {
+					x.Impressions = []Impression{}
+				}
+			}
+		case "site":
+			if r.null() {
+				x.Site = nil
+			} else {
+				if x.Site == nil {
+					x.Site = r.alloc.newSite()
+				}
+				x.Site.decodeJSON(r)
+			}
+		case "app":
+			if r.null() {
+				x.App = nil
+			} else {
+				if x.App == nil {
+					x.App = r.alloc.newApp()
+				}
+				x.App.decodeJSON(r)
+			}
+		case "device":
+			if r.null() {
+				x.Device = nil
+			} else {
+				if x.Device == nil {
+					x.Device = r.alloc.newDevice()
+				}
+				x.Device.decodeJSON(r)
+			}
+		case "user":
+			if r.null() {
+				x.User = nil
+			} else {
+				if x.User == nil {
+					x.User = r.alloc.newUser()
+				}
+				x.User.decodeJSON(r)
+			}
+		case "test":
+			if n, ok := r.int(0); ok {
+				x.Test = int(n)
+			}
+		case "at":
+			if n, ok := r.int(0); ok {
+				x.AuctionType = int(n)
+			}
+		case "tmax":
+			if n, ok := r.int(0); ok {
+				x.TMax = int(n)
+			}
+		case "wseat":
+			seen |= 1 << 1
+			if r.null() {
+				x.Seats = nil
+			} else {
+				x.Seats = x.Seats[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Seats)
+					if i < cap(x.Seats) {
+						x.Seats = x.Seats[:i+1]
+					} else {
+						x.Seats = append(x.Seats, "")
+					}
+					r.string(&x.Seats[i])
+				}
+				if x.Seats == nil {
+					x.Seats = []string{}
+				}
+			}
+		case "bseat":
+			seen |= 1 << 2
+			if r.null() {
+				x.BlockedSeats = nil
+			} else {
+				x.BlockedSeats = x.BlockedSeats[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedSeats)
+					if i < cap(x.BlockedSeats) {
+						x.BlockedSeats = x.BlockedSeats[:i+1]
+					} else {
+						x.BlockedSeats = append(x.BlockedSeats, "")
+					}
+					r.string(&x.BlockedSeats[i])
+				}
+				if x.BlockedSeats == nil {
+					x.BlockedSeats = []string{}
+				}
+			}
+		case "allimps":
+			if n, ok := r.int(0); ok {
+				x.AllImpressions = int(n)
+			}
+		case "cur":
+			seen |= 1 << 3
+			if r.null() {
+				x.Currencies = nil
+			} else {
+				x.Currencies = x.Currencies[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Currencies)
+					if i < cap(x.Currencies) {
+						x.Currencies = x.Currencies[:i+1]
+					} else {
+						x.Currencies = append(x.Currencies, "")
+					}
+					r.string(&x.Currencies[i])
+				}
+				if x.Currencies == nil {
+					x.Currencies = []string{}
+				}
+			}
+		case "wlang":
+			seen |= 1 << 4
+			if r.null() {
+				x.Languages = nil
+			} else {
+				x.Languages = x.Languages[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Languages)
+					if i < cap(x.Languages) {
+						x.Languages = x.Languages[:i+1]
+					} else {
+						x.Languages = append(x.Languages, "")
+					}
+					r.string(&x.Languages[i])
+				}
+				if x.Languages == nil {
+					x.Languages = []string{}
+				}
+			}
+		case "bcat":
+			seen |= 1 << 5
+			if r.null() {
+				x.BlockedCategories = nil
+			} else {
+				x.BlockedCategories = x.BlockedCategories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedCategories)
+					if i < cap(x.BlockedCategories) {
+						x.BlockedCategories = x.BlockedCategories[:i+1]
+					} else {
+						x.BlockedCategories = append(x.BlockedCategories, "")
+					}
+					r.string((*string)(&x.BlockedCategories[i]))
+				}
+				if x.BlockedCategories == nil {
+					x.BlockedCategories = []ContentCategory{}
+				}
+			}
+		case "cattax":
+			if n, ok := r.int(0); ok {
+				x.CategoryTaxonomies = int(n)
+			}
+		case "badv":
+			seen |= 1 << 6
+			if r.null() {
+				x.BlockedAdvDomains = nil
+			} else {
+				x.BlockedAdvDomains = x.BlockedAdvDomains[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedAdvDomains)
+					if i < cap(x.BlockedAdvDomains) {
+						x.BlockedAdvDomains = x.BlockedAdvDomains[:i+1]
+					} else {
+						x.BlockedAdvDomains = append(x.BlockedAdvDomains, "")
+					}
+					r.string(&x.BlockedAdvDomains[i])
+				}
+				if x.BlockedAdvDomains == nil {
+					x.BlockedAdvDomains = []string{}
+				}
+			}
+		case "bapp":
+			seen |= 1 << 7
+			if r.null() {
+				x.BlockedApps = nil
+			} else {
+				x.BlockedApps = x.BlockedApps[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedApps)
+					if i < cap(x.BlockedApps) {
+						x.BlockedApps = x.BlockedApps[:i+1]
+					} else {
+						x.BlockedApps = append(x.BlockedApps, "")
+					}
+					r.string(&x.BlockedApps[i])
+				}
+				if x.BlockedApps == nil {
+					x.BlockedApps = []string{}
+				}
+			}
+		case "source":
+			if r.null() {
+				x.Source = nil
+			} else {
+				if x.Source == nil {
+					x.Source = r.alloc.newSource()
+				}
+				x.Source.decodeJSON(r)
+			}
+		case "regs":
+			if r.null() {
+				x.Regulations = nil
+			} else {
+				if x.Regulations == nil {
+					x.Regulations = r.alloc.newRegulations()
+				}
+				x.Regulations.decodeJSON(r)
+			}
+		case "ext":
+			seen |= 1 << 8
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonBidRequestKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Impressions) == 0 {
+		x.Impressions = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Seats) == 0 {
+		x.Seats = nil
+	}
+	if seen&(1<<2) == 0 && len(x.BlockedSeats) == 0 {
+		x.BlockedSeats = nil
+	}
+	if seen&(1<<3) == 0 && len(x.Currencies) == 0 {
+		x.Currencies = nil
+	}
+	if seen&(1<<4) == 0 && len(x.Languages) == 0 {
+		x.Languages = nil
+	}
+	if seen&(1<<5) == 0 && len(x.BlockedCategories) == 0 {
+		x.BlockedCategories = nil
+	}
+	if seen&(1<<6) == 0 && len(x.BlockedAdvDomains) == 0 {
+		x.BlockedAdvDomains = nil
+	}
+	if seen&(1<<7) == 0 && len(x.BlockedApps) == 0 {
+		x.BlockedApps = nil
+	}
+	if seen&(1<<8) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *BidRequest) Reset() {
+	x.ID = ""
+	for i, si := 0, x.Impressions[:cap(x.Impressions)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Impressions = x.Impressions[:0]
+	x.Site = nil
+	x.App = nil
+	x.Device = nil
+	x.User = nil
+	x.Test = 0
+	x.AuctionType = 0
+	x.TMax = 0
+	for i, si := 0, x.Seats[:cap(x.Seats)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Seats = x.Seats[:0]
+	for i, si := 0, x.BlockedSeats[:cap(x.BlockedSeats)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.BlockedSeats = x.BlockedSeats[:0]
+	x.AllImpressions = 0
+	for i, si := 0, x.Currencies[:cap(x.Currencies)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Currencies = x.Currencies[:0]
+	for i, si := 0, x.Languages[:cap(x.Languages)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Languages = x.Languages[:0]
+	for i, si := 0, x.BlockedCategories[:cap(x.BlockedCategories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.BlockedCategories = x.BlockedCategories[:0]
+	x.CategoryTaxonomies = 0
+	for i, si := 0, x.BlockedAdvDomains[:cap(x.BlockedAdvDomains)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.BlockedAdvDomains = x.BlockedAdvDomains[:0]
+	for i, si := 0, x.BlockedApps[:cap(x.BlockedApps)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.BlockedApps = x.BlockedApps[:0]
+	x.Source = nil
+	x.Regulations = nil
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *BidResponse) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *BidResponse) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *BidResponse) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	w.field("\"id\":")
+	w.string(x.ID)
+	w.field("\"seatbid\":")
+	if x.SeatBids == nil {
+		w.null()
+	} else {
+		w.b = append(w.b, '[')
+		for i := range x.SeatBids {
+			w.elem()
+			x.SeatBids[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.BidID != "" {
+		w.field("\"bidid\":")
+		w.string(x.BidID)
+	}
+	if x.Currency != "" {
+		w.field("\"cur\":")
+		w.string(x.Currency)
+	}
+	if x.CustomData != "" {
+		w.field("\"customdata\":")
+		w.string(x.CustomData)
+	}
+	if x.NBR != 0 {
+		w.field("\"nbr\":")
+		w.int(int64(x.NBR))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonBidResponseKeys = []string{"id", "seatbid", "bidid", "cur", "customdata", "nbr", "ext"}
+
+func (x *BidResponse) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "seatbid":
+			seen |= 1 << 0
+			if r.null() {
+				x.SeatBids = nil
+			} else {
+				x.SeatBids = x.SeatBids[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.SeatBids)
+					if i < cap(x.SeatBids) {
+						x.SeatBids = x.SeatBids[:i+1]
+					} else {
+						x.SeatBids = append(x.SeatBids, SeatBid{})
+					}
+					x.SeatBids[i].decodeJSON(r)
+				}
+				if x.SeatBids == nil {
+					x.SeatBids = []SeatBid{}
+				}
+			}
+		case "bidid":
+			r.string(&x.BidID)
+		case "cur":
+			r.string(&x.Currency)
+		case "customdata":
+			r.string(&x.CustomData)
+		case "nbr":
+			if n, ok := r.int(0); ok {
+				x.NBR = NBR(n)
+				if r.strict && !validNBR(x.NBR) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonBidResponseKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.SeatBids) == 0 {
+		x.SeatBids = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *BidResponse) Reset() {
+	x.ID = ""
+	for i, si := 0, x.SeatBids[:cap(x.SeatBids)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.SeatBids = x.SeatBids[:0]
+	x.BidID = ""
+	x.Currency = ""
+	x.CustomData = ""
+	x.NBR = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *BrandVersion) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *BrandVersion) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *BrandVersion) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Brand != 0 {
+		w.field("\"brand\":")
+		w.int(int64(x.Brand))
+	}
+	if len(x.Source) != 0 {
+		w.field("\"version\":")
+		w.b = append(w.b, '[')
+		for i := range x.Source {
+			w.elem()
+			w.string(x.Source[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonBrandVersionKeys = []string{"brand", "version", "ext"}
+
+func (x *BrandVersion) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "brand":
+			if n, ok := r.int(0); ok {
+				x.Brand = int(n)
+			}
+		case "version":
+			seen |= 1 << 0
+			if r.null() {
+				x.Source = nil
+			} else {
+				x.Source = x.Source[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Source)
+					if i < cap(x.Source) {
+						x.Source = x.Source[:i+1]
+					} else {
+						x.Source = append(x.Source, "")
+					}
+					r.string(&x.Source[i])
+				}
+				if x.Source == nil {
+					x.Source = []string{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonBrandVersionKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Source) == 0 {
+		x.Source = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *BrandVersion) Reset() {
+	x.Brand = 0
+	for i, si := 0, x.Source[:cap(x.Source)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Source = x.Source[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Channel) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Channel) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Channel) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Name)
+	}
+	if x.Domain != "" {
+		w.field("\"domain\":")
+		w.string(x.Domain)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonChannelKeys = []string{"id", "name", "domain", "ext"}
+
+func (x *Channel) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "name":
+			r.string(&x.Name)
+		case "domain":
+			r.string(&x.Domain)
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonChannelKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Channel) Reset() {
+	x.ID = ""
+	x.Name = ""
+	x.Domain = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Content) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Content) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Content) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.Episode != 0 {
+		w.field("\"episode\":")
+		w.int(int64(x.Episode))
+	}
+	if x.Title != "" {
+		w.field("\"title\":")
+		w.string(x.Title)
+	}
+	if x.Series != "" {
+		w.field("\"series\":")
+		w.string(x.Series)
+	}
+	if x.Season != "" {
+		w.field("\"season\":")
+		w.string(x.Season)
+	}
+	if x.Artist != "" {
+		w.field("\"artist\":")
+		w.string(x.Artist)
+	}
+	if x.Genre != "" {
+		w.field("\"genre\":")
+		w.string(x.Genre)
+	}
+	if x.Album != "" {
+		w.field("\"album\":")
+		w.string(x.Album)
+	}
+	if x.ISRC != "" {
+		w.field("\"isrc\":")
+		w.string(x.ISRC)
+	}
+	if x.Producer != nil {
+		w.field("\"producer\":")
+		x.Producer.encodeJSON(w)
+	}
+	if x.URL != "" {
+		w.field("\"url\":")
+		w.string(x.URL)
+	}
+	if x.CategoryTaxonomies != 0 {
+		w.field("\"cattax\":")
+		w.uint(uint64(x.CategoryTaxonomies))
+	}
+	if len(x.Categories) != 0 {
+		w.field("\"cat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Categories {
+			w.elem()
+			w.string(string(x.Categories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.ProductionQuality != 0 {
+		w.field("\"prodq\":")
+		w.int(int64(x.ProductionQuality))
+	}
+	if x.Context != 0 {
+		w.field("\"context\":")
+		w.int(int64(x.Context))
+	}
+	if x.ContentRating != "" {
+		w.field("\"contentrating\":")
+		w.string(x.ContentRating)
+	}
+	if x.UserRating != "" {
+		w.field("\"userrating\":")
+		w.string(x.UserRating)
+	}
+	if x.MediaRating != 0 {
+		w.field("\"qagmediarating\":")
+		w.int(int64(x.MediaRating))
+	}
+	if x.Keywords != "" {
+		w.field("\"keywords\":")
+		w.string(x.Keywords)
+	}
+	if len(x.KeywordArray) != 0 {
+		w.field("\"kwarray\":")
+		w.b = append(w.b, '[')
+		for i := range x.KeywordArray {
+			w.elem()
+			w.string(x.KeywordArray[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.LiveStream != 0 {
+		w.field("\"livestream\":")
+		w.int(int64(x.LiveStream))
+	}
+	if x.SourceRelationship != 0 {
+		w.field("\"sourcerelationship\":")
+		w.int(int64(x.SourceRelationship))
+	}
+	if x.Length != 0 {
+		w.field("\"len\":")
+		w.int(int64(x.Length))
+	}
+	if x.Language != "" {
+		w.field("\"language\":")
+		w.string(x.Language)
+	}
+	if x.LangB != "" {
+		w.field("\"langb\":")
+		w.string(x.LangB)
+	}
+	if x.Embeddable != 0 {
+		w.field("\"embeddable\":")
+		w.int(int64(x.Embeddable))
+	}
+	if len(x.Data) != 0 {
+		w.field("\"data\":")
+		w.b = append(w.b, '[')
+		for i := range x.Data {
+			w.elem()
+			x.Data[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Network != nil {
+		w.field("\"network\":")
+		x.Network.encodeJSON(w)
+	}
+	if x.Channel != nil {
+		w.field("\"channel\":")
+		x.Channel.encodeJSON(w)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonContentKeys = []string{"id", "episode", "title", "series", "season", "artist", "genre", "album", "isrc", "producer", "url", "cattax", "cat", "prodq", "context", "contentrating", "userrating", "qagmediarating", "keywords", "kwarray", "livestream", "sourcerelationship", "len", "language", "langb", "embeddable", "data", "network", "channel", "ext"}
+
+func (x *Content) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "episode":
+			if n, ok := r.int(0); ok {
+				x.Episode = int(n)
+			}
+		case "title":
+			r.string(&x.Title)
+		case "series":
+			r.string(&x.Series)
+		case "season":
+			r.string(&x.Season)
+		case "artist":
+			r.string(&x.Artist)
+		case "genre":
+			r.string(&x.Genre)
+		case "album":
+			r.string(&x.Album)
+		case "isrc":
+			r.string(&x.ISRC)
+		case "producer":
+			if r.null() {
+				x.Producer = nil
+			} else {
+				if x.Producer == nil {
+					x.Producer = r.alloc.newProducer()
+				}
+				x.Producer.decodeJSON(r)
+			}
+		case "url":
+			r.string(&x.URL)
+		case "cattax":
+			if n, ok := r.uint(0); ok {
+				x.CategoryTaxonomies = uint(n)
+			}
+		case "cat":
+			seen |= 1 << 0
+			if r.null() {
+				x.Categories = nil
+			} else {
+				x.Categories = x.Categories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Categories)
+					if i < cap(x.Categories) {
+						x.Categories = x.Categories[:i+1]
+					} else {
+						x.Categories = append(x.Categories, "")
+					}
+					r.string((*string)(&x.Categories[i]))
+				}
+				if x.Categories == nil {
+					x.Categories = []ContentCategory{}
+				}
+			}
+		case "prodq":
+			if n, ok := r.int(0); ok {
+				x.ProductionQuality = ProductionQuality(n)
+				if r.strict && !validProductionQuality(x.ProductionQuality) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "context":
+			x.Context = ContentContext(r.quotedInt())
+			if r.strict && !validContentContext(x.Context) {
+				r.violation(ErrStrictEnum)
+			}
+		case "contentrating":
+			r.string(&x.ContentRating)
+		case "userrating":
+			r.string(&x.UserRating)
+		case "qagmediarating":
+			if n, ok := r.int(0); ok {
+				x.MediaRating = IQGRating(n)
+				if r.strict && !validIQGRating(x.MediaRating) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "keywords":
+			r.string(&x.Keywords)
+		case "kwarray":
+			seen |= 1 << 1
+			if r.null() {
+				x.KeywordArray = nil
+			} else {
+				x.KeywordArray = x.KeywordArray[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.KeywordArray)
+					if i < cap(x.KeywordArray) {
+						x.KeywordArray = x.KeywordArray[:i+1]
+					} else {
+						x.KeywordArray = append(x.KeywordArray, "")
+					}
+					r.string(&x.KeywordArray[i])
+				}
+				if x.KeywordArray == nil {
+					x.KeywordArray = []string{}
+				}
+			}
+		case "livestream":
+			if n, ok := r.int(0); ok {
+				x.LiveStream = int(n)
+			}
+		case "sourcerelationship":
+			if n, ok := r.int(0); ok {
+				x.SourceRelationship = int(n)
+			}
+		case "len":
+			if n, ok := r.int(0); ok {
+				x.Length = int(n)
+			}
+		case "language":
+			r.string(&x.Language)
+		case "langb":
+			r.string(&x.LangB)
+		case "embeddable":
+			if n, ok := r.int(0); ok {
+				x.Embeddable = int(n)
+			}
+		case "data":
+			seen |= 1 << 2
+			if r.null() {
+				x.Data = nil
+			} else {
+				x.Data = x.Data[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Data)
+					if i < cap(x.Data) {
+						x.Data = x.Data[:i+1]
+					} else {
+						x.Data = append(x.Data, Data{})
+					}
+					x.Data[i].decodeJSON(r)
+				}
+				if x.Data == nil {
+					x.Data = []Data{}
+				}
+			}
+		case "network":
+			if r.null() {
+				x.Network = nil
+			} else {
+				if x.Network == nil {
+					x.Network = r.alloc.newNetwork()
+				}
+				x.Network.decodeJSON(r)
+			}
+		case "channel":
+			if r.null() {
+				x.Channel = nil
+			} else {
+				if x.Channel == nil {
+					x.Channel = r.alloc.newChannel()
+				}
+				x.Channel.decodeJSON(r)
+			}
+		case "ext":
+			seen |= 1 << 3
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonContentKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Categories) == 0 {
+		x.Categories = nil
+	}
+	if seen&(1<<1) == 0 && len(x.KeywordArray) == 0 {
+		x.KeywordArray = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Data) == 0 {
+		x.Data = nil
+	}
+	if seen&(1<<3) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Content) Reset() {
+	x.ID = ""
+	x.Episode = 0
+	x.Title = ""
+	x.Series = ""
+	x.Season = ""
+	x.Artist = ""
+	x.Genre = ""
+	x.Album = ""
+	x.ISRC = ""
+	x.Producer = nil
+	x.URL = ""
+	x.CategoryTaxonomies = 0
+	for i, si := 0, x.Categories[:cap(x.Categories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Categories = x.Categories[:0]
+	x.ProductionQuality = 0
+	x.Context = 0
+	x.ContentRating = ""
+	x.UserRating = ""
+	x.MediaRating = 0
+	x.Keywords = ""
+	for i, si := 0, x.KeywordArray[:cap(x.KeywordArray)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.KeywordArray = x.KeywordArray[:0]
+	x.LiveStream = 0
+	x.SourceRelationship = 0
+	x.Length = 0
+	x.Language = ""
+	x.LangB = ""
+	x.Embeddable = 0
+	for i, si := 0, x.Data[:cap(x.Data)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Data = x.Data[:0]
+	x.Network = nil
+	x.Channel = nil
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Data) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Data) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Data) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Name)
+	}
+	if len(x.Segment) != 0 {
+		w.field("\"segment\":")
+		w.b = append(w.b, '[')
+		for i := range x.Segment {
+			w.elem()
+			x.Segment[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonDataKeys = []string{"id", "name", "segment", "ext"}
+
+func (x *Data) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "name":
+			r.string(&x.Name)
+		case "segment":
+			seen |= 1 << 0
+			if r.null() {
+				x.Segment = nil
+			} else {
+				x.Segment = x.Segment[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Segment)
+					if i < cap(x.Segment) {
+						x.Segment = x.Segment[:i+1]
+					} else {
+						x.Segment = append(x.Segment, Segment{})
+					}
+					x.Segment[i].decodeJSON(r)
+				}
+				if x.Segment == nil {
+					x.Segment = []Segment{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonDataKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Segment) == 0 {
+		x.Segment = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Data) Reset() {
+	x.ID = ""
+	x.Name = ""
+	for i, si := 0, x.Segment[:cap(x.Segment)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Segment = x.Segment[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Deal) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Deal) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Deal) encodeJSON(w *jsonWriter) {
+	x.normalize()
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.BidFloor != 0 {
+		w.field("\"bidfloor\":")
+		w.float(float64(x.BidFloor), 64)
+	}
+	if x.BidFloorCurrency != "" {
+		w.field("\"bidfloorcur\":")
+		w.string(x.BidFloorCurrency)
+	}
+	if x.AuctionType != 0 {
+		w.field("\"at\":")
+		w.int(int64(x.AuctionType))
+	}
+	if len(x.Seats) != 0 {
+		w.field("\"wseat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Seats {
+			w.elem()
+			w.string(x.Seats[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.AdvDomains) != 0 {
+		w.field("\"wadomain\":")
+		w.b = append(w.b, '[')
+		for i := range x.AdvDomains {
+			w.elem()
+			w.string(x.AdvDomains[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonDealKeys = []string{"id", "bidfloor", "bidfloorcur", "at", "wseat", "wadomain", "ext"}
+
+func (x *Deal) decodeJSON(r *jsonReader) {
+	x.Reset()
+	defer x.normalize()
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "bidfloor":
+			if n, ok := r.float(64); ok {
+				x.BidFloor = float64(n)
+			}
+		case "bidfloorcur":
+			r.string(&x.BidFloorCurrency)
+		case "at":
+			if n, ok := r.int(0); ok {
+				x.AuctionType = int(n)
+			}
+		case "wseat":
+			seen |= 1 << 0
+			if r.null() {
+				x.Seats = nil
+			} else {
+				x.Seats = x.Seats[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Seats)
+					if i < cap(x.Seats) {
+						x.Seats = x.Seats[:i+1]
+					} else {
+						x.Seats = append(x.Seats, "")
+					}
+					r.string(&x.Seats[i])
+				}
+				if x.Seats == nil {
+					x.Seats = []string{}
+				}
+			}
+		case "wadomain":
+			seen |= 1 << 1
+			if r.null() {
+				x.AdvDomains = nil
+			} else {
+				x.AdvDomains = x.AdvDomains[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.AdvDomains)
+					if i < cap(x.AdvDomains) {
+						x.AdvDomains = x.AdvDomains[:i+1]
+					} else {
+						x.AdvDomains = append(x.AdvDomains, "")
+					}
+					r.string(&x.AdvDomains[i])
+				}
+				if x.AdvDomains == nil {
+					x.AdvDomains = []string{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 2
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonDealKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Seats) == 0 {
+		x.Seats = nil
+	}
+	if seen&(1<<1) == 0 && len(x.AdvDomains) == 0 {
+		x.AdvDomains = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Deal) Reset() {
+	x.ID = ""
+	x.BidFloor = 0
+	x.BidFloorCurrency = ""
+	x.AuctionType = 0
+	for i, si := 0, x.Seats[:cap(x.Seats)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Seats = x.Seats[:0]
+	for i, si := 0, x.AdvDomains[:cap(x.AdvDomains)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.AdvDomains = x.AdvDomains[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Device) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Device) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Device) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Geo != nil {
+		w.field("\"geo\":")
+		x.Geo.encodeJSON(w)
+	}
+	if x.DNT != 0 {
+		w.field("\"dnt\":")
+		w.int(int64(x.DNT))
+	}
+	if x.LMT != 0 {
+		w.field("\"lmt\":")
+		w.int(int64(x.LMT))
+	}
+	if x.UA != "" {
+		w.field("\"ua\":")
+		w.string(x.UA)
+	}
+	w.field("\"sua\":")
+	x.StructuredUserAgent.encodeJSON(w)
+	if x.IP != "" {
+		w.field("\"ip\":")
+		w.string(x.IP)
+	}
+	if x.IPv6 != "" {
+		w.field("\"ipv6\":")
+		w.string(x.IPv6)
+	}
+	if x.DeviceType != 0 {
+		w.field("\"devicetype\":")
+		w.int(int64(x.DeviceType))
+	}
+	if x.Make != "" {
+		w.field("\"make\":")
+		w.string(x.Make)
+	}
+	if x.Model != "" {
+		w.field("\"model\":")
+		w.string(x.Model)
+	}
+	if x.OS != "" {
+		w.field("\"os\":")
+		w.string(x.OS)
+	}
+	if x.OSVersion != "" {
+		w.field("\"osv\":")
+		w.string(x.OSVersion)
+	}
+	if x.HWVersion != "" {
+		w.field("\"hwv\":")
+		w.string(x.HWVersion)
+	}
+	if x.Height != 0 {
+		w.field("\"h\":")
+		w.int(int64(x.Height))
+	}
+	if x.Width != 0 {
+		w.field("\"w\":")
+		w.int(int64(x.Width))
+	}
+	if x.PPI != 0 {
+		w.field("\"ppi\":")
+		w.int(int64(x.PPI))
+	}
+	if x.PixelRatio != 0 {
+		w.field("\"pxratio\":")
+		w.float(float64(x.PixelRatio), 64)
+	}
+	if x.JS != 0 {
+		w.field("\"js\":")
+		w.int(int64(x.JS))
+	}
+	if x.GeoFetch != 0 {
+		w.field("\"geofetch\":")
+		w.int(int64(x.GeoFetch))
+	}
+	if x.FlashVersion != "" {
+		w.field("\"flashver\":")
+		w.string(x.FlashVersion)
+	}
+	if x.Language != "" {
+		w.field("\"language\":")
+		w.string(x.Language)
+	}
+	if x.LangB != "" {
+		w.field("\"langb\":")
+		w.string(x.LangB)
+	}
+	if x.Carrier != "" {
+		w.field("\"carrier\":")
+		w.string(x.Carrier)
+	}
+	if x.MCCMNC != "" {
+		w.field("\"mccmnc\":")
+		w.string(x.MCCMNC)
+	}
+	if x.ConnType != 0 {
+		w.field("\"connectiontype\":")
+		w.int(int64(x.ConnType))
+	}
+	if x.IFA != "" {
+		w.field("\"ifa\":")
+		w.string(x.IFA)
+	}
+	if x.IDSHA1 != "" {
+		w.field("\"didsha1\":")
+		w.string(x.IDSHA1)
+	}
+	if x.IDMD5 != "" {
+		w.field("\"didmd5\":")
+		w.string(x.IDMD5)
+	}
+	if x.PIDSHA1 != "" {
+		w.field("\"dpidsha1\":")
+		w.string(x.PIDSHA1)
+	}
+	if x.PIDMD5 != "" {
+		w.field("\"dpidmd5\":")
+		w.string(x.PIDMD5)
+	}
+	if x.MacSHA1 != "" {
+		w.field("\"macsha1\":")
+		w.string(x.MacSHA1)
+	}
+	if x.MacMD5 != "" {
+		w.field("\"macmd5\":")
+		w.string(x.MacMD5)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonDeviceKeys = []string{"geo", "dnt", "lmt", "ua", "sua", "ip", "ipv6", "devicetype", "make", "model", "os", "osv", "hwv", "h", "w", "ppi", "pxratio", "js", "geofetch", "flashver", "language", "langb", "carrier", "mccmnc", "connectiontype", "ifa", "didsha1", "didmd5", "dpidsha1", "dpidmd5", "macsha1", "macmd5", "ext"}
+
+func (x *Device) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "geo":
+			if r.null() {
+				x.Geo = nil
+			} else {
+				if x.Geo == nil {
+					x.Geo = r.alloc.newGeo()
+				}
+				x.Geo.decodeJSON(r)
+			}
+		case "dnt":
+			if n, ok := r.int(0); ok {
+				x.DNT = int(n)
+			}
+		case "lmt":
+			if n, ok := r.int(0); ok {
+				x.LMT = int(n)
+			}
+		case "ua":
+			r.string(&x.UA)
+		case "sua":
+			x.StructuredUserAgent.decodeJSON(r)
+		case "ip":
+			r.string(&x.IP)
+		case "ipv6":
+			r.string(&x.IPv6)
+		case "devicetype":
+			if n, ok := r.int(0); ok {
+				x.DeviceType = DeviceType(n)
+				if r.strict && !validDeviceType(x.DeviceType) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "make":
+			r.string(&x.Make)
+		case "model":
+			r.string(&x.Model)
+		case "os":
+			r.string(&x.OS)
+		case "osv":
+			r.string(&x.OSVersion)
+		case "hwv":
+			r.string(&x.HWVersion)
+		case "h":
+			if n, ok := r.int(0); ok {
+				x.Height = int(n)
+			}
+		case "w":
+			if n, ok := r.int(0); ok {
+				x.Width = int(n)
+			}
+		case "ppi":
+			if n, ok := r.int(0); ok {
+				x.PPI = int(n)
+			}
+		case "pxratio":
+			if n, ok := r.float(64); ok {
+				x.PixelRatio = float64(n)
+			}
+		case "js":
+			if n, ok := r.int(0); ok {
+				x.JS = int(n)
+			}
+		case "geofetch":
+			if n, ok := r.int(0); ok {
+				x.GeoFetch = int(n)
+			}
+		case "flashver":
+			r.string(&x.FlashVersion)
+		case "language":
+			r.string(&x.Language)
+		case "langb":
+			r.string(&x.LangB)
+		case "carrier":
+			r.string(&x.Carrier)
+		case "mccmnc":
+			r.string(&x.MCCMNC)
+		case "connectiontype":
+			if n, ok := r.int(0); ok {
+				x.ConnType = ConnType(n)
+				if r.strict && !validConnType(x.ConnType) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "ifa":
+			r.string(&x.IFA)
+		case "didsha1":
+			r.string(&x.IDSHA1)
+		case "didmd5":
+			r.string(&x.IDMD5)
+		case "dpidsha1":
+			r.string(&x.PIDSHA1)
+		case "dpidmd5":
+			r.string(&x.PIDMD5)
+		case "macsha1":
+			r.string(&x.MacSHA1)
+		case "macmd5":
+			r.string(&x.MacMD5)
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonDeviceKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Device) Reset() {
+	x.Geo = nil
+	x.DNT = 0
+	x.LMT = 0
+	x.UA = ""
+	x.StructuredUserAgent.Reset()
+	x.IP = ""
+	x.IPv6 = ""
+	x.DeviceType = 0
+	x.Make = ""
+	x.Model = ""
+	x.OS = ""
+	x.OSVersion = ""
+	x.HWVersion = ""
+	x.Height = 0
+	x.Width = 0
+	x.PPI = 0
+	x.PixelRatio = 0
+	x.JS = 0
+	x.GeoFetch = 0
+	x.FlashVersion = ""
+	x.Language = ""
+	x.LangB = ""
+	x.Carrier = ""
+	x.MCCMNC = ""
+	x.ConnType = 0
+	x.IFA = ""
+	x.IDSHA1 = ""
+	x.IDMD5 = ""
+	x.PIDSHA1 = ""
+	x.PIDMD5 = ""
+	x.MacSHA1 = ""
+	x.MacMD5 = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *EID) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *EID) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *EID) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Source != "" {
+		w.field("\"source\":")
+		w.string(x.Source)
+	}
+	if len(x.UIDs) != 0 {
+		w.field("\"uids\":")
+		w.b = append(w.b, '[')
+		for i := range x.UIDs {
+			w.elem()
+			x.UIDs[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonEIDKeys = []string{"source", "uids", "ext"}
+
+func (x *EID) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "source":
+			r.string(&x.Source)
+		case "uids":
+			seen |= 1 << 0
+			if r.null() {
+				x.UIDs = nil
+			} else {
+				x.UIDs = x.UIDs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.UIDs)
+					if i < cap(x.UIDs) {
+						x.UIDs = x.UIDs[:i+1]
+					} else {
+						x.UIDs = append(x.UIDs, UID{})
+					}
+					x.UIDs[i].decodeJSON(r)
+				}
+				if x.UIDs == nil {
+					x.UIDs = []UID{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonEIDKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.UIDs) == 0 {
+		x.UIDs = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *EID) Reset() {
+	x.Source = ""
+	for i, si := 0, x.UIDs[:cap(x.UIDs)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.UIDs = x.UIDs[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Format) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Format) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Format) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Width != 0 {
+		w.field("\"w\":")
+		w.int(int64(x.Width))
+	}
+	if x.Height != 0 {
+		w.field("\"h\":")
+		w.int(int64(x.Height))
+	}
+	if x.WidthRatio != 0 {
+		w.field("\"wratio\":")
+		w.int(int64(x.WidthRatio))
+	}
+	if x.HeightRatio != 0 {
+		w.field("\"hratio\":")
+		w.int(int64(x.HeightRatio))
+	}
+	if x.WidthMin != 0 {
+		w.field("\"wmin\":")
+		w.int(int64(x.WidthMin))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonFormatKeys = []string{"w", "h", "wratio", "hratio", "wmin", "ext"}
+
+func (x *Format) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "w":
+			if n, ok := r.int(0); ok {
+				x.Width = int(n)
+			}
+		case "h":
+			if n, ok := r.int(0); ok {
+				x.Height = int(n)
+			}
+		case "wratio":
+			if n, ok := r.int(0); ok {
+				x.WidthRatio = int(n)
+			}
+		case "hratio":
+			if n, ok := r.int(0); ok {
+				x.HeightRatio = int(n)
+			}
+		case "wmin":
+			if n, ok := r.int(0); ok {
+				x.WidthMin = int(n)
+			}
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonFormatKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Format) Reset() {
+	x.Width = 0
+	x.Height = 0
+	x.WidthRatio = 0
+	x.HeightRatio = 0
+	x.WidthMin = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Geo) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Geo) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Geo) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Latitude != 0 {
+		w.field("\"lat\":")
+		w.float(float64(x.Latitude), 64)
+	}
+	if x.Longitude != 0 {
+		w.field("\"lon\":")
+		w.float(float64(x.Longitude), 64)
+	}
+	if x.Type != 0 {
+		w.field("\"type\":")
+		w.int(int64(x.Type))
+	}
+	if x.Accuracy != 0 {
+		w.field("\"accuracy\":")
+		w.int(int64(x.Accuracy))
+	}
+	if x.LastFix != 0 {
+		w.field("\"lastfix\":")
+		w.int(int64(x.LastFix))
+	}
+	if x.IPService != 0 {
+		w.field("\"ipservice\":")
+		w.int(int64(x.IPService))
+	}
+	if x.Country != "" {
+		w.field("\"country\":")
+		w.string(x.Country)
+	}
+	if x.Region != "" {
+		w.field("\"region\":")
+		w.string(x.Region)
+	}
+	if x.RegionFIPS104 != "" {
+		w.field("\"regionFIPS104\":")
+		w.string(x.RegionFIPS104)
+	}
+	if x.Metro != "" {
+		w.field("\"metro\":")
+		w.string(x.Metro)
+	}
+	if x.City != "" {
+		w.field("\"city\":")
+		w.string(x.City)
+	}
+	if x.ZIP != "" {
+		w.field("\"zip\":")
+		w.string(x.ZIP)
+	}
+	if x.UTCOffset != 0 {
+		w.field("\"utcoffset\":")
+		w.int(int64(x.UTCOffset))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonGeoKeys = []string{"lat", "lon", "type", "accuracy", "lastfix", "ipservice", "country", "region", "regionFIPS104", "metro", "city", "zip", "utcoffset", "ext"}
+
+func (x *Geo) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "lat":
+			if n, ok := r.float(64); ok {
+				x.Latitude = float64(n)
+			}
+		case "lon":
+			if n, ok := r.float(64); ok {
+				x.Longitude = float64(n)
+			}
+		case "type":
+			if n, ok := r.int(0); ok {
+				x.Type = LocationType(n)
+				if r.strict && !validLocationType(x.Type) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "accuracy":
+			if n, ok := r.int(0); ok {
+				x.Accuracy = int(n)
+			}
+		case "lastfix":
+			if n, ok := r.int(0); ok {
+				x.LastFix = int(n)
+			}
+		case "ipservice":
+			if n, ok := r.int(0); ok {
+				x.IPService = IPLocation(n)
+				if r.strict && !validIPLocation(x.IPService) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "country":
+			r.string(&x.Country)
+		case "region":
+			r.string(&x.Region)
+		case "regionFIPS104":
+			r.string(&x.RegionFIPS104)
+		case "metro":
+			r.string(&x.Metro)
+		case "city":
+			r.string(&x.City)
+		case "zip":
+			r.string(&x.ZIP)
+		case "utcoffset":
+			if n, ok := r.int(0); ok {
+				x.UTCOffset = int(n)
+			}
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonGeoKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Geo) Reset() {
+	x.Latitude = 0
+	x.Longitude = 0
+	x.Type = 0
+	x.Accuracy = 0
+	x.LastFix = 0
+	x.IPService = 0
+	x.Country = ""
+	x.Region = ""
+	x.RegionFIPS104 = ""
+	x.Metro = ""
+	x.City = ""
+	x.ZIP = ""
+	x.UTCOffset = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Impression) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Impression) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Impression) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	w.field("\"id\":")
+	w.string(x.ID)
+	if len(x.Metric) != 0 {
+		w.field("\"metric\":")
+		w.b = append(w.b, '[')
+		for i := range x.Metric {
+			w.elem()
+			if x.Metric[i] == nil {
+				w.null()
+			} else {
+				x.Metric[i].encodeJSON(w)
+			}
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Banner != nil {
+		w.field("\"banner\":")
+		x.Banner.encodeJSON(w)
+	}
+	if x.Video != nil {
+		w.field("\"video\":")
+		x.Video.encodeJSON(w)
+	}
+	if x.Audio != nil {
+		w.field("\"audio\":")
+		x.Audio.encodeJSON(w)
+	}
+	if x.Native != nil {
+		w.field("\"native\":")
+		x.Native.encodeJSON(w)
+	}
+	if x.PMP != nil {
+		w.field("\"pmp\":")
+		x.PMP.encodeJSON(w)
+	}
+	if x.DisplayManager != "" {
+		w.field("\"displaymanager\":")
+		w.string(x.DisplayManager)
+	}
+	if x.DisplayManagerVersion != "" {
+		w.field("\"displaymanagerver\":")
+		w.string(x.DisplayManagerVersion)
+	}
+	if x.Interstitial != 0 {
+		w.field("\"instl\":")
+		w.int(int64(x.Interstitial))
+	}
+	if x.TagID != "" {
+		w.field("\"tagid\":")
+		w.string(x.TagID)
+	}
+	if x.BidFloor != 0 {
+		w.field("\"bidfloor\":")
+		w.float(float64(x.BidFloor), 64)
+	}
+	if x.BidFloorCurrency != "" {
+		w.field("\"bidfloorcur\":")
+		w.string(x.BidFloorCurrency)
+	}
+	if x.ClickBrowser != 0 {
+		w.field("\"clickbrowser\":")
+		w.int(int64(x.ClickBrowser))
+	}
+	if x.Secure != 0 {
+		w.field("\"secure\":")
+		w.int(int64(x.Secure))
+	}
+	if len(x.IFrameBusters) != 0 {
+		w.field("\"iframebuster\":")
+		w.b = append(w.b, '[')
+		for i := range x.IFrameBusters {
+			w.elem()
+			w.string(x.IFrameBusters[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.RWDD != 0 {
+		w.field("\"rwdd\":")
+		w.int(int64(x.RWDD))
+	}
+	if x.SSAI != 0 {
+		w.field("\"ssai\":")
+		w.int(int64(x.SSAI))
+	}
+	if x.Exp != 0 {
+		w.field("\"exp\":")
+		w.int(int64(x.Exp))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonImpressionKeys = []string{"id", "metric", "banner", "video", "audio", "native", "pmp", "displaymanager", "displaymanagerver", "instl", "tagid", "bidfloor", "bidfloorcur", "clickbrowser", "secure", "iframebuster", "rwdd", "ssai", "exp", "ext"}
+
+func (x *Impression) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "metric":
+			seen |= 1 << 0
+			if r.null() {
+				x.Metric = nil
+			} else {
+				x.Metric = x.Metric[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Metric)
+					if i < cap(x.Metric) {
+						x.Metric = x.Metric[:i+1]
+					} else {
+						x.Metric = append(x.Metric, nil)
+					}
+					if r.null() {
+						x.Metric[i] = nil
+					} else {
+						if x.Metric[i] == nil {
+							x.Metric[i] = r.alloc.newMetric()
+						}
+						x.Metric[i].decodeJSON(r)
+					}
+				}
+				if x.Metric == nil {
+					x.Metric = []*Metric{}
+				}
+			}
+		case "banner":
+			if r.null() {
+				x.Banner = nil
+			} else {
+				if x.Banner == nil {
+					x.Banner = r.alloc.newBanner()
+				}
+				x.Banner.decodeJSON(r)
+			}
+		case "video":
+			if r.null() {
+				x.Video = nil
+			} else {
+				if x.Video == nil {
+					x.Video = r.alloc.newVideo()
+				}
+				x.Video.decodeJSON(r)
+			}
+		case "audio":
+			if r.null() {
+				x.Audio = nil
+			} else {
+				if x.Audio == nil {
+					x.Audio = r.alloc.newAudio()
+				}
+				x.Audio.decodeJSON(r)
+			}
+		case "native":
+			if r.null() {
+				x.Native = nil
+			} else {
+				if x.Native == nil {
+					x.Native = r.alloc.newNative()
+				}
+				x.Native.decodeJSON(r)
+			}
+		case "pmp":
+			if r.null() {
+				x.PMP = nil
+			} else {
+				if x.PMP == nil {
+					x.PMP = r.alloc.newPMP()
+				}
+				x.PMP.decodeJSON(r)
+			}
+		case "displaymanager":
+			r.string(&x.DisplayManager)
+		case "displaymanagerver":
+			r.string(&x.DisplayManagerVersion)
+		case "instl":
+			if n, ok := r.int(0); ok {
+				x.Interstitial = int(n)
+			}
+		case "tagid":
+			r.string(&x.TagID)
+		case "bidfloor":
+			if n, ok := r.float(64); ok {
+				x.BidFloor = float64(n)
+			}
+		case "bidfloorcur":
+			r.string(&x.BidFloorCurrency)
+		case "clickbrowser":
+			if n, ok := r.int(0); ok {
+				x.ClickBrowser = int(n)
+			}
+		case "secure":
+			if n, ok := r.int(0); ok {
+				x.Secure = int(n)
+			}
+		case "iframebuster":
+			seen |= 1 << 1
+			if r.null() {
+				x.IFrameBusters = nil
+			} else {
+				x.IFrameBusters = x.IFrameBusters[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.IFrameBusters)
+					if i < cap(x.IFrameBusters) {
+						x.IFrameBusters = x.IFrameBusters[:i+1]
+					} else {
+						x.IFrameBusters = append(x.IFrameBusters, "")
+					}
+					r.string(&x.IFrameBusters[i])
+				}
+				if x.IFrameBusters == nil {
+					x.IFrameBusters = []string{}
+				}
+			}
+		case "rwdd":
+			if n, ok := r.int(0); ok {
+				x.RWDD = int(n)
+			}
+		case "ssai":
+			if n, ok := r.int(0); ok {
+				x.SSAI = int(n)
+			}
+		case "exp":
+			if n, ok := r.int(0); ok {
+				x.Exp = int(n)
+			}
+		case "ext":
+			seen |= 1 << 2
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonImpressionKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Metric) == 0 {
+		x.Metric = nil
+	}
+	if seen&(1<<1) == 0 && len(x.IFrameBusters) == 0 {
+		x.IFrameBusters = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Impression) Reset() {
+	x.ID = ""
+	for i, si := 0, x.Metric[:cap(x.Metric)]; i < len(si); i++ {
+		si[i] = nil
+	}
+	x.Metric = x.Metric[:0]
+	x.Banner = nil
+	x.Video = nil
+	x.Audio = nil
+	x.Native = nil
+	x.PMP = nil
+	x.DisplayManager = ""
+	x.DisplayManagerVersion = ""
+	x.Interstitial = 0
+	x.TagID = ""
+	x.BidFloor = 0
+	x.BidFloorCurrency = ""
+	x.ClickBrowser = 0
+	x.Secure = 0
+	for i, si := 0, x.IFrameBusters[:cap(x.IFrameBusters)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.IFrameBusters = x.IFrameBusters[:0]
+	x.RWDD = 0
+	x.SSAI = 0
+	x.Exp = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Metric) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Metric) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Metric) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Type != "" {
+		w.field("\"type\":")
+		w.string(x.Type)
+	}
+	if x.Value != 0 {
+		w.field("\"value\":")
+		w.float(float64(x.Value), 64)
+	}
+	if x.Vendor != "" {
+		w.field("\"vendor\":")
+		w.string(x.Vendor)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonMetricKeys = []string{"type", "value", "vendor", "ext"}
+
+func (x *Metric) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "type":
+			r.string(&x.Type)
+		case "value":
+			if n, ok := r.float(64); ok {
+				x.Value = float64(n)
+			}
+		case "vendor":
+			r.string(&x.Vendor)
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonMetricKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Metric) Reset() {
+	x.Type = ""
+	x.Value = 0
+	x.Vendor = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Native) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Native) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Native) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	w.field("\"request\":")
+	w.raw(x.Request)
+	if x.Version != "" {
+		w.field("\"ver\":")
+		w.string(x.Version)
+	}
+	if len(x.APIs) != 0 {
+		w.field("\"api\":")
+		w.b = append(w.b, '[')
+		for i := range x.APIs {
+			w.elem()
+			w.int(int64(x.APIs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.BlockedAttrs) != 0 {
+		w.field("\"battr\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedAttrs {
+			w.elem()
+			w.int(int64(x.BlockedAttrs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonNativeKeys = []string{"request", "ver", "api", "battr", "ext"}
+
+func (x *Native) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "request":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Request = append(x.Request[:0], raw...)
+			}
+		case "ver":
+			r.string(&x.Version)
+		case "api":
+			seen |= 1 << 1
+			if r.null() {
+				x.APIs = nil
+			} else {
+				x.APIs = x.APIs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.APIs)
+					if i < cap(x.APIs) {
+						x.APIs = x.APIs[:i+1]
+					} else {
+						x.APIs = append(x.APIs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.APIs[i] = APIFramework(n)
+						if r.strict && !validAPIFramework(x.APIs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.APIs == nil {
+					x.APIs = []APIFramework{}
+				}
+			}
+		case "battr":
+			seen |= 1 << 2
+			if r.null() {
+				x.BlockedAttrs = nil
+			} else {
+				x.BlockedAttrs = x.BlockedAttrs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedAttrs)
+					if i < cap(x.BlockedAttrs) {
+						x.BlockedAttrs = x.BlockedAttrs[:i+1]
+					} else {
+						x.BlockedAttrs = append(x.BlockedAttrs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.BlockedAttrs[i] = CreativeAttribute(n)
+						if r.strict && !validCreativeAttribute(x.BlockedAttrs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.BlockedAttrs == nil {
+					x.BlockedAttrs = []CreativeAttribute{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 3
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonNativeKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Request) == 0 {
+		x.Request = nil
+	}
+	if seen&(1<<1) == 0 && len(x.APIs) == 0 {
+		x.APIs = nil
+	}
+	if seen&(1<<2) == 0 && len(x.BlockedAttrs) == 0 {
+		x.BlockedAttrs = nil
+	}
+	if seen&(1<<3) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Native) Reset() {
+	for i, si := 0, x.Request[:cap(x.Request)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Request = x.Request[:0]
+	x.Version = ""
+	for i, si := 0, x.APIs[:cap(x.APIs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.APIs = x.APIs[:0]
+	for i, si := 0, x.BlockedAttrs[:cap(x.BlockedAttrs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.BlockedAttrs = x.BlockedAttrs[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Network) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Network) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Network) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Name)
+	}
+	if x.Domain != "" {
+		w.field("\"domain\":")
+		w.string(x.Domain)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonNetworkKeys = []string{"id", "name", "domain", "ext"}
+
+func (x *Network) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "name":
+			r.string(&x.Name)
+		case "domain":
+			r.string(&x.Domain)
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonNetworkKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Network) Reset() {
+	x.ID = ""
+	x.Name = ""
+	x.Domain = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *PMP) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *PMP) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *PMP) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Private != 0 {
+		w.field("\"private_auction\":")
+		w.int(int64(x.Private))
+	}
+	if len(x.Deals) != 0 {
+		w.field("\"deals\":")
+		w.b = append(w.b, '[')
+		for i := range x.Deals {
+			w.elem()
+			x.Deals[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonPMPKeys = []string{"private_auction", "deals", "ext"}
+
+func (x *PMP) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "private_auction":
+			if n, ok := r.int(0); ok {
+				x.Private = int(n)
+			}
+		case "deals":
+			seen |= 1 << 0
+			if r.null() {
+				x.Deals = nil
+			} else {
+				x.Deals = x.Deals[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Deals)
+					if i < cap(x.Deals) {
+						x.Deals = x.Deals[:i+1]
+					} else {
+						x.Deals = append(x.Deals, Deal{})
+					}
+					x.Deals[i].decodeJSON(r)
+				}
+				if x.Deals == nil {
+					x.Deals = []Deal{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonPMPKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Deals) == 0 {
+		x.Deals = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *PMP) Reset() {
+	x.Private = 0
+	for i, si := 0, x.Deals[:cap(x.Deals)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Deals = x.Deals[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Producer) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Producer) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Producer) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Name)
+	}
+	if x.CategoryTaxonomies != 0 {
+		w.field("\"cattax\":")
+		w.uint(uint64(x.CategoryTaxonomies))
+	}
+	if len(x.Categories) != 0 {
+		w.field("\"cat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Categories {
+			w.elem()
+			w.string(string(x.Categories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Domain != "" {
+		w.field("\"domain\":")
+		w.string(x.Domain)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonProducerKeys = []string{"id", "name", "cattax", "cat", "domain", "ext"}
+
+func (x *Producer) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "name":
+			r.string(&x.Name)
+		case "cattax":
+			if n, ok := r.uint(0); ok {
+				x.CategoryTaxonomies = uint(n)
+			}
+		case "cat":
+			seen |= 1 << 0
+			if r.null() {
+				x.Categories = nil
+			} else {
+				x.Categories = x.Categories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Categories)
+					if i < cap(x.Categories) {
+						x.Categories = x.Categories[:i+1]
+					} else {
+						x.Categories = append(x.Categories, "")
+					}
+					r.string((*string)(&x.Categories[i]))
+				}
+				if x.Categories == nil {
+					x.Categories = []ContentCategory{}
+				}
+			}
+		case "domain":
+			r.string(&x.Domain)
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonProducerKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Categories) == 0 {
+		x.Categories = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Producer) Reset() {
+	x.ID = ""
+	x.Name = ""
+	x.CategoryTaxonomies = 0
+	for i, si := 0, x.Categories[:cap(x.Categories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Categories = x.Categories[:0]
+	x.Domain = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Publisher) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Publisher) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Publisher) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Name)
+	}
+	if x.CategoryTaxonomies != 0 {
+		w.field("\"cattax\":")
+		w.uint(uint64(x.CategoryTaxonomies))
+	}
+	if len(x.Categories) != 0 {
+		w.field("\"cat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Categories {
+			w.elem()
+			w.string(string(x.Categories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Domain != "" {
+		w.field("\"domain\":")
+		w.string(x.Domain)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonPublisherKeys = []string{"id", "name", "cattax", "cat", "domain", "ext"}
+
+func (x *Publisher) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "name":
+			r.string(&x.Name)
+		case "cattax":
+			if n, ok := r.uint(0); ok {
+				x.CategoryTaxonomies = uint(n)
+			}
+		case "cat":
+			seen |= 1 << 0
+			if r.null() {
+				x.Categories = nil
+			} else {
+				x.Categories = x.Categories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Categories)
+					if i < cap(x.Categories) {
+						x.Categories = x.Categories[:i+1]
+					} else {
+						x.Categories = append(x.Categories, "")
+					}
+					r.string((*string)(&x.Categories[i]))
+				}
+				if x.Categories == nil {
+					x.Categories = []ContentCategory{}
+				}
+			}
+		case "domain":
+			r.string(&x.Domain)
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonPublisherKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Categories) == 0 {
+		x.Categories = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Publisher) Reset() {
+	x.ID = ""
+	x.Name = ""
+	x.CategoryTaxonomies = 0
+	for i, si := 0, x.Categories[:cap(x.Categories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Categories = x.Categories[:0]
+	x.Domain = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Regulations) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Regulations) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Regulations) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.COPPA != 0 {
+		w.field("\"coppa\":")
+		w.int(int64(x.COPPA))
+	}
+	if x.GDPR != 0 {
+		w.field("\"gdpr\":")
+		w.int(int64(x.GDPR))
+	}
+	if x.UsPrivacy != "" {
+		w.field("\"us_privacy\":")
+		w.string(x.UsPrivacy)
+	}
+	if x.GPP != "" {
+		w.field("\"gpp\":")
+		w.string(x.GPP)
+	}
+	if len(x.GPPSID) != 0 {
+		w.field("\"gpp_sid\":")
+		w.b = append(w.b, '[')
+		for i := range x.GPPSID {
+			w.elem()
+			w.int(int64(x.GPPSID[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonRegulationsKeys = []string{"coppa", "gdpr", "us_privacy", "gpp", "gpp_sid", "ext"}
+
+func (x *Regulations) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "coppa":
+			if n, ok := r.int(0); ok {
+				x.COPPA = int(n)
+			}
+		case "gdpr":
+			if n, ok := r.int(0); ok {
+				x.GDPR = int(n)
+			}
+		case "us_privacy":
+			r.string(&x.UsPrivacy)
+		case "gpp":
+			r.string(&x.GPP)
+		case "gpp_sid":
+			seen |= 1 << 0
+			if r.null() {
+				x.GPPSID = nil
+			} else {
+				x.GPPSID = x.GPPSID[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.GPPSID)
+					if i < cap(x.GPPSID) {
+						x.GPPSID = x.GPPSID[:i+1]
+					} else {
+						x.GPPSID = append(x.GPPSID, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.GPPSID[i] = GPPSectionID(n)
+					}
+				}
+				if x.GPPSID == nil {
+					x.GPPSID = []GPPSectionID{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonRegulationsKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.GPPSID) == 0 {
+		x.GPPSID = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Regulations) Reset() {
+	x.COPPA = 0
+	x.GDPR = 0
+	x.UsPrivacy = ""
+	x.GPP = ""
+	for i, si := 0, x.GPPSID[:cap(x.GPPSID)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.GPPSID = x.GPPSID[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *SeatBid) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *SeatBid) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *SeatBid) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	w.field("\"bid\":")
+	if x.Bids == nil {
+		w.null()
+	} else {
+		w.b = append(w.b, '[')
+		for i := range x.Bids {
+			w.elem()
+			x.Bids[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Seat != "" {
+		w.field("\"seat\":")
+		w.string(x.Seat)
+	}
+	if x.Group != 0 {
+		w.field("\"group\":")
+		w.int(int64(x.Group))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonSeatBidKeys = []string{"bid", "seat", "group", "ext"}
+
+func (x *SeatBid) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "bid":
+			seen |= 1 << 0
+			if r.null() {
+				x.Bids = nil
+			} else {
+				x.Bids = x.Bids[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Bids)
+					if i < cap(x.Bids) {
+						x.Bids = x.Bids[:i+1]
+					} else {
+						x.Bids = append(x.Bids, Bid{})
+					}
+					x.Bids[i].decodeJSON(r)
+				}
+				if x.Bids == nil {
+					x.Bids = []Bid{}
+				}
+			}
+		case "seat":
+			r.string(&x.Seat)
+		case "group":
+			if n, ok := r.int(0); ok {
+				x.Group = int(n)
+			}
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonSeatBidKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Bids) == 0 {
+		x.Bids = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *SeatBid) Reset() {
+	for i, si := 0, x.Bids[:cap(x.Bids)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Bids = x.Bids[:0]
+	x.Seat = ""
+	x.Group = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Segment) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Segment) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Segment) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Name)
+	}
+	if x.Value != "" {
+		w.field("\"value\":")
+		w.string(x.Value)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonSegmentKeys = []string{"id", "name", "value", "ext"}
+
+func (x *Segment) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "name":
+			r.string(&x.Name)
+		case "value":
+			r.string(&x.Value)
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonSegmentKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Segment) Reset() {
+	x.ID = ""
+	x.Name = ""
+	x.Value = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Site) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Site) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Site) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Inventory.ID != "" {
+		w.field("\"id\":")
+		w.string(x.Inventory.ID)
+	}
+	if x.Inventory.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Inventory.Name)
+	}
+	if x.Inventory.Domain != "" {
+		w.field("\"domain\":")
+		w.string(x.Inventory.Domain)
+	}
+	if x.Inventory.CategoryTaxonomies != 0 {
+		w.field("\"cattax\":")
+		w.uint(uint64(x.Inventory.CategoryTaxonomies))
+	}
+	if len(x.Inventory.Categories) != 0 {
+		w.field("\"cat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.Categories {
+			w.elem()
+			w.string(string(x.Inventory.Categories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Inventory.SectionCategories) != 0 {
+		w.field("\"sectioncat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.SectionCategories {
+			w.elem()
+			w.string(string(x.Inventory.SectionCategories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Inventory.PageCategories) != 0 {
+		w.field("\"pagecat\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.PageCategories {
+			w.elem()
+			w.string(string(x.Inventory.PageCategories[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Inventory.PrivacyPolicy != nil {
+		w.field("\"privacypolicy\":")
+		w.int(int64(*x.Inventory.PrivacyPolicy))
+	}
+	if x.Inventory.Publisher != nil {
+		w.field("\"publisher\":")
+		x.Inventory.Publisher.encodeJSON(w)
+	}
+	if x.Inventory.Content != nil {
+		w.field("\"content\":")
+		x.Inventory.Content.encodeJSON(w)
+	}
+	if x.Inventory.Keywords != "" {
+		w.field("\"keywords\":")
+		w.string(x.Inventory.Keywords)
+	}
+	if len(x.Inventory.KeywordArray) != 0 {
+		w.field("\"kwarray\":")
+		w.b = append(w.b, '[')
+		for i := range x.Inventory.KeywordArray {
+			w.elem()
+			w.string(x.Inventory.KeywordArray[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Inventory.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Inventory.Ext)
+	}
+	if x.Page != "" {
+		w.field("\"page\":")
+		w.string(x.Page)
+	}
+	if x.Referrer != "" {
+		w.field("\"ref\":")
+		w.string(x.Referrer)
+	}
+	if x.Search != "" {
+		w.field("\"search\":")
+		w.string(x.Search)
+	}
+	if x.Mobile != 0 {
+		w.field("\"mobile\":")
+		w.int(int64(x.Mobile))
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonSiteKeys = []string{"id", "name", "domain", "cattax", "cat", "sectioncat", "pagecat", "privacypolicy", "publisher", "content", "keywords", "kwarray", "ext", "page", "ref", "search", "mobile"}
+
+func (x *Site) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.Inventory.ID)
+		case "name":
+			r.string(&x.Inventory.Name)
+		case "domain":
+			r.string(&x.Inventory.Domain)
+		case "cattax":
+			if n, ok := r.uint(0); ok {
+				x.Inventory.CategoryTaxonomies = uint(n)
+			}
+		case "cat":
+			seen |= 1 << 0
+			if r.null() {
+				x.Inventory.Categories = nil
+			} else {
+				x.Inventory.Categories = x.Inventory.Categories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.Categories)
+					if i < cap(x.Inventory.Categories) {
+						x.Inventory.Categories = x.Inventory.Categories[:i+1]
+					} else {
+						x.Inventory.Categories = append(x.Inventory.Categories, "")
+					}
+					r.string((*string)(&x.Inventory.Categories[i]))
+				}
+				if x.Inventory.Categories == nil {
+					x.Inventory.Categories = []ContentCategory{}
+				}
+			}
+		case "sectioncat":
+			seen |= 1 << 1
+			if r.null() {
+				x.Inventory.SectionCategories = nil
+			} else {
+				x.Inventory.SectionCategories = x.Inventory.SectionCategories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.SectionCategories)
+					if i < cap(x.Inventory.SectionCategories) {
+						x.Inventory.SectionCategories = x.Inventory.SectionCategories[:i+1]
+					} else {
+						x.Inventory.SectionCategories = append(x.Inventory.SectionCategories, "")
+					}
+					r.string((*string)(&x.Inventory.SectionCategories[i]))
+				}
+				if x.Inventory.SectionCategories == nil {
+					x.Inventory.SectionCategories = []ContentCategory{}
+				}
+			}
+		case "pagecat":
+			seen |= 1 << 2
+			if r.null() {
+				x.Inventory.PageCategories = nil
+			} else {
+				x.Inventory.PageCategories = x.Inventory.PageCategories[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.PageCategories)
+					if i < cap(x.Inventory.PageCategories) {
+						x.Inventory.PageCategories = x.Inventory.PageCategories[:i+1]
+					} else {
+						x.Inventory.PageCategories = append(x.Inventory.PageCategories, "")
+					}
+					r.string((*string)(&x.Inventory.PageCategories[i]))
+				}
+				if x.Inventory.PageCategories == nil {
+					x.Inventory.PageCategories = []ContentCategory{}
+				}
+			}
+		case "privacypolicy":
+			if r.null() {
+				x.Inventory.PrivacyPolicy = nil
+			} else {
+				if x.Inventory.PrivacyPolicy == nil {
+					x.Inventory.PrivacyPolicy = new(int)
+				}
+				if n, ok := r.int(0); ok {
+					*x.Inventory.PrivacyPolicy = int(n)
+				}
+			}
+		case "publisher":
+			if r.null() {
+				x.Inventory.Publisher = nil
+			} else {
+				if x.Inventory.Publisher == nil {
+					x.Inventory.Publisher = r.alloc.newPublisher()
+				}
+				x.Inventory.Publisher.decodeJSON(r)
+			}
+		case "content":
+			if r.null() {
+				x.Inventory.Content = nil
+			} else {
+				if x.Inventory.Content == nil {
+					x.Inventory.Content = r.alloc.newContent()
+				}
+				x.Inventory.Content.decodeJSON(r)
+			}
+		case "keywords":
+			r.string(&x.Inventory.Keywords)
+		case "kwarray":
+			seen |= 1 << 3
+			if r.null() {
+				x.Inventory.KeywordArray = nil
+			} else {
+				x.Inventory.KeywordArray = x.Inventory.KeywordArray[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Inventory.KeywordArray)
+					if i < cap(x.Inventory.KeywordArray) {
+						x.Inventory.KeywordArray = x.Inventory.KeywordArray[:i+1]
+					} else {
+						x.Inventory.KeywordArray = append(x.Inventory.KeywordArray, "")
+					}
+					r.string(&x.Inventory.KeywordArray[i])
+				}
+				if x.Inventory.KeywordArray == nil {
+					x.Inventory.KeywordArray = []string{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 4
+			if raw := r.raw(); raw != nil {
+				x.Inventory.Ext = append(x.Inventory.Ext[:0], raw...)
+			}
+		case "page":
+			r.string(&x.Page)
+		case "ref":
+			r.string(&x.Referrer)
+		case "search":
+			r.string(&x.Search)
+		case "mobile":
+			if n, ok := r.int(0); ok {
+				x.Mobile = int(n)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonSiteKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Inventory.Categories) == 0 {
+		x.Inventory.Categories = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Inventory.SectionCategories) == 0 {
+		x.Inventory.SectionCategories = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Inventory.PageCategories) == 0 {
+		x.Inventory.PageCategories = nil
+	}
+	if seen&(1<<3) == 0 && len(x.Inventory.KeywordArray) == 0 {
+		x.Inventory.KeywordArray = nil
+	}
+	if seen&(1<<4) == 0 && len(x.Inventory.Ext) == 0 {
+		x.Inventory.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Site) Reset() {
+	x.Inventory.ID = ""
+	x.Inventory.Name = ""
+	x.Inventory.Domain = ""
+	x.Inventory.CategoryTaxonomies = 0
+	for i, si := 0, x.Inventory.Categories[:cap(x.Inventory.Categories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.Categories = x.Inventory.Categories[:0]
+	for i, si := 0, x.Inventory.SectionCategories[:cap(x.Inventory.SectionCategories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.SectionCategories = x.Inventory.SectionCategories[:0]
+	for i, si := 0, x.Inventory.PageCategories[:cap(x.Inventory.PageCategories)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.PageCategories = x.Inventory.PageCategories[:0]
+	x.Inventory.PrivacyPolicy = nil
+	x.Inventory.Publisher = nil
+	x.Inventory.Content = nil
+	x.Inventory.Keywords = ""
+	for i, si := 0, x.Inventory.KeywordArray[:cap(x.Inventory.KeywordArray)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.Inventory.KeywordArray = x.Inventory.KeywordArray[:0]
+	for i, si := 0, x.Inventory.Ext[:cap(x.Inventory.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Inventory.Ext = x.Inventory.Ext[:0]
+	x.Page = ""
+	x.Referrer = ""
+	x.Search = ""
+	x.Mobile = 0
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Source) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Source) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Source) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.FinalSaleDecision != 0 {
+		w.field("\"fd\":")
+		w.uint(uint64(x.FinalSaleDecision))
+	}
+	if x.TransactionID != "" {
+		w.field("\"tid\":")
+		w.string(x.TransactionID)
+	}
+	if x.PaymentChain != "" {
+		w.field("\"pchain\":")
+		w.string(x.PaymentChain)
+	}
+	if x.SupplyChain != nil {
+		w.field("\"schain\":")
+		x.SupplyChain.encodeJSON(w)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonSourceKeys = []string{"fd", "tid", "pchain", "schain", "ext"}
+
+func (x *Source) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "fd":
+			if n, ok := r.uint(8); ok {
+				x.FinalSaleDecision = uint8(n)
+			}
+		case "tid":
+			r.string(&x.TransactionID)
+		case "pchain":
+			r.string(&x.PaymentChain)
+		case "schain":
+			if r.null() {
+				x.SupplyChain = nil
+			} else {
+				if x.SupplyChain == nil {
+					x.SupplyChain = r.alloc.newSupplyChain()
+				}
+				x.SupplyChain.decodeJSON(r)
+			}
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonSourceKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Source) Reset() {
+	x.FinalSaleDecision = 0
+	x.TransactionID = ""
+	x.PaymentChain = ""
+	x.SupplyChain = nil
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *SupplyChain) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *SupplyChain) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *SupplyChain) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Complete != 0 {
+		w.field("\"complete\":")
+		w.int(int64(x.Complete))
+	}
+	if len(x.Node) != 0 {
+		w.field("\"nodes\":")
+		w.b = append(w.b, '[')
+		for i := range x.Node {
+			w.elem()
+			x.Node[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Version != "" {
+		w.field("\"ver\":")
+		w.string(x.Version)
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonSupplyChainKeys = []string{"complete", "nodes", "ver", "ext"}
+
+func (x *SupplyChain) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "complete":
+			if n, ok := r.int(0); ok {
+				x.Complete = int(n)
+			}
+		case "nodes":
+			seen |= 1 << 0
+			if r.null() {
+				x.Node = nil
+			} else {
+				x.Node = x.Node[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Node)
+					if i < cap(x.Node) {
+						x.Node = x.Node[:i+1]
+					} else {
+						x.Node = append(x.Node, SupplyChainNode{})
+					}
+					x.Node[i].decodeJSON(r)
+				}
+				if x.Node == nil {
+					x.Node = []SupplyChainNode{}
+				}
+			}
+		case "ver":
+			r.string(&x.Version)
+		case "ext":
+			seen |= 1 << 1
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonSupplyChainKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Node) == 0 {
+		x.Node = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *SupplyChain) Reset() {
+	x.Complete = 0
+	for i, si := 0, x.Node[:cap(x.Node)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Node = x.Node[:0]
+	x.Version = ""
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *SupplyChainNode) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *SupplyChainNode) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *SupplyChainNode) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ASI != "" {
+		w.field("\"asi\":")
+		w.string(x.ASI)
+	}
+	if x.SID != "" {
+		w.field("\"sid\":")
+		w.string(x.SID)
+	}
+	if x.RequestId != "" {
+		w.field("\"rid\":")
+		w.string(x.RequestId)
+	}
+	if x.Name != "" {
+		w.field("\"name\":")
+		w.string(x.Name)
+	}
+	if x.Domain != "" {
+		w.field("\"domain\":")
+		w.string(x.Domain)
+	}
+	if x.HP != 0 {
+		w.field("\"hp\":")
+		w.int(int64(x.HP))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonSupplyChainNodeKeys = []string{"asi", "sid", "rid", "name", "domain", "hp", "ext"}
+
+func (x *SupplyChainNode) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "asi":
+			r.string(&x.ASI)
+		case "sid":
+			r.string(&x.SID)
+		case "rid":
+			r.string(&x.RequestId)
+		case "name":
+			r.string(&x.Name)
+		case "domain":
+			r.string(&x.Domain)
+		case "hp":
+			if n, ok := r.int(0); ok {
+				x.HP = int(n)
+			}
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonSupplyChainNodeKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *SupplyChainNode) Reset() {
+	x.ASI = ""
+	x.SID = ""
+	x.RequestId = ""
+	x.Name = ""
+	x.Domain = ""
+	x.HP = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *UID) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *UID) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *UID) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.Id != "" {
+		w.field("\"id\":")
+		w.string(x.Id)
+	}
+	if x.AtType != 0 {
+		w.field("\"atype\":")
+		w.int(int64(x.AtType))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonUIDKeys = []string{"id", "atype", "ext"}
+
+func (x *UID) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.Id)
+		case "atype":
+			if n, ok := r.int(0); ok {
+				x.AtType = int(n)
+			}
+		case "ext":
+			seen |= 1 << 0
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonUIDKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *UID) Reset() {
+	x.Id = ""
+	x.AtType = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *User) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *User) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *User) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if x.ID != "" {
+		w.field("\"id\":")
+		w.string(x.ID)
+	}
+	if x.BuyerID != "" {
+		w.field("\"buyerid\":")
+		w.string(x.BuyerID)
+	}
+	if x.BuyerUID != "" {
+		w.field("\"buyeruid\":")
+		w.string(x.BuyerUID)
+	}
+	if x.YearOfBirth != 0 {
+		w.field("\"yob\":")
+		w.int(int64(x.YearOfBirth))
+	}
+	if x.Gender != "" {
+		w.field("\"gender\":")
+		w.string(x.Gender)
+	}
+	if x.Keywords != "" {
+		w.field("\"keywords\":")
+		w.string(x.Keywords)
+	}
+	if len(x.KeywordArray) != 0 {
+		w.field("\"kwarray\":")
+		w.b = append(w.b, '[')
+		for i := range x.KeywordArray {
+			w.elem()
+			w.string(x.KeywordArray[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.CustomData != "" {
+		w.field("\"customdata\":")
+		w.string(x.CustomData)
+	}
+	if x.Geo != nil {
+		w.field("\"geo\":")
+		x.Geo.encodeJSON(w)
+	}
+	if len(x.Data) != 0 {
+		w.field("\"data\":")
+		w.b = append(w.b, '[')
+		for i := range x.Data {
+			w.elem()
+			x.Data[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Consent != "" {
+		w.field("\"consent\":")
+		w.string(x.Consent)
+	}
+	if len(x.Eids) != 0 {
+		w.field("\"eids\":")
+		w.b = append(w.b, '[')
+		for i := range x.Eids {
+			w.elem()
+			x.Eids[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonUserKeys = []string{"id", "buyerid", "buyeruid", "yob", "gender", "keywords", "kwarray", "customdata", "geo", "data", "consent", "eids", "ext"}
+
+func (x *User) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "id":
+			r.string(&x.ID)
+		case "buyerid":
+			r.string(&x.BuyerID)
+		case "buyeruid":
+			r.string(&x.BuyerUID)
+		case "yob":
+			if n, ok := r.int(0); ok {
+				x.YearOfBirth = int(n)
+			}
+		case "gender":
+			r.string(&x.Gender)
+		case "keywords":
+			r.string(&x.Keywords)
+		case "kwarray":
+			seen |= 1 << 0
+			if r.null() {
+				x.KeywordArray = nil
+			} else {
+				x.KeywordArray = x.KeywordArray[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.KeywordArray)
+					if i < cap(x.KeywordArray) {
+						x.KeywordArray = x.KeywordArray[:i+1]
+					} else {
+						x.KeywordArray = append(x.KeywordArray, "")
+					}
+					r.string(&x.KeywordArray[i])
+				}
+				if x.KeywordArray == nil {
+					x.KeywordArray = []string{}
+				}
+			}
+		case "customdata":
+			r.string(&x.CustomData)
+		case "geo":
+			if r.null() {
+				x.Geo = nil
+			} else {
+				if x.Geo == nil {
+					x.Geo = r.alloc.newGeo()
+				}
+				x.Geo.decodeJSON(r)
+			}
+		case "data":
+			seen |= 1 << 1
+			if r.null() {
+				x.Data = nil
+			} else {
+				x.Data = x.Data[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Data)
+					if i < cap(x.Data) {
+						x.Data = x.Data[:i+1]
+					} else {
+						x.Data = append(x.Data, Data{})
+					}
+					x.Data[i].decodeJSON(r)
+				}
+				if x.Data == nil {
+					x.Data = []Data{}
+				}
+			}
+		case "consent":
+			r.string(&x.Consent)
+		case "eids":
+			seen |= 1 << 2
+			if r.null() {
+				x.Eids = nil
+			} else {
+				x.Eids = x.Eids[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Eids)
+					if i < cap(x.Eids) {
+						x.Eids = x.Eids[:i+1]
+					} else {
+						x.Eids = append(x.Eids, EID{})
+					}
+					x.Eids[i].decodeJSON(r)
+				}
+				if x.Eids == nil {
+					x.Eids = []EID{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 3
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonUserKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.KeywordArray) == 0 {
+		x.KeywordArray = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Data) == 0 {
+		x.Data = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Eids) == 0 {
+		x.Eids = nil
+	}
+	if seen&(1<<3) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *User) Reset() {
+	x.ID = ""
+	x.BuyerID = ""
+	x.BuyerUID = ""
+	x.YearOfBirth = 0
+	x.Gender = ""
+	x.Keywords = ""
+	for i, si := 0, x.KeywordArray[:cap(x.KeywordArray)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.KeywordArray = x.KeywordArray[:0]
+	x.CustomData = ""
+	x.Geo = nil
+	for i, si := 0, x.Data[:cap(x.Data)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Data = x.Data[:0]
+	x.Consent = ""
+	for i, si := 0, x.Eids[:cap(x.Eids)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Eids = x.Eids[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *UserAgent) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *UserAgent) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *UserAgent) encodeJSON(w *jsonWriter) {
+	w.b = append(w.b, '{')
+	if len(x.Browsers) != 0 {
+		w.field("\"browsers\":")
+		w.b = append(w.b, '[')
+		for i := range x.Browsers {
+			w.elem()
+			x.Browsers[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.PMPlatform) != 0 {
+		w.field("\"platform\":")
+		w.b = append(w.b, '[')
+		for i := range x.PMPlatform {
+			w.elem()
+			x.PMPlatform[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Mobile != 0 {
+		w.field("\"mobile\":")
+		w.int(int64(x.Mobile))
+	}
+	if x.Architecture != "" {
+		w.field("\"architecture\":")
+		w.string(x.Architecture)
+	}
+	if x.Bitness != "" {
+		w.field("\"bitness\":")
+		w.string(x.Bitness)
+	}
+	if x.Model != "" {
+		w.field("\"model\":")
+		w.string(x.Model)
+	}
+	if x.Source != 0 {
+		w.field("\"source\":")
+		w.int(int64(x.Source))
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonUserAgentKeys = []string{"browsers", "platform", "mobile", "architecture", "bitness", "model", "source", "ext"}
+
+func (x *UserAgent) decodeJSON(r *jsonReader) {
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "browsers":
+			seen |= 1 << 0
+			if r.null() {
+				x.Browsers = nil
+			} else {
+				x.Browsers = x.Browsers[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Browsers)
+					if i < cap(x.Browsers) {
+						x.Browsers = x.Browsers[:i+1]
+					} else {
+						x.Browsers = append(x.Browsers, BrandVersion{})
+					}
+					x.Browsers[i].decodeJSON(r)
+				}
+				if x.Browsers == nil {
+					x.Browsers = []BrandVersion{}
+				}
+			}
+		case "platform":
+			seen |= 1 << 1
+			if r.null() {
+				x.PMPlatform = nil
+			} else {
+				x.PMPlatform = x.PMPlatform[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.PMPlatform)
+					if i < cap(x.PMPlatform) {
+						x.PMPlatform = x.PMPlatform[:i+1]
+					} else {
+						x.PMPlatform = append(x.PMPlatform, BrandVersion{})
+					}
+					x.PMPlatform[i].decodeJSON(r)
+				}
+				if x.PMPlatform == nil {
+					x.PMPlatform = []BrandVersion{}
+				}
+			}
+		case "mobile":
+			if n, ok := r.int(0); ok {
+				x.Mobile = int(n)
+			}
+		case "architecture":
+			r.string(&x.Architecture)
+		case "bitness":
+			r.string(&x.Bitness)
+		case "model":
+			r.string(&x.Model)
+		case "source":
+			if n, ok := r.int(0); ok {
+				x.Source = int(n)
+			}
+		case "ext":
+			seen |= 1 << 2
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonUserAgentKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.Browsers) == 0 {
+		x.Browsers = nil
+	}
+	if seen&(1<<1) == 0 && len(x.PMPlatform) == 0 {
+		x.PMPlatform = nil
+	}
+	if seen&(1<<2) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *UserAgent) Reset() {
+	for i, si := 0, x.Browsers[:cap(x.Browsers)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.Browsers = x.Browsers[:0]
+	for i, si := 0, x.PMPlatform[:cap(x.PMPlatform)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.PMPlatform = x.PMPlatform[:0]
+	x.Mobile = 0
+	x.Architecture = ""
+	x.Bitness = ""
+	x.Model = ""
+	x.Source = 0
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.
+func (x *Video) AppendJSON(dst []byte) ([]byte, error) {
+	w := jsonWriter{b: dst}
+	x.encodeJSON(&w)
+	return w.b, w.err
+}
+
+// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.
+func (x *Video) DecodeJSON(data []byte) error {
+	r := jsonReader{data: data}
+	x.decodeJSON(&r)
+	return r.finish()
+}
+
+func (x *Video) encodeJSON(w *jsonWriter) {
+	x.normalize()
+	w.b = append(w.b, '{')
+	if len(x.MIMEs) != 0 {
+		w.field("\"mimes\":")
+		w.b = append(w.b, '[')
+		for i := range x.MIMEs {
+			w.elem()
+			w.string(x.MIMEs[i])
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.MinDuration != 0 {
+		w.field("\"minduration\":")
+		w.int(int64(x.MinDuration))
+	}
+	if x.MaxDuration != 0 {
+		w.field("\"maxduration\":")
+		w.int(int64(x.MaxDuration))
+	}
+	if x.StartDelay != 0 {
+		w.field("\"startdelay\":")
+		w.int(int64(x.StartDelay))
+	}
+	if x.MaxSeq != 0 {
+		w.field("\"maxseq\":")
+		w.int(int64(x.MaxSeq))
+	}
+	if x.PodDur != 0 {
+		w.field("\"poddur\":")
+		w.int(int64(x.PodDur))
+	}
+	if len(x.Protocols) != 0 {
+		w.field("\"protocols\":")
+		w.b = append(w.b, '[')
+		for i := range x.Protocols {
+			w.elem()
+			w.int(int64(x.Protocols[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Protocol != 0 {
+		w.field("\"protocol\":")
+		w.int(int64(x.Protocol))
+	}
+	if x.Width != 0 {
+		w.field("\"w\":")
+		w.int(int64(x.Width))
+	}
+	if x.Height != 0 {
+		w.field("\"h\":")
+		w.int(int64(x.Height))
+	}
+	if x.PoDid != 0 {
+		w.field("\"podid\":")
+		w.int(int64(x.PoDid))
+	}
+	if x.PodSeq != 0 {
+		w.field("\"podseq\":")
+		w.int(int64(x.PodSeq))
+	}
+	if len(x.RqdDurs) != 0 {
+		w.field("\"rqddurs\":")
+		w.b = append(w.b, '[')
+		for i := range x.RqdDurs {
+			w.elem()
+			w.int(int64(x.RqdDurs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Placement != 0 {
+		w.field("\"placement\":")
+		w.int(int64(x.Placement))
+	}
+	if x.Linearity != 0 {
+		w.field("\"linearity\":")
+		w.int(int64(x.Linearity))
+	}
+	if x.Skip != 0 {
+		w.field("\"skip\":")
+		w.int(int64(x.Skip))
+	}
+	if x.SkipMin != 0 {
+		w.field("\"skipmin\":")
+		w.int(int64(x.SkipMin))
+	}
+	if x.SkipAfter != 0 {
+		w.field("\"skipafter\":")
+		w.int(int64(x.SkipAfter))
+	}
+	if x.Sequence != 0 {
+		w.field("\"sequence\":")
+		w.int(int64(x.Sequence))
+	}
+	if x.SlotInPod != 0 {
+		w.field("\"slotinpod\":")
+		w.int(int64(x.SlotInPod))
+	}
+	if x.MinCPMPerSec != 0 {
+		w.field("\"mincpmpersec\":")
+		w.float(float64(x.MinCPMPerSec), 32)
+	}
+	if len(x.BlockedAttrs) != 0 {
+		w.field("\"battr\":")
+		w.b = append(w.b, '[')
+		for i := range x.BlockedAttrs {
+			w.elem()
+			w.int(int64(x.BlockedAttrs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.MaxExtended != 0 {
+		w.field("\"maxextended\":")
+		w.int(int64(x.MaxExtended))
+	}
+	if x.MinBitrate != 0 {
+		w.field("\"minbitrate\":")
+		w.int(int64(x.MinBitrate))
+	}
+	if x.MaxBitrate != 0 {
+		w.field("\"maxbitrate\":")
+		w.int(int64(x.MaxBitrate))
+	}
+	if x.BoxingAllowed != nil {
+		w.field("\"boxingallowed\":")
+		w.int(int64(*x.BoxingAllowed))
+	}
+	if len(x.PlaybackMethods) != 0 {
+		w.field("\"playbackmethod\":")
+		w.b = append(w.b, '[')
+		for i := range x.PlaybackMethods {
+			w.elem()
+			w.int(int64(x.PlaybackMethods[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Delivery) != 0 {
+		w.field("\"delivery\":")
+		w.b = append(w.b, '[')
+		for i := range x.Delivery {
+			w.elem()
+			w.int(int64(x.Delivery[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if x.Position != 0 {
+		w.field("\"pos\":")
+		w.int(int64(x.Position))
+	}
+	if len(x.CompanionAds) != 0 {
+		w.field("\"companionad\":")
+		w.b = append(w.b, '[')
+		for i := range x.CompanionAds {
+			w.elem()
+			x.CompanionAds[i].encodeJSON(w)
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.APIs) != 0 {
+		w.field("\"api\":")
+		w.b = append(w.b, '[')
+		for i := range x.APIs {
+			w.elem()
+			w.int(int64(x.APIs[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.CompanionTypes) != 0 {
+		w.field("\"companiontype\":")
+		w.b = append(w.b, '[')
+		for i := range x.CompanionTypes {
+			w.elem()
+			w.int(int64(x.CompanionTypes[i]))
+		}
+		w.b = append(w.b, ']')
+	}
+	if len(x.Ext) != 0 {
+		w.field("\"ext\":")
+		w.raw(x.Ext)
+	}
+	w.b = append(w.b, '}')
+}
+
+var jsonVideoKeys = []string{"mimes", "minduration", "maxduration", "startdelay", "maxseq", "poddur", "protocols", "protocol", "w", "h", "podid", "podseq", "rqddurs", "placement", "linearity", "skip", "skipmin", "skipafter", "sequence", "slotinpod", "mincpmpersec", "battr", "maxextended", "minbitrate", "maxbitrate", "boxingallowed", "playbackmethod", "delivery", "pos", "companionad", "api", "companiontype", "ext"}
+
+func (x *Video) decodeJSON(r *jsonReader) {
+	x.Reset()
+	defer x.normalize()
+	var seen uint64
+	for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {
+	field:
+		switch string(key) {
+		case "mimes":
+			seen |= 1 << 0
+			if r.null() {
+				x.MIMEs = nil
+			} else {
+				x.MIMEs = x.MIMEs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.MIMEs)
+					if i < cap(x.MIMEs) {
+						x.MIMEs = x.MIMEs[:i+1]
+					} else {
+						x.MIMEs = append(x.MIMEs, "")
+					}
+					r.string(&x.MIMEs[i])
+				}
+				if x.MIMEs == nil {
+					x.MIMEs = []string{}
+				}
+			}
+		case "minduration":
+			if n, ok := r.int(0); ok {
+				x.MinDuration = int(n)
+			}
+		case "maxduration":
+			if n, ok := r.int(0); ok {
+				x.MaxDuration = int(n)
+			}
+		case "startdelay":
+			if n, ok := r.int(0); ok {
+				x.StartDelay = StartDelay(n)
+			}
+		case "maxseq":
+			if n, ok := r.int(0); ok {
+				x.MaxSeq = int(n)
+			}
+		case "poddur":
+			if n, ok := r.int(0); ok {
+				x.PodDur = int(n)
+			}
+		case "protocols":
+			seen |= 1 << 1
+			if r.null() {
+				x.Protocols = nil
+			} else {
+				x.Protocols = x.Protocols[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Protocols)
+					if i < cap(x.Protocols) {
+						x.Protocols = x.Protocols[:i+1]
+					} else {
+						x.Protocols = append(x.Protocols, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.Protocols[i] = Protocol(n)
+						if r.strict && !validProtocol(x.Protocols[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.Protocols == nil {
+					x.Protocols = []Protocol{}
+				}
+			}
+		case "protocol":
+			if n, ok := r.int(0); ok {
+				x.Protocol = Protocol(n)
+				if r.strict && !validProtocol(x.Protocol) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "w":
+			if n, ok := r.int(0); ok {
+				x.Width = int(n)
+			}
+		case "h":
+			if n, ok := r.int(0); ok {
+				x.Height = int(n)
+			}
+		case "podid":
+			if n, ok := r.int(0); ok {
+				x.PoDid = int(n)
+			}
+		case "podseq":
+			if n, ok := r.int(0); ok {
+				x.PodSeq = int(n)
+			}
+		case "rqddurs":
+			seen |= 1 << 2
+			if r.null() {
+				x.RqdDurs = nil
+			} else {
+				x.RqdDurs = x.RqdDurs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.RqdDurs)
+					if i < cap(x.RqdDurs) {
+						x.RqdDurs = x.RqdDurs[:i+1]
+					} else {
+						x.RqdDurs = append(x.RqdDurs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.RqdDurs[i] = int(n)
+					}
+				}
+				if x.RqdDurs == nil {
+					x.RqdDurs = []int{}
+				}
+			}
+		case "placement":
+			if n, ok := r.int(0); ok {
+				x.Placement = VideoPlacement(n)
+				if r.strict && !validVideoPlacement(x.Placement) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "linearity":
+			if n, ok := r.int(0); ok {
+				x.Linearity = VideoLinearity(n)
+				if r.strict && !validVideoLinearity(x.Linearity) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "skip":
+			if n, ok := r.int(0); ok {
+				x.Skip = int(n)
+			}
+		case "skipmin":
+			if n, ok := r.int(0); ok {
+				x.SkipMin = int(n)
+			}
+		case "skipafter":
+			if n, ok := r.int(0); ok {
+				x.SkipAfter = int(n)
+			}
+		case "sequence":
+			if n, ok := r.int(0); ok {
+				x.Sequence = int(n)
+			}
+		case "slotinpod":
+			if n, ok := r.int(0); ok {
+				x.SlotInPod = int(n)
+			}
+		case "mincpmpersec":
+			if n, ok := r.float(32); ok {
+				x.MinCPMPerSec = float32(n)
+			}
+		case "battr":
+			seen |= 1 << 3
+			if r.null() {
+				x.BlockedAttrs = nil
+			} else {
+				x.BlockedAttrs = x.BlockedAttrs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.BlockedAttrs)
+					if i < cap(x.BlockedAttrs) {
+						x.BlockedAttrs = x.BlockedAttrs[:i+1]
+					} else {
+						x.BlockedAttrs = append(x.BlockedAttrs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.BlockedAttrs[i] = CreativeAttribute(n)
+						if r.strict && !validCreativeAttribute(x.BlockedAttrs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.BlockedAttrs == nil {
+					x.BlockedAttrs = []CreativeAttribute{}
+				}
+			}
+		case "maxextended":
+			if n, ok := r.int(0); ok {
+				x.MaxExtended = int(n)
+			}
+		case "minbitrate":
+			if n, ok := r.int(0); ok {
+				x.MinBitrate = int(n)
+			}
+		case "maxbitrate":
+			if n, ok := r.int(0); ok {
+				x.MaxBitrate = int(n)
+			}
+		case "boxingallowed":
+			if r.null() {
+				x.BoxingAllowed = nil
+			} else {
+				if x.BoxingAllowed == nil {
+					x.BoxingAllowed = new(int)
+				}
+				if n, ok := r.int(0); ok {
+					*x.BoxingAllowed = int(n)
+				}
+			}
+		case "playbackmethod":
+			seen |= 1 << 4
+			if r.null() {
+				x.PlaybackMethods = nil
+			} else {
+				x.PlaybackMethods = x.PlaybackMethods[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.PlaybackMethods)
+					if i < cap(x.PlaybackMethods) {
+						x.PlaybackMethods = x.PlaybackMethods[:i+1]
+					} else {
+						x.PlaybackMethods = append(x.PlaybackMethods, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.PlaybackMethods[i] = VideoPlayback(n)
+						if r.strict && !validVideoPlayback(x.PlaybackMethods[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.PlaybackMethods == nil {
+					x.PlaybackMethods = []VideoPlayback{}
+				}
+			}
+		case "delivery":
+			seen |= 1 << 5
+			if r.null() {
+				x.Delivery = nil
+			} else {
+				x.Delivery = x.Delivery[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.Delivery)
+					if i < cap(x.Delivery) {
+						x.Delivery = x.Delivery[:i+1]
+					} else {
+						x.Delivery = append(x.Delivery, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.Delivery[i] = ContentDelivery(n)
+						if r.strict && !validContentDelivery(x.Delivery[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.Delivery == nil {
+					x.Delivery = []ContentDelivery{}
+				}
+			}
+		case "pos":
+			if n, ok := r.int(0); ok {
+				x.Position = AdPosition(n)
+				if r.strict && !validAdPosition(x.Position) {
+					r.violation(ErrStrictEnum)
+				}
+			}
+		case "companionad":
+			seen |= 1 << 6
+			if r.null() {
+				x.CompanionAds = nil
+			} else {
+				x.CompanionAds = x.CompanionAds[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.CompanionAds)
+					if i < cap(x.CompanionAds) {
+						x.CompanionAds = x.CompanionAds[:i+1]
+					} else {
+						x.CompanionAds = append(x.CompanionAds, Banner{})
+					}
+					x.CompanionAds[i].decodeJSON(r)
+				}
+				if x.CompanionAds == nil {
+					x.CompanionAds = []Banner{}
+				}
+			}
+		case "api":
+			seen |= 1 << 7
+			if r.null() {
+				x.APIs = nil
+			} else {
+				x.APIs = x.APIs[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.APIs)
+					if i < cap(x.APIs) {
+						x.APIs = x.APIs[:i+1]
+					} else {
+						x.APIs = append(x.APIs, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.APIs[i] = APIFramework(n)
+						if r.strict && !validAPIFramework(x.APIs[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.APIs == nil {
+					x.APIs = []APIFramework{}
+				}
+			}
+		case "companiontype":
+			seen |= 1 << 8
+			if r.null() {
+				x.CompanionTypes = nil
+			} else {
+				x.CompanionTypes = x.CompanionTypes[:0]
+				for ok := r.firstElem(); ok; ok = r.nextElem() {
+					i := len(x.CompanionTypes)
+					if i < cap(x.CompanionTypes) {
+						x.CompanionTypes = x.CompanionTypes[:i+1]
+					} else {
+						x.CompanionTypes = append(x.CompanionTypes, 0)
+					}
+					if n, ok := r.int(0); ok {
+						x.CompanionTypes[i] = CompanionType(n)
+						if r.strict && !validCompanionType(x.CompanionTypes[i]) {
+							r.violation(ErrStrictEnum)
+						}
+					}
+				}
+				if x.CompanionTypes == nil {
+					x.CompanionTypes = []CompanionType{}
+				}
+			}
+		case "ext":
+			seen |= 1 << 9
+			if raw := r.raw(); raw != nil {
+				x.Ext = append(x.Ext[:0], raw...)
+			}
+		default:
+			if k, ok := r.foldKey(key, jsonVideoKeys); ok {
+				key = []byte(k)
+				goto field
+			}
+			r.unknownKey()
+		}
+	}
+	if seen&(1<<0) == 0 && len(x.MIMEs) == 0 {
+		x.MIMEs = nil
+	}
+	if seen&(1<<1) == 0 && len(x.Protocols) == 0 {
+		x.Protocols = nil
+	}
+	if seen&(1<<2) == 0 && len(x.RqdDurs) == 0 {
+		x.RqdDurs = nil
+	}
+	if seen&(1<<3) == 0 && len(x.BlockedAttrs) == 0 {
+		x.BlockedAttrs = nil
+	}
+	if seen&(1<<4) == 0 && len(x.PlaybackMethods) == 0 {
+		x.PlaybackMethods = nil
+	}
+	if seen&(1<<5) == 0 && len(x.Delivery) == 0 {
+		x.Delivery = nil
+	}
+	if seen&(1<<6) == 0 && len(x.CompanionAds) == 0 {
+		x.CompanionAds = nil
+	}
+	if seen&(1<<7) == 0 && len(x.APIs) == 0 {
+		x.APIs = nil
+	}
+	if seen&(1<<8) == 0 && len(x.CompanionTypes) == 0 {
+		x.CompanionTypes = nil
+	}
+	if seen&(1<<9) == 0 && len(x.Ext) == 0 {
+		x.Ext = nil
+	}
+}
+
+// Reset clears the object for reuse. Slices are truncated and their backing arrays are
+// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.
+func (x *Video) Reset() {
+	for i, si := 0, x.MIMEs[:cap(x.MIMEs)]; i < len(si); i++ {
+		si[i] = ""
+	}
+	x.MIMEs = x.MIMEs[:0]
+	x.MinDuration = 0
+	x.MaxDuration = 0
+	x.StartDelay = 0
+	x.MaxSeq = 0
+	x.PodDur = 0
+	for i, si := 0, x.Protocols[:cap(x.Protocols)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Protocols = x.Protocols[:0]
+	x.Protocol = 0
+	x.Width = 0
+	x.Height = 0
+	x.PoDid = 0
+	x.PodSeq = 0
+	for i, si := 0, x.RqdDurs[:cap(x.RqdDurs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.RqdDurs = x.RqdDurs[:0]
+	x.Placement = 0
+	x.Linearity = 0
+	x.Skip = 0
+	x.SkipMin = 0
+	x.SkipAfter = 0
+	x.Sequence = 0
+	x.SlotInPod = 0
+	x.MinCPMPerSec = 0
+	for i, si := 0, x.BlockedAttrs[:cap(x.BlockedAttrs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.BlockedAttrs = x.BlockedAttrs[:0]
+	x.MaxExtended = 0
+	x.MinBitrate = 0
+	x.MaxBitrate = 0
+	x.BoxingAllowed = nil
+	for i, si := 0, x.PlaybackMethods[:cap(x.PlaybackMethods)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.PlaybackMethods = x.PlaybackMethods[:0]
+	for i, si := 0, x.Delivery[:cap(x.Delivery)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Delivery = x.Delivery[:0]
+	x.Position = 0
+	for i, si := 0, x.CompanionAds[:cap(x.CompanionAds)]; i < len(si); i++ {
+		si[i].Reset()
+	}
+	x.CompanionAds = x.CompanionAds[:0]
+	for i, si := 0, x.APIs[:cap(x.APIs)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.APIs = x.APIs[:0]
+	for i, si := 0, x.CompanionTypes[:cap(x.CompanionTypes)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.CompanionTypes = x.CompanionTypes[:0]
+	for i, si := 0, x.Ext[:cap(x.Ext)]; i < len(si); i++ {
+		si[i] = 0
+	}
+	x.Ext = x.Ext[:0]
+}
+
+// jsonAlloc recycles the nested objects of decoded requests, see RequestDecoder.
+type jsonAlloc struct {
+	app          []*App
+	nApp         int
+	audio        []*Audio
+	nAudio       int
+	banner       []*Banner
+	nBanner      int
+	channel      []*Channel
+	nChannel     int
+	content      []*Content
+	nContent     int
+	device       []*Device
+	nDevice      int
+	geo          []*Geo
+	nGeo         int
+	metric       []*Metric
+	nMetric      int
+	native       []*Native
+	nNative      int
+	network      []*Network
+	nNetwork     int
+	pmp          []*PMP
+	nPMP         int
+	producer     []*Producer
+	nProducer    int
+	publisher    []*Publisher
+	nPublisher   int
+	regulations  []*Regulations
+	nRegulations int
+	site         []*Site
+	nSite        int
+	source       []*Source
+	nSource      int
+	supplyChain  []*SupplyChain
+	nSupplyChain int
+	user         []*User
+	nUser        int
+	video        []*Video
+	nVideo       int
+}
+
+// release resets all objects and makes them available again, they must no longer be referenced.
+func (a *jsonAlloc) release() {
+	for _, x := range a.app[:a.nApp] {
+		x.Reset()
+	}
+	a.nApp = 0
+	for _, x := range a.audio[:a.nAudio] {
+		x.Reset()
+	}
+	a.nAudio = 0
+	for _, x := range a.banner[:a.nBanner] {
+		x.Reset()
+	}
+	a.nBanner = 0
+	for _, x := range a.channel[:a.nChannel] {
+		x.Reset()
+	}
+	a.nChannel = 0
+	for _, x := range a.content[:a.nContent] {
+		x.Reset()
+	}
+	a.nContent = 0
+	for _, x := range a.device[:a.nDevice] {
+		x.Reset()
+	}
+	a.nDevice = 0
+	for _, x := range a.geo[:a.nGeo] {
+		x.Reset()
+	}
+	a.nGeo = 0
+	for _, x := range a.metric[:a.nMetric] {
+		x.Reset()
+	}
+	a.nMetric = 0
+	for _, x := range a.native[:a.nNative] {
+		x.Reset()
+	}
+	a.nNative = 0
+	for _, x := range a.network[:a.nNetwork] {
+		x.Reset()
+	}
+	a.nNetwork = 0
+	for _, x := range a.pmp[:a.nPMP] {
+		x.Reset()
+	}
+	a.nPMP = 0
+	for _, x := range a.producer[:a.nProducer] {
+		x.Reset()
+	}
+	a.nProducer = 0
+	for _, x := range a.publisher[:a.nPublisher] {
+		x.Reset()
+	}
+	a.nPublisher = 0
+	for _, x := range a.regulations[:a.nRegulations] {
+		x.Reset()
+	}
+	a.nRegulations = 0
+	for _, x := range a.site[:a.nSite] {
+		x.Reset()
+	}
+	a.nSite = 0
+	for _, x := range a.source[:a.nSource] {
+		x.Reset()
+	}
+	a.nSource = 0
+	for _, x := range a.supplyChain[:a.nSupplyChain] {
+		x.Reset()
+	}
+	a.nSupplyChain = 0
+	for _, x := range a.user[:a.nUser] {
+		x.Reset()
+	}
+	a.nUser = 0
+	for _, x := range a.video[:a.nVideo] {
+		x.Reset()
+	}
+	a.nVideo = 0
+}
+
+func (a *jsonAlloc) newApp() *App {
+	if a == nil {
+		return new(App)
+	}
+	if a.nApp < len(a.app) {
+		x := a.app[a.nApp]
+		a.nApp++
+		return x
+	}
+	x := new(App)
+	a.app = append(a.app, x)
+	a.nApp++
+	return x
+}
+
+func (a *jsonAlloc) newAudio() *Audio {
+	if a == nil {
+		return new(Audio)
+	}
+	if a.nAudio < len(a.audio) {
+		x := a.audio[a.nAudio]
+		a.nAudio++
+		return x
+	}
+	x := new(Audio)
+	a.audio = append(a.audio, x)
+	a.nAudio++
+	return x
+}
+
+func (a *jsonAlloc) newBanner() *Banner {
+	if a == nil {
+		return new(Banner)
+	}
+	if a.nBanner < len(a.banner) {
+		x := a.banner[a.nBanner]
+		a.nBanner++
+		return x
+	}
+	x := new(Banner)
+	a.banner = append(a.banner, x)
+	a.nBanner++
+	return x
+}
+
+func (a *jsonAlloc) newChannel() *Channel {
+	if a == nil {
+		return new(Channel)
+	}
+	if a.nChannel < len(a.channel) {
+		x := a.channel[a.nChannel]
+		a.nChannel++
+		return x
+	}
+	x := new(Channel)
+	a.channel = append(a.channel, x)
+	a.nChannel++
+	return x
+}
+
+func (a *jsonAlloc) newContent() *Content {
+	if a == nil {
+		return new(Content)
+	}
+	if a.nContent < len(a.content) {
+		x := a.content[a.nContent]
+		a.nContent++
+		return x
+	}
+	x := new(Content)
+	a.content = append(a.content, x)
+	a.nContent++
+	return x
+}
+
+func (a *jsonAlloc) newDevice() *Device {
+	if a == nil {
+		return new(Device)
+	}
+	if a.nDevice < len(a.device) {
+		x := a.device[a.nDevice]
+		a.nDevice++
+		return x
+	}
+	x := new(Device)
+	a.device = append(a.device, x)
+	a.nDevice++
+	return x
+}
+
+func (a *jsonAlloc) newGeo() *Geo {
+	if a == nil {
+		return new(Geo)
+	}
+	if a.nGeo < len(a.geo) {
+		x := a.geo[a.nGeo]
+		a.nGeo++
+		return x
+	}
+	x := new(Geo)
+	a.geo = append(a.geo, x)
+	a.nGeo++
+	return x
+}
+
+func (a *jsonAlloc) newMetric() *Metric {
+	if a == nil {
+		return new(Metric)
+	}
+	if a.nMetric < len(a.metric) {
+		x := a.metric[a.nMetric]
+		a.nMetric++
+		return x
+	}
+	x := new(Metric)
+	a.metric = append(a.metric, x)
+	a.nMetric++
+	return x
+}
+
+func (a *jsonAlloc) newNative() *Native {
+	if a == nil {
+		return new(Native)
+	}
+	if a.nNative < len(a.native) {
+		x := a.native[a.nNative]
+		a.nNative++
+		return x
+	}
+	x := new(Native)
+	a.native = append(a.native, x)
+	a.nNative++
+	return x
+}
+
+func (a *jsonAlloc) newNetwork() *Network {
+	if a == nil {
+		return new(Network)
+	}
+	if a.nNetwork < len(a.network) {
+		x := a.network[a.nNetwork]
+		a.nNetwork++
+		return x
+	}
+	x := new(Network)
+	a.network = append(a.network, x)
+	a.nNetwork++
+	return x
+}
+
+func (a *jsonAlloc) newPMP() *PMP {
+	if a == nil {
+		return new(PMP)
+	}
+	if a.nPMP < len(a.pmp) {
+		x := a.pmp[a.nPMP]
+		a.nPMP++
+		return x
+	}
+	x := new(PMP)
+	a.pmp = append(a.pmp, x)
+	a.nPMP++
+	return x
+}
+
+func (a *jsonAlloc) newProducer() *Producer {
+	if a == nil {
+		return new(Producer)
+	}
+	if a.nProducer < len(a.producer) {
+		x := a.producer[a.nProducer]
+		a.nProducer++
+		return x
+	}
+	x := new(Producer)
+	a.producer = append(a.producer, x)
+	a.nProducer++
+	return x
+}
+
+func (a *jsonAlloc) newPublisher() *Publisher {
+	if a == nil {
+		return new(Publisher)
+	}
+	if a.nPublisher < len(a.publisher) {
+		x := a.publisher[a.nPublisher]
+		a.nPublisher++
+		return x
+	}
+	x := new(Publisher)
+	a.publisher = append(a.publisher, x)
+	a.nPublisher++
+	return x
+}
+
+func (a *jsonAlloc) newRegulations() *Regulations {
+	if a == nil {
+		return new(Regulations)
+	}
+	if a.nRegulations < len(a.regulations) {
+		x := a.regulations[a.nRegulations]
+		a.nRegulations++
+		return x
+	}
+	x := new(Regulations)
+	a.regulations = append(a.regulations, x)
+	a.nRegulations++
+	return x
+}
+
+func (a *jsonAlloc) newSite() *Site {
+	if a == nil {
+		return new(Site)
+	}
+	if a.nSite < len(a.site) {
+		x := a.site[a.nSite]
+		a.nSite++
+		return x
+	}
+	x := new(Site)
+	a.site = append(a.site, x)
+	a.nSite++
+	return x
+}
+
+func (a *jsonAlloc) newSource() *Source {
+	if a == nil {
+		return new(Source)
+	}
+	if a.nSource < len(a.source) {
+		x := a.source[a.nSource]
+		a.nSource++
+		return x
+	}
+	x := new(Source)
+	a.source = append(a.source, x)
+	a.nSource++
+	return x
+}
+
+func (a *jsonAlloc) newSupplyChain() *SupplyChain {
+	if a == nil {
+		return new(SupplyChain)
+	}
+	if a.nSupplyChain < len(a.supplyChain) {
+		x := a.supplyChain[a.nSupplyChain]
+		a.nSupplyChain++
+		return x
+	}
+	x := new(SupplyChain)
+	a.supplyChain = append(a.supplyChain, x)
+	a.nSupplyChain++
+	return x
+}
+
+func (a *jsonAlloc) newUser() *User {
+	if a == nil {
+		return new(User)
+	}
+	if a.nUser < len(a.user) {
+		x := a.user[a.nUser]
+		a.nUser++
+		return x
+	}
+	x := new(User)
+	a.user = append(a.user, x)
+	a.nUser++
+	return x
+}
+
+func (a *jsonAlloc) newVideo() *Video {
+	if a == nil {
+		return new(Video)
+	}
+	if a.nVideo < len(a.video) {
+		x := a.video[a.nVideo]
+		a.nVideo++
+		return x
+	}
+	x := new(Video)
+	a.video = append(a.video, x)
+	a.nVideo++
+	return x
+}
+
+// validAPIFramework returns true for declared and exchange-specific values.
+func validAPIFramework(v APIFramework) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9:
+		return true
+	}
+	return v >= 500
+}
+
+// validAdPosition returns true for declared and exchange-specific values.
+func validAdPosition(v AdPosition) bool {
+	switch v {
+	case 0, 1, 3, 4, 5, 6, 7:
+		return true
+	}
+	return v >= 500
+}
+
+// validBannerType returns true for declared and exchange-specific values.
+func validBannerType(v BannerType) bool {
+	switch v {
+	case 1, 2, 3, 4:
+		return true
+	}
+	return v >= 500
+}
+
+// validCompanionType returns true for declared and exchange-specific values.
+func validCompanionType(v CompanionType) bool {
+	switch v {
+	case 0, 1, 2, 3:
+		return true
+	}
+	return v >= 500
+}
+
+// validConnType returns true for declared and exchange-specific values.
+func validConnType(v ConnType) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5, 6:
+		return true
+	}
+	return v >= 500
+}
+
+// validContentContext returns true for declared and exchange-specific values.
+func validContentContext(v ContentContext) bool {
+	switch v {
+	case 1, 2, 3, 4, 5, 6, 7:
+		return true
+	}
+	return v >= 500
+}
+
+// validContentDelivery returns true for declared and exchange-specific values.
+func validContentDelivery(v ContentDelivery) bool {
+	switch v {
+	case 0, 1, 2, 3:
+		return true
+	}
+	return v >= 500
+}
+
+// validCreativeAttribute returns true for declared and exchange-specific values.
+func validCreativeAttribute(v CreativeAttribute) bool {
+	switch v {
+	case 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17:
+		return true
+	}
+	return v >= 500
+}
+
+// validDeviceType returns true for declared and exchange-specific values.
+func validDeviceType(v DeviceType) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5, 6, 7:
+		return true
+	}
+	return v >= 500
+}
+
+// validExpDir returns true for declared and exchange-specific values.
+func validExpDir(v ExpDir) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5:
+		return true
+	}
+	return v >= 500
+}
+
+// validFeedType returns true for declared and exchange-specific values.
+func validFeedType(v FeedType) bool {
+	switch v {
+	case 0, 1, 2, 3:
+		return true
+	}
+	return v >= 500
+}
+
+// validIPLocation returns true for declared and exchange-specific values.
+func validIPLocation(v IPLocation) bool {
+	switch v {
+	case 0, 1, 2, 3, 4:
+		return true
+	}
+	return v >= 500
+}
+
+// validIQGRating returns true for declared and exchange-specific values.
+func validIQGRating(v IQGRating) bool {
+	switch v {
+	case 0, 1, 2, 3:
+		return true
+	}
+	return v >= 500
+}
+
+// validLocationType returns true for declared and exchange-specific values.
+func validLocationType(v LocationType) bool {
+	switch v {
+	case 0, 1, 2, 3:
+		return true
+	}
+	return v >= 500
+}
+
+// validMediaType returns true for declared and exchange-specific values.
+func validMediaType(v MediaType) bool {
+	switch v {
+	case 1, 2, 3, 4:
+		return true
+	}
+	return v >= 500
+}
+
+// validNBR returns true for declared and exchange-specific values.
+func validNBR(v NBR) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5, 6, 7, 8:
+		return true
+	}
+	return v >= 500
+}
+
+// validProductionQuality returns true for declared and exchange-specific values.
+func validProductionQuality(v ProductionQuality) bool {
+	switch v {
+	case 0, 1, 2, 3:
+		return true
+	}
+	return v >= 500
+}
+
+// validProtocol returns true for declared and exchange-specific values.
+func validProtocol(v Protocol) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10:
+		return true
+	}
+	return v >= 500
+}
+
+// validVideoLinearity returns true for declared and exchange-specific values.
+func validVideoLinearity(v VideoLinearity) bool {
+	switch v {
+	case 0, 1, 2:
+		return true
+	}
+	return v >= 500
+}
+
+// validVideoPlacement returns true for declared and exchange-specific values.
+func validVideoPlacement(v VideoPlacement) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5:
+		return true
+	}
+	return v >= 500
+}
+
+// validVideoPlayback returns true for declared and exchange-specific values.
+func validVideoPlayback(v VideoPlayback) bool {
+	switch v {
+	case 0, 1, 2, 3, 4, 5, 6:
+		return true
+	}
+	return v >= 500
+}
+
+// validVolumeNorm returns true for declared and exchange-specific values.
+func validVolumeNorm(v VolumeNorm) bool {
+	switch v {
+	case 0, 1, 2, 3, 4:
+		return true
+	}
+	return v >= 500
+}
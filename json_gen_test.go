@@ -0,0 +1,221 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// jsonCodec is implemented by the objects of the generated JSON codec.
+type jsonCodec interface {
+	AppendJSON([]byte) ([]byte, error)
+	DecodeJSON([]byte) error
+}
+
+var fillStrings = []string{"", "a", "<b>&amp;", "é x", "\xff\xfe", "q\"\\\n\t\x01", "日本", "0", "12"}
+
+// fill sets the exported fields of v to random values.
+func fill(rng *rand.Rand, v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(fillStrings[rng.Intn(len(fillStrings))])
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rng.Intn(2) == 0 {
+			v.SetInt(int64(rng.Intn(2000) - 1000))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rng.Intn(2) == 0 {
+			v.SetUint(uint64(rng.Intn(200)))
+		}
+	case reflect.Float32, reflect.Float64:
+		fs := []float64{0, 1.5, 1e-7, 1e21, 123456789.125, -0.001, 3.14159}
+		v.SetFloat(fs[rng.Intn(len(fs))])
+	case reflect.Ptr:
+		if depth < 6 && rng.Intn(3) != 0 {
+			v.Set(reflect.New(v.Type().Elem()))
+			fill(rng, v.Elem(), depth+1)
+		}
+	case reflect.Slice:
+		if v.Type() == rawMessageType {
+			raws := []string{"", "null", `{"a": [1, 2, "<x>"] }`, `"s "`, "12"}
+			if s := raws[rng.Intn(len(raws))]; s != "" {
+				v.SetBytes([]byte(s))
+			}
+			return
+		}
+		switch rng.Intn(4) {
+		case 0:
+		case 1:
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		default:
+			if depth < 6 {
+				n := rng.Intn(3) + 1
+				v.Set(reflect.MakeSlice(v.Type(), n, n))
+				for i := 0; i < n; i++ {
+					fill(rng, v.Index(i), depth+1)
+				}
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				fill(rng, v.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+func TestAppendJSON_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 1000; n++ {
+		for _, typ := range []reflect.Type{reflect.TypeOf(BidRequest{}), reflect.TypeOf(BidResponse{})} {
+			v := reflect.New(typ)
+			fill(rng, v.Elem(), 0)
+
+			exp, err := json.Marshal(v.Interface())
+			if err != nil {
+				continue // invalid raw JSON in Ext
+			}
+			got, err := v.Interface().(jsonCodec).AppendJSON(nil)
+			if err != nil {
+				t.Fatalf("%s: %v", typ, err)
+			}
+			if !bytes.Equal(exp, got) {
+				t.Fatalf("%s: expected\n%s\ngot\n%s", typ, exp, got)
+			}
+
+			assertDecodeJSON(t, typ, exp)
+			for _, s := range mutateJSON(rng, string(exp)) {
+				assertDecodeJSON(t, typ, []byte(s))
+			}
+		}
+	}
+}
+
+func FuzzBidRequest_DecodeJSON(f *testing.F) {
+	f.Add([]byte(goldenRequest))
+	f.Add([]byte(`{"id":"1","imp":[{"id":"1","banner":{"w":300,"h":250}}],"ext":null}`))
+	f.Add([]byte(`{"ID":"1","imp":null,"tmax":"120"}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		assertDecodeJSON(t, reflect.TypeOf(BidRequest{}), data)
+	})
+}
+
+func FuzzBidResponse_DecodeJSON(f *testing.F) {
+	f.Add([]byte(goldenResponse))
+	f.Add([]byte(`{"id":"1","seatbid":[{"bid":[{"id":"1","impid":"1","price":0.5}]}]}`))
+	f.Add([]byte(`{"id":"1","nbr":2,"ext":{"a":[1,"é"]}}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		assertDecodeJSON(t, reflect.TypeOf(BidResponse{}), data)
+	})
+}
+
+// assertDecodeJSON checks that DecodeJSON agrees with encoding/json, and that AppendJSON
+// agrees with encoding/json on the decoded object.
+func assertDecodeJSON(t *testing.T, typ reflect.Type, data []byte) {
+	t.Helper()
+
+	exp, got := reflect.New(typ), reflect.New(typ)
+	expErr := json.Unmarshal(data, exp.Interface())
+	gotErr := got.Interface().(jsonCodec).DecodeJSON(data)
+	if (expErr == nil) != (gotErr == nil) {
+		t.Fatalf("%s: expected error %v, got %v\n%s", typ, expErr, gotErr, data)
+	}
+	if expErr != nil {
+		return
+	}
+	if !reflect.DeepEqual(exp.Interface(), got.Interface()) {
+		t.Fatalf("%s: decoded objects differ\n%s", typ, data)
+	}
+
+	expJSON, expErr := json.Marshal(exp.Interface())
+	gotJSON, gotErr := got.Interface().(jsonCodec).AppendJSON(nil)
+	if (expErr == nil) != (gotErr == nil) {
+		t.Fatalf("%s: expected error %v, got %v\n%s", typ, expErr, gotErr, data)
+	}
+	if expErr == nil && !bytes.Equal(expJSON, gotJSON) {
+		t.Fatalf("%s: expected\n%s\ngot\n%s", typ, expJSON, gotJSON)
+	}
+}
+
+// mutateJSON returns variants of valid JSON with changed key cases and value types, nulls,
+// unknown keys and syntax errors.
+func mutateJSON(rng *rand.Rand, s string) []string {
+	i, j := rng.Intn(len(s)), rng.Intn(len(s))
+	return []string{
+		strings.Replace(s, `"id"`, `"ID"`, -1),
+		strings.Replace(s, `:1`, `:"1"`, 1),
+		strings.Replace(s, `:[`, `:null,"zz":[`, 1),
+		strings.Replace(s, `:{`, `:null,"x":{`, 1),
+		strings.Replace(s, `"`, `"A`, 1),
+		strings.Replace(s, `:"`, `:`, 1),
+		strings.Replace(s, `,"`, `,"ext":null,"`, 1),
+		strings.Replace(s, `]`, `,null]`, 1),
+		strings.Replace(s, `{"`, `null`, 1),
+		s[:i] + s[i+1:],
+		s[:j] + " " + s[j:],
+	}
+}
+
+func BenchmarkBidRequest_AppendJSON(b *testing.B) {
+	req := benchmarkRequest(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = req.AppendJSON(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBidRequest_Marshal(b *testing.B) {
+	req := benchmarkRequest(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBidRequest_DecodeJSON(b *testing.B) {
+	data := []byte(goldenRequest)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		var req BidRequest
+		if err := req.DecodeJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBidRequest_Unmarshal(b *testing.B) {
+	data := []byte(goldenRequest)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		var req BidRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkRequest(b *testing.B) *BidRequest {
+	req := new(BidRequest)
+	if err := req.DecodeJSON([]byte(goldenRequest)); err != nil {
+		b.Fatal(err)
+	}
+	return req
+}
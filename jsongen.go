@@ -0,0 +1,802 @@
+//go:build ignore
+
+// jsongen generates json_gen.go, the reflection-free JSON codec of all objects
+// reachable from BidRequest and BidResponse, and clone_gen.go, their deep copies.
+// Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	output      = "json_gen.go"
+	cloneOutput = "clone_gen.go"
+)
+
+var roots = []string{"BidRequest", "BidResponse"}
+
+// openEnums are integer types with declared constants which accept other values as well.
+var openEnums = map[string]bool{
+	"StartDelay":   true, // start delay in seconds
+	"GPPSectionID": true, // open registry
+}
+
+// minCustomEnum is the first value reserved for exchange-specific enum values.
+const minCustomEnum = 500
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindInt
+	kindUint
+	kindFloat
+	kindRaw
+	kindStruct
+	kindPtr
+	kindSlice
+	kindStringOrNumber
+	kindQuotedInt
+)
+
+type typeInfo struct {
+	kind kind
+	expr string    // Go type expression
+	name string    // name of struct types
+	bits int       // bit size of numbers
+	elem *typeInfo // element of pointers and slices
+	enum string    // name of enum types
+}
+
+type field struct {
+	path      string // Go selector, relative to the receiver
+	key       string
+	omitEmpty bool
+	typ       *typeInfo
+}
+
+type generator struct {
+	decls   map[string]ast.Expr
+	methods map[string]map[string]bool
+	structs map[string][]field
+	order   []string
+	buf     bytes.Buffer
+
+	allocated map[string]bool    // types allocated by jsonAlloc
+	consts    map[string][]int64 // declared constants of integer types
+	enums     []string           // enum types validated by strict decoding
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return name != output && name != cloneOutput && name != "jsongen.go" && !strings.HasSuffix(name, "_test.go")
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := &generator{
+		decls:   make(map[string]ast.Expr),
+		methods: make(map[string]map[string]bool),
+		structs: make(map[string][]field),
+
+		allocated: make(map[string]bool),
+		consts:    make(map[string][]int64),
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			g.collect(file)
+		}
+	}
+	for _, name := range roots {
+		g.visit(name)
+	}
+	sort.Strings(g.order)
+	sort.Strings(g.enums)
+
+	g.write(output, g.emit)
+	g.write(cloneOutput, g.emitClones)
+}
+
+func (g *generator) write(name string, emit func()) {
+	g.buf.Reset()
+	g.p("// Code generated by jsongen; DO NOT EDIT.")
+	g.p("")
+	g.p("package openrtb")
+	emit()
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, g.buf.Bytes())
+	}
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (g *generator) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					g.decls[s.Name.Name] = s.Type
+				case *ast.ValueSpec:
+					g.collectConsts(d.Tok, s)
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) != 1 {
+				continue
+			}
+			recv := d.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if id, ok := recv.(*ast.Ident); ok {
+				if g.methods[id.Name] == nil {
+					g.methods[id.Name] = make(map[string]bool)
+				}
+				g.methods[id.Name][d.Name.Name] = true
+			}
+		}
+	}
+}
+
+// collectConsts records integer constants with an explicit type and value.
+func (g *generator) collectConsts(tok token.Token, s *ast.ValueSpec) {
+	id, ok := s.Type.(*ast.Ident)
+	if tok != token.CONST || !ok || len(s.Values) != len(s.Names) {
+		return
+	}
+	for _, v := range s.Values {
+		neg := false
+		if u, ok := v.(*ast.UnaryExpr); ok && u.Op == token.SUB {
+			neg, v = true, u.X
+		}
+		lit, ok := v.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			continue
+		}
+		n, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if neg {
+			n = -n
+		}
+		g.consts[id.Name] = append(g.consts[id.Name], n)
+	}
+}
+
+// enum returns the name of an integer type if strict decoding validates its values.
+func (g *generator) enum(name string) string {
+	if len(g.consts[name]) == 0 || openEnums[name] {
+		return ""
+	}
+	for _, e := range g.enums {
+		if e == name {
+			return name
+		}
+	}
+	g.enums = append(g.enums, name)
+	return name
+}
+
+// underlyingStruct resolves declarations like `type Publisher ThirdParty`.
+func (g *generator) underlyingStruct(name string) *ast.StructType {
+	for {
+		switch t := g.decls[name].(type) {
+		case *ast.StructType:
+			return t
+		case *ast.Ident:
+			name = t.Name
+		default:
+			return nil
+		}
+	}
+}
+
+func (g *generator) visit(name string) {
+	if _, ok := g.structs[name]; ok {
+		return
+	}
+	st := g.underlyingStruct(name)
+	if st == nil {
+		log.Fatalf("%s is not a struct", name)
+	}
+	g.structs[name] = nil
+	g.order = append(g.order, name)
+	g.structs[name] = g.fields(st, "")
+}
+
+func (g *generator) fields(st *ast.StructType, prefix string) []field {
+	var fields []field
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(s)
+		}
+		key, opts, _ := strings.Cut(tag.Get("json"), ",")
+		if key == "-" && opts == "" {
+			continue
+		}
+
+		if len(f.Names) == 0 {
+			id, ok := f.Type.(*ast.Ident)
+			if !ok || key != "" {
+				log.Fatalf("unsupported embedded field %s", types.ExprString(f.Type))
+			}
+			fields = append(fields, g.fields(g.underlyingStruct(id.Name), prefix+id.Name+".")...)
+			continue
+		}
+
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			k := key
+			if k == "" {
+				k = n.Name
+			}
+			fields = append(fields, field{
+				path:      prefix + n.Name,
+				key:       k,
+				omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+				typ:       g.resolve(f.Type),
+			})
+		}
+	}
+	return fields
+}
+
+func (g *generator) resolve(expr ast.Expr) *typeInfo {
+	t := &typeInfo{expr: types.ExprString(expr)}
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		t.kind, t.elem = kindPtr, g.resolve(e.X)
+	case *ast.ArrayType:
+		if e.Len != nil {
+			log.Fatalf("unsupported array type %s", t.expr)
+		}
+		t.kind, t.elem = kindSlice, g.resolve(e.Elt)
+	case *ast.SelectorExpr:
+		if t.expr != "json.RawMessage" {
+			log.Fatalf("unsupported type %s", t.expr)
+		}
+		t.kind = kindRaw
+	case *ast.Ident:
+		switch e.Name {
+		case "StringOrNumber":
+			t.kind = kindStringOrNumber
+		case "NumberOrString", "ContentContext":
+			t.kind, t.enum = kindQuotedInt, g.enum(e.Name)
+		default:
+			g.resolveIdent(t, e.Name)
+		}
+	default:
+		log.Fatalf("unsupported type %s", t.expr)
+	}
+	return t
+}
+
+func (g *generator) resolveIdent(t *typeInfo, name string) {
+	switch name {
+	case "string":
+		t.kind = kindString
+	case "int":
+		t.kind = kindInt // bit size 0 is the size of int
+	case "int64":
+		t.kind, t.bits = kindInt, 64
+	case "int8", "int16", "int32":
+		t.kind = kindInt
+		t.bits, _ = strconv.Atoi(strings.TrimPrefix(name, "int"))
+	case "uint", "uintptr":
+		t.kind = kindUint
+	case "uint64":
+		t.kind, t.bits = kindUint, 64
+	case "uint8", "byte", "uint16", "uint32":
+		t.kind = kindUint
+		t.bits, _ = strconv.Atoi(strings.TrimPrefix(name, "uint"))
+		if name == "byte" {
+			t.bits = 8
+		}
+	case "float64":
+		t.kind, t.bits = kindFloat, 64
+	case "float32":
+		t.kind, t.bits = kindFloat, 32
+	default:
+		decl, ok := g.decls[name]
+		if !ok {
+			log.Fatalf("unsupported type %s", name)
+		}
+		if g.methods[name]["UnmarshalJSON"] && !g.methods[name]["normalize"] {
+			log.Fatalf("type %s has a custom JSON decoding", name)
+		}
+		if g.underlyingStruct(name) != nil {
+			t.kind, t.name = kindStruct, name
+			g.visit(name)
+			return
+		}
+		id, ok := decl.(*ast.Ident)
+		if !ok {
+			log.Fatalf("unsupported type %s", name)
+		}
+		if t.enum == "" {
+			t.enum = g.enum(name)
+		}
+		g.resolveIdent(t, id.Name)
+	}
+}
+
+func (g *generator) p(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+	g.buf.WriteByte('\n')
+}
+
+func (g *generator) emit() {
+	for _, name := range g.order {
+		g.emitType(name)
+	}
+	g.emitAlloc()
+	for _, name := range g.enums {
+		g.emitEnum(name)
+	}
+}
+
+func (g *generator) emitEnum(name string) {
+	values := append([]int64(nil), g.consts[name]...)
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	cases := make([]string, 0, len(values))
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			cases = append(cases, strconv.FormatInt(v, 10))
+		}
+	}
+
+	g.p("")
+	g.p("// valid%s returns true for declared and exchange-specific values.", name)
+	g.p("func valid%s(v %s) bool {", name, name)
+	g.p("switch v {")
+	g.p("case %s:", strings.Join(cases, ", "))
+	g.p("return true")
+	g.p("}")
+	g.p("return v >= %d", minCustomEnum)
+	g.p("}")
+}
+
+func (g *generator) emitAlloc() {
+	var names []string
+	for _, name := range g.order {
+		if g.allocated[name] {
+			names = append(names, name)
+		}
+	}
+
+	g.p("")
+	g.p("// jsonAlloc recycles the nested objects of decoded requests, see RequestDecoder.")
+	g.p("type jsonAlloc struct {")
+	for _, name := range names {
+		g.p("%s []*%s", lower(name), name)
+		g.p("n%s int", name)
+	}
+	g.p("}")
+
+	g.p("")
+	g.p("// release resets all objects and makes them available again, they must no longer be referenced.")
+	g.p("func (a *jsonAlloc) release() {")
+	for _, name := range names {
+		g.p("for _, x := range a.%s[:a.n%s] {", lower(name), name)
+		g.p("x.Reset()")
+		g.p("}")
+		g.p("a.n%s = 0", name)
+	}
+	g.p("}")
+
+	for _, name := range names {
+		l := lower(name)
+		g.p("")
+		g.p("func (a *jsonAlloc) new%s() *%s {", name, name)
+		g.p("if a == nil {")
+		g.p("return new(%s)", name)
+		g.p("}")
+		g.p("if a.n%s < len(a.%s) {", name, l)
+		g.p("x := a.%s[a.n%s]", l, name)
+		g.p("a.n%s++", name)
+		g.p("return x")
+		g.p("}")
+		g.p("x := new(%s)", name)
+		g.p("a.%s = append(a.%s, x)", l, l)
+		g.p("a.n%s++", name)
+		g.p("return x")
+		g.p("}")
+	}
+}
+
+func lower(name string) string {
+	for i, c := range name {
+		if c < 'A' || c > 'Z' {
+			if i > 1 {
+				i--
+			}
+			return strings.ToLower(name[:i]) + name[i:]
+		}
+	}
+	return strings.ToLower(name)
+}
+
+func (g *generator) emitType(name string) {
+	fields := g.structs[name]
+	normalize := g.methods[name]["normalize"]
+
+	g.p("")
+	g.p("// AppendJSON appends the JSON encoding of the object to dst, the result is the same as with json.Marshal.")
+	g.p("func (x *%s) AppendJSON(dst []byte) ([]byte, error) {", name)
+	g.p("w := jsonWriter{b: dst}")
+	g.p("x.encodeJSON(&w)")
+	g.p("return w.b, w.err")
+	g.p("}")
+	g.p("")
+	g.p("// DecodeJSON decodes the object from JSON data following the rules of json.Unmarshal.")
+	g.p("func (x *%s) DecodeJSON(data []byte) error {", name)
+	g.p("r := jsonReader{data: data}")
+	g.p("x.decodeJSON(&r)")
+	g.p("return r.finish()")
+	g.p("}")
+
+	// encoder
+	g.p("")
+	g.p("func (x *%s) encodeJSON(w *jsonWriter) {", name)
+	if normalize {
+		g.p("x.normalize()")
+	}
+	g.p("w.b = append(w.b, '{')")
+	for _, f := range fields {
+		v := "x." + f.path
+		if f.omitEmpty {
+			if cond := nonEmpty(v, f.typ); cond != "" {
+				g.p("if %s {", cond)
+				g.emitField(f, v, true)
+				g.p("}")
+				continue
+			}
+		}
+		g.emitField(f, v, false)
+	}
+	g.p("w.b = append(w.b, '}')")
+	g.p("}")
+
+	// decoder
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = strconv.Quote(f.key)
+	}
+	g.p("")
+	g.p("var json%sKeys = []string{%s}", name, strings.Join(keys, ", "))
+	g.p("")
+	g.p("func (x *%s) decodeJSON(r *jsonReader) {", name)
+	if normalize {
+		g.p("x.Reset()")
+		g.p("defer x.normalize()")
+	}
+
+	// Reset truncates slices to retain their backing arrays, the ones
+	// which are missing from the input are set to nil afterwards.
+	var reused []string
+	for _, f := range fields {
+		if f.typ.kind == kindSlice || f.typ.kind == kindRaw {
+			reused = append(reused, "x."+f.path)
+		}
+	}
+	if len(reused) > 64 {
+		log.Fatalf("%s has too many slices", name)
+	}
+	if len(reused) != 0 {
+		g.p("var seen uint64")
+	}
+
+	g.p("for key, ok := r.firstKey(); ok; key, ok = r.nextKey() {")
+	g.p("field:")
+	g.p("switch string(key) {")
+	for _, f := range fields {
+		g.p("case %q:", f.key)
+		for i, v := range reused {
+			if v == "x."+f.path {
+				g.p("seen |= 1 << %d", i)
+			}
+		}
+		g.emitDecode("x."+f.path, f.typ, 0)
+	}
+	g.p("default:")
+	g.p("if k, ok := r.foldKey(key, json%sKeys); ok {", name)
+	g.p("key = []byte(k)")
+	g.p("goto field")
+	g.p("}")
+	g.p("r.unknownKey()")
+	g.p("}")
+	g.p("}")
+	for i, v := range reused {
+		g.p("if seen&(1<<%d) == 0 && len(%s) == 0 {", i, v)
+		g.p("%s = nil", v)
+		g.p("}")
+	}
+	g.p("}")
+
+	// reset
+	g.p("")
+	g.p("// Reset clears the object for reuse. Slices are truncated and their backing arrays are")
+	g.p("// cleared, so that DecodeJSON can reuse the allocated memory without retaining old data.")
+	g.p("func (x *%s) Reset() {", name)
+	for _, f := range fields {
+		g.emitReset("x."+f.path, f.typ, 0)
+	}
+	g.p("}")
+}
+
+func (g *generator) emitReset(v string, t *typeInfo, depth int) {
+	switch t.kind {
+	case kindStruct:
+		g.p("%s.Reset()", v)
+	case kindPtr:
+		g.p("%s = nil", v)
+	case kindRaw:
+		i, s := loopVar(depth), "s"+loopVar(depth)
+		g.p("for %s, %s := 0, %s[:cap(%s)]; %s < len(%s); %s++ {", i, s, v, v, i, s, i)
+		g.p("%s[%s] = 0", s, i)
+		g.p("}")
+		g.p("%s = %s[:0]", v, v)
+	case kindSlice:
+		i, s := loopVar(depth), "s"+loopVar(depth)
+		g.p("for %s, %s := 0, %s[:cap(%s)]; %s < len(%s); %s++ {", i, s, v, v, i, s, i)
+		g.emitReset(s+"["+i+"]", t.elem, depth+1)
+		g.p("}")
+		g.p("%s = %s[:0]", v, v)
+	default:
+		g.p("%s = %s", v, zero(t))
+	}
+}
+
+// emitField encodes a member, nonNil is set if the value is known to be a non-nil pointer or slice.
+func (g *generator) emitField(f field, v string, nonNil bool) {
+	g.p("w.field(%s)", strconv.Quote(strconv.Quote(f.key)+":"))
+	g.emitEncode(v, f.typ, 0, nonNil)
+}
+
+// nonEmpty returns the condition under which an omitempty field is encoded.
+func nonEmpty(v string, t *typeInfo) string {
+	switch t.kind {
+	case kindString, kindStringOrNumber:
+		return v + ` != ""`
+	case kindInt, kindUint, kindFloat, kindQuotedInt:
+		return v + " != 0"
+	case kindRaw, kindSlice:
+		return "len(" + v + ") != 0"
+	case kindPtr:
+		return v + " != nil"
+	}
+	return "" // structs are never empty
+}
+
+func (g *generator) emitEncode(v string, t *typeInfo, depth int, nonNil bool) {
+	if !nonNil && (t.kind == kindPtr || t.kind == kindSlice) {
+		g.p("if %s == nil {", v)
+		g.p("w.null()")
+		g.p("} else {")
+		g.emitEncode(v, t, depth, true)
+		g.p("}")
+		return
+	}
+
+	switch t.kind {
+	case kindString:
+		if t.expr == "string" {
+			g.p("w.string(%s)", v)
+		} else {
+			g.p("w.string(string(%s))", v)
+		}
+	case kindStringOrNumber:
+		g.p("w.string(string(%s))", v)
+	case kindInt, kindQuotedInt:
+		g.p("w.int(int64(%s))", v)
+	case kindUint:
+		g.p("w.uint(uint64(%s))", v)
+	case kindFloat:
+		g.p("w.float(float64(%s), %d)", v, t.bits)
+	case kindRaw:
+		g.p("w.raw(%s)", v)
+	case kindStruct:
+		g.p("%s.encodeJSON(w)", v)
+	case kindPtr:
+		if t.elem.kind == kindStruct {
+			g.emitEncode(v, t.elem, depth, false)
+		} else {
+			g.emitEncode("*"+v, t.elem, depth, false)
+		}
+	case kindSlice:
+		i := loopVar(depth)
+		g.p("w.b = append(w.b, '[')")
+		g.p("for %s := range %s {", i, v)
+		g.p("w.elem()")
+		g.emitEncode(v+"["+i+"]", t.elem, depth+1, false)
+		g.p("}")
+		g.p("w.b = append(w.b, ']')")
+	}
+}
+
+func (g *generator) emitDecode(v string, t *typeInfo, depth int) {
+	switch t.kind {
+	case kindString:
+		if t.expr == "string" {
+			g.p("r.string(&%s)", v)
+		} else {
+			g.p("r.string((*string)(&%s))", v)
+		}
+	case kindStringOrNumber:
+		g.p("r.stringOrNumber(&%s)", v)
+	case kindQuotedInt:
+		g.p("%s = %s(r.quotedInt())", v, t.expr)
+		g.emitEnumCheck(v, t)
+	case kindInt:
+		g.p("if n, ok := r.int(%d); ok {", t.bits)
+		g.p("%s = %s(n)", v, t.expr)
+		g.emitEnumCheck(v, t)
+		g.p("}")
+	case kindUint:
+		g.p("if n, ok := r.uint(%d); ok {", t.bits)
+		g.p("%s = %s(n)", v, t.expr)
+		g.p("}")
+	case kindFloat:
+		g.p("if n, ok := r.float(%d); ok {", t.bits)
+		g.p("%s = %s(n)", v, t.expr)
+		g.p("}")
+	case kindRaw:
+		g.p("if raw := r.raw(); raw != nil {")
+		g.p("%s = append(%s[:0], raw...)", v, v)
+		g.p("}")
+	case kindStruct:
+		g.p("%s.decodeJSON(r)", v)
+	case kindPtr:
+		g.p("if r.null() {")
+		g.p("%s = nil", v)
+		g.p("} else {")
+		g.p("if %s == nil {", v)
+		if t.elem.kind == kindStruct {
+			g.allocated[t.elem.name] = true
+			g.p("%s = r.alloc.new%s()", v, t.elem.name)
+		} else {
+			g.p("%s = new(%s)", v, t.elem.expr)
+		}
+		g.p("}")
+		if t.elem.kind == kindStruct {
+			g.emitDecode(v, t.elem, depth)
+		} else {
+			g.emitDecode("*"+v, t.elem, depth)
+		}
+		g.p("}")
+	case kindSlice:
+		n := loopVar(depth)
+		g.p("if r.null() {")
+		g.p("%s = nil", v)
+		g.p("} else {")
+		g.p("%s = %s[:0]", v, v)
+		g.p("for ok := r.firstElem(); ok; ok = r.nextElem() {")
+		g.p("%s := len(%s)", n, v)
+		g.p("if %s < cap(%s) {", n, v)
+		g.p("%s = %s[:%s+1]", v, v, n)
+		g.p("} else {")
+		g.p("%s = append(%s, %s)", v, v, zero(t.elem))
+		g.p("}")
+		g.emitDecode(v+"["+n+"]", t.elem, depth+1)
+		g.p("}")
+		g.p("if %s == nil {", v)
+		g.p("%s = %s{}", v, t.expr)
+		g.p("}")
+		g.p("}")
+	}
+}
+
+func (g *generator) emitEnumCheck(v string, t *typeInfo) {
+	if t.enum != "" {
+		g.p("if r.strict && !valid%s(%s) {", t.enum, v)
+		g.p("r.violation(ErrStrictEnum)")
+		g.p("}")
+	}
+}
+
+func (g *generator) emitClones() {
+	for _, name := range g.order {
+		g.p("")
+		g.p("// Clone returns a deep copy of the object, which shares no pointers, slices or")
+		g.p("// raw JSON with the original. A nil object results in nil.")
+		g.p("func (x *%s) Clone() *%s {", name, name)
+		g.p("if x == nil {")
+		g.p("return nil")
+		g.p("}")
+		g.p("c := new(%s)", name)
+		g.p("x.copyTo(c)")
+		g.p("return c")
+		g.p("}")
+		g.p("")
+		g.p("func (x *%s) copyTo(c *%s) {", name, name)
+		g.p("*c = *x")
+		for _, f := range g.structs[name] {
+			g.emitCopy("c."+f.path, "x."+f.path, f.typ, 0)
+		}
+		g.p("}")
+	}
+}
+
+// emitCopy deep-copies src to dst, which already holds a shallow copy. Appending to a
+// zero-capacity slice of the source allocates new memory and preserves nil slices.
+func (g *generator) emitCopy(dst, src string, t *typeInfo, depth int) {
+	switch t.kind {
+	case kindStruct:
+		g.p("%s.copyTo(&%s)", src, dst)
+	case kindPtr:
+		if t.elem.kind == kindStruct {
+			g.p("%s = %s.Clone()", dst, src)
+			return
+		}
+		g.p("if %s != nil {", src)
+		g.p("%s = new(%s)", dst, t.elem.expr)
+		g.p("*%s = *%s", dst, src)
+		g.emitCopy("*"+dst, "*"+src, t.elem, depth)
+		g.p("}")
+	case kindRaw:
+		g.p("%s = append(%s[:0:0], %s...)", dst, src, src)
+	case kindSlice:
+		if !t.elem.deep() {
+			g.p("%s = append(%s[:0:0], %s...)", dst, src, src)
+			return
+		}
+		n := loopVar(depth)
+		g.p("if %s != nil {", src)
+		g.p("%s = make(%s, len(%s))", dst, t.expr, src)
+		g.p("for %s := range %s {", n, src)
+		g.emitCopy(dst+"["+n+"]", src+"["+n+"]", t.elem, depth+1)
+		g.p("}")
+		g.p("}")
+	}
+}
+
+// deep returns true if copies of the type must not share memory with the original.
+func (t *typeInfo) deep() bool {
+	switch t.kind {
+	case kindStruct, kindPtr, kindSlice, kindRaw:
+		return true
+	}
+	return false
+}
+
+func zero(t *typeInfo) string {
+	switch t.kind {
+	case kindString, kindStringOrNumber:
+		return `""`
+	case kindStruct:
+		return t.expr + "{}"
+	case kindPtr, kindSlice, kindRaw:
+		return "nil"
+	}
+	return "0"
+}
+
+func loopVar(depth int) string {
+	return string(rune('i' + depth))
+}
@@ -0,0 +1,703 @@
+package openrtb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Decoding errors
+var (
+	ErrJSONSyntax       = errors.New("openrtb: invalid JSON")
+	ErrJSONUnexpected   = errors.New("openrtb: unexpected JSON value type")
+	ErrJSONOutOfRange   = errors.New("openrtb: JSON number out of range")
+	ErrJSONTrailingData = errors.New("openrtb: unexpected data after top-level JSON value")
+)
+
+// jsonReader is the decoding runtime of the generated JSON codec, a pull parser over a
+// byte slice following the decoding rules of encoding/json. The first error is sticky,
+// subsequent reads return zero values.
+type jsonReader struct {
+	data    []byte
+	pos     int
+	err     error
+	scratch []byte
+
+	alloc  *jsonAlloc        // recycled nested objects, optional
+	intern map[string]string // interned short strings, optional
+
+	// path tracking of lenient and strict decoding
+	track      bool
+	frames     []jsonFrame
+	lenient    Coercion
+	coercions  []AppliedCoercion
+	strict     bool
+	violations Violations
+	errPointer string // JSON Pointer of the value which failed tracked decoding
+}
+
+// jsonFrame is an object or array on the path to the current value.
+type jsonFrame struct {
+	key    string   // current member of objects
+	index  int      // current element of arrays, -1 for objects
+	single bool     // a single value decoded as an array
+	keys   []string // keys of objects read so far, by strict decoding
+}
+
+// path returns the JSON path of the current value, e.g. "imp[0].banner.w".
+func (r *jsonReader) path() string {
+	var b []byte
+	for _, f := range r.frames {
+		if f.index < 0 {
+			if len(b) != 0 {
+				b = append(b, '.')
+			}
+			b = append(b, f.key...)
+		} else if !f.single {
+			b = append(b, '[')
+			b = strconv.AppendInt(b, int64(f.index), 10)
+			b = append(b, ']')
+		}
+	}
+	return string(b)
+}
+
+// pointer returns the JSON Pointer (RFC 6901) of the current value, e.g. "/imp/0/banner/w".
+func (r *jsonReader) pointer() string {
+	var b []byte
+	for _, f := range r.frames {
+		if f.index < 0 {
+			b = append(b, '/')
+			b = appendPointerToken(b, f.key)
+		} else if !f.single {
+			b = append(b, '/')
+			b = strconv.AppendInt(b, int64(f.index), 10)
+		}
+	}
+	return string(b)
+}
+
+func (r *jsonReader) push(f jsonFrame) {
+	if r.track {
+		r.frames = append(r.frames, f)
+	}
+}
+
+func (r *jsonReader) pop() {
+	if r.track && len(r.frames) != 0 {
+		r.frames = r.frames[:len(r.frames)-1]
+	}
+}
+
+func (r *jsonReader) top() *jsonFrame {
+	if r.track && len(r.frames) != 0 {
+		return &r.frames[len(r.frames)-1]
+	}
+	return nil
+}
+
+// coerced records a coercion of the current value.
+func (r *jsonReader) coerced(c Coercion) {
+	r.coercions = append(r.coercions, AppliedCoercion{Path: r.path(), Coercion: c})
+}
+
+// violation records a violation of strict decoding at the current value.
+func (r *jsonReader) violation(err error) {
+	r.violations = append(r.violations, Violation{Path: r.path(), Err: err})
+}
+
+// mismatch fails with a type or range error of a valid JSON value, which strict decoding
+// records as a violation instead.
+func (r *jsonReader) mismatch(err error) {
+	if r.strict {
+		r.violation(err)
+	} else {
+		r.fail(err)
+	}
+}
+
+func (r *jsonReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+		if r.track {
+			r.errPointer = r.pointer()
+		}
+	}
+	r.pos = len(r.data)
+}
+
+func (r *jsonReader) ws() {
+	for r.pos < len(r.data) {
+		switch r.data[r.pos] {
+		case ' ', '\t', '\n', '\r':
+			r.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next non-whitespace byte, or zero at the end of input.
+func (r *jsonReader) peek() byte {
+	r.ws()
+	if r.pos < len(r.data) {
+		return r.data[r.pos]
+	}
+	return 0
+}
+
+func (r *jsonReader) expect(c byte) bool {
+	if r.peek() != c {
+		r.fail(ErrJSONSyntax)
+		return false
+	}
+	r.pos++
+	return true
+}
+
+// finish ensures that only whitespace follows the top-level value.
+func (r *jsonReader) finish() error {
+	if r.ws(); r.err == nil && r.pos < len(r.data) {
+		r.fail(ErrJSONTrailingData)
+	}
+	return r.err
+}
+
+// null consumes a null literal, returns false if the next value is not null.
+func (r *jsonReader) null() bool {
+	if r.peek() != 'n' {
+		return false
+	}
+	return r.literal("null")
+}
+
+func (r *jsonReader) literal(lit string) bool {
+	if len(r.data)-r.pos < len(lit) || string(r.data[r.pos:r.pos+len(lit)]) != lit {
+		r.fail(ErrJSONSyntax)
+		return false
+	}
+	r.pos += len(lit)
+	return true
+}
+
+// firstKey starts an object and reads its first key, returns false if the object is empty or null.
+func (r *jsonReader) firstKey() ([]byte, bool) {
+	if r.null() {
+		return nil, false
+	}
+	if r.peek() != '{' {
+		r.skipUnexpected()
+		return nil, false
+	}
+	r.pos++
+	if r.peek() == '}' {
+		r.pos++
+		return nil, false
+	}
+	r.push(jsonFrame{index: -1})
+	return r.key()
+}
+
+// nextKey reads the next key of an object, returns false at the end of the object.
+func (r *jsonReader) nextKey() ([]byte, bool) {
+	switch r.peek() {
+	case ',':
+		r.pos++
+		return r.key()
+	case '}':
+		r.pos++
+		r.pop()
+	default:
+		r.fail(ErrJSONSyntax)
+	}
+	return nil, false
+}
+
+func (r *jsonReader) key() ([]byte, bool) {
+	if r.peek() != '"' {
+		r.fail(ErrJSONSyntax)
+		return nil, false
+	}
+	key := r.stringBytes()
+	if !r.expect(':') {
+		return nil, false
+	}
+	if f := r.top(); f != nil {
+		f.key = string(key)
+		if r.strict {
+			for _, k := range f.keys {
+				if k == f.key {
+					r.violation(ErrStrictDuplicateKey)
+					break
+				}
+			}
+			f.keys = append(f.keys, f.key)
+		}
+	}
+	return key, r.err == nil
+}
+
+// firstElem starts an array, returns false if the array is empty.
+func (r *jsonReader) firstElem() bool {
+	if c := r.peek(); c != '[' {
+		if r.lenient&CoerceSingleArrays != 0 && c != 'n' && c != 0 {
+			r.coerced(CoerceSingleArrays)
+			r.push(jsonFrame{single: true})
+			return true
+		}
+		r.skipUnexpected()
+		return false
+	}
+	r.pos++
+	if r.peek() == ']' {
+		r.pos++
+		return false
+	}
+	r.push(jsonFrame{})
+	return r.err == nil
+}
+
+// nextElem moves to the next array element, returns false at the end of the array.
+func (r *jsonReader) nextElem() bool {
+	if f := r.top(); f != nil && f.single {
+		// the single value is not followed by a closing bracket
+		r.pop()
+		return false
+	}
+
+	switch r.peek() {
+	case ',':
+		r.pos++
+		if f := r.top(); f != nil {
+			f.index++
+		}
+		return true
+	case ']':
+		r.pos++
+		r.pop()
+	default:
+		r.fail(ErrJSONSyntax)
+	}
+	return false
+}
+
+// skipUnexpected fails with a type error, or a syntax error if the value is invalid.
+func (r *jsonReader) skipUnexpected() {
+	if r.skip(); r.err == nil {
+		r.mismatch(ErrJSONUnexpected)
+	}
+}
+
+// string reads a string value, null leaves the value unchanged.
+func (r *jsonReader) string(v *string) {
+	switch c := r.peek(); {
+	case c == '"':
+		*v = r.internString(r.stringBytes())
+	case c == 'n':
+		r.null()
+	case (c == '-' || c >= '0' && c <= '9') && r.lenient&CoerceNumberStrings != 0:
+		start := r.pos
+		r.scanNumber()
+		*v = string(r.data[start:r.pos])
+		r.coerced(CoerceNumberStrings)
+	default:
+		r.skipUnexpected()
+	}
+}
+
+// maxInterned is the maximum number of interned strings, the table is cleared when full.
+const maxInterned = 4096
+
+// internString converts b to a string, looking up short strings in the intern table to
+// avoid allocations of values which recur between requests.
+func (r *jsonReader) internString(b []byte) string {
+	if r.intern == nil || len(b) > 64 {
+		return string(b)
+	}
+	if s, ok := r.intern[string(b)]; ok {
+		return s
+	}
+	if len(r.intern) >= maxInterned {
+		r.intern = make(map[string]string, maxInterned)
+	}
+	s := string(b)
+	r.intern[s] = s
+	return s
+}
+
+// stringBytes reads a string literal. The result is only valid until the next read.
+func (r *jsonReader) stringBytes() []byte {
+	r.pos++ // opening quote
+	start := r.pos
+	for r.pos < len(r.data) {
+		c := r.data[r.pos]
+		switch {
+		case c == '"':
+			r.pos++
+			return r.data[start : r.pos-1]
+		case c == '\\' || c >= utf8.RuneSelf:
+			return r.unquote(start)
+		case c < 0x20:
+			r.fail(ErrJSONSyntax)
+			return nil
+		}
+		r.pos++
+	}
+	r.fail(ErrJSONSyntax)
+	return nil
+}
+
+// unquote decodes a string literal with escapes or non-ASCII characters into the scratch buffer.
+func (r *jsonReader) unquote(start int) []byte {
+	b := append(r.scratch[:0], r.data[start:r.pos]...)
+	for r.pos < len(r.data) {
+		c := r.data[r.pos]
+		switch {
+		case c == '"':
+			r.pos++
+			r.scratch = b
+			return b
+		case c < 0x20:
+			r.fail(ErrJSONSyntax)
+			return nil
+		case c == '\\':
+			if r.pos+1 >= len(r.data) {
+				r.fail(ErrJSONSyntax)
+				return nil
+			}
+			r.pos += 2
+			switch e := r.data[r.pos-1]; e {
+			case '"', '\\', '/':
+				b = append(b, e)
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'u':
+				rr := r.hex4()
+				if utf16.IsSurrogate(rr) {
+					if r.pos+1 < len(r.data) && r.data[r.pos] == '\\' && r.data[r.pos+1] == 'u' {
+						save := r.pos
+						r.pos += 2
+						if dec := utf16.DecodeRune(rr, r.hex4()); dec != utf8.RuneError {
+							rr = dec
+						} else {
+							r.pos = save
+							rr = utf8.RuneError
+						}
+					} else {
+						rr = utf8.RuneError
+					}
+				}
+				if r.err != nil {
+					return nil
+				}
+				b = utf8.AppendRune(b, rr)
+			default:
+				r.fail(ErrJSONSyntax)
+				return nil
+			}
+		case c < utf8.RuneSelf:
+			b = append(b, c)
+			r.pos++
+		default:
+			rr, size := utf8.DecodeRune(r.data[r.pos:])
+			r.pos += size
+			b = utf8.AppendRune(b, rr)
+		}
+	}
+	r.fail(ErrJSONSyntax)
+	return nil
+}
+
+func (r *jsonReader) hex4() rune {
+	if r.pos+4 > len(r.data) {
+		r.fail(ErrJSONSyntax)
+		return 0
+	}
+	var v rune
+	for _, c := range r.data[r.pos : r.pos+4] {
+		switch {
+		case c >= '0' && c <= '9':
+			c -= '0'
+		case c >= 'a' && c <= 'f':
+			c = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			r.fail(ErrJSONSyntax)
+			return 0
+		}
+		v = v<<4 | rune(c)
+	}
+	r.pos += 4
+	return v
+}
+
+// number reads a number literal, returns nil if the value is null.
+func (r *jsonReader) number() []byte {
+	switch c := r.peek(); {
+	case c == '-' || c >= '0' && c <= '9':
+		start := r.pos
+		r.scanNumber()
+		return r.data[start:r.pos]
+	case c == 'n':
+		r.null()
+	case c == '"' && r.lenient&CoerceQuotedNumbers != 0:
+		return r.quotedNumber()
+	case (c == 't' || c == 'f') && r.lenient&CoerceBoolNumbers != 0:
+		r.coerced(CoerceBoolNumbers)
+		if c == 't' && r.literal("true") {
+			return []byte("1")
+		} else if c == 'f' && r.literal("false") {
+			return []byte("0")
+		}
+	default:
+		r.skipUnexpected()
+	}
+	return nil
+}
+
+// quotedNumber reads a number sent as a string, an empty string is treated like null.
+func (r *jsonReader) quotedNumber() []byte {
+	s := r.stringBytes()
+	if r.err != nil {
+		return nil
+	}
+
+	inner := jsonReader{data: s}
+	if inner.peek() == 0 {
+		r.coerced(CoerceQuotedNumbers)
+		return nil
+	}
+	start := inner.pos
+	inner.scanNumber()
+	if inner.finish(); inner.err != nil {
+		r.mismatch(ErrJSONUnexpected)
+		return nil
+	}
+	r.coerced(CoerceQuotedNumbers)
+	return s[start:inner.pos]
+}
+
+func (r *jsonReader) scanNumber() {
+	d := r.data
+	i := r.pos
+	if i < len(d) && d[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(d) && d[i] == '0':
+		i++
+	case i < len(d) && d[i] >= '1' && d[i] <= '9':
+		for i < len(d) && d[i] >= '0' && d[i] <= '9' {
+			i++
+		}
+	default:
+		r.fail(ErrJSONSyntax)
+		return
+	}
+	if i < len(d) && d[i] == '.' {
+		i++
+		if i >= len(d) || d[i] < '0' || d[i] > '9' {
+			r.fail(ErrJSONSyntax)
+			return
+		}
+		for i < len(d) && d[i] >= '0' && d[i] <= '9' {
+			i++
+		}
+	}
+	if i < len(d) && (d[i] == 'e' || d[i] == 'E') {
+		i++
+		if i < len(d) && (d[i] == '+' || d[i] == '-') {
+			i++
+		}
+		if i >= len(d) || d[i] < '0' || d[i] > '9' {
+			r.fail(ErrJSONSyntax)
+			return
+		}
+		for i < len(d) && d[i] >= '0' && d[i] <= '9' {
+			i++
+		}
+	}
+	r.pos = i
+}
+
+// int reads an integer of the given bit size (0 for int), returns false if the value is null.
+func (r *jsonReader) int(bits int) (int64, bool) {
+	lit := r.number()
+	if lit == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(string(lit), 10, bits)
+	if err != nil {
+		r.mismatch(r.numError(err))
+		return 0, false
+	}
+	return v, true
+}
+
+// uint reads an unsigned integer of the given bit size (0 for uint), returns false if the value is null.
+func (r *jsonReader) uint(bits int) (uint64, bool) {
+	lit := r.number()
+	if lit == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(lit), 10, bits)
+	if err != nil {
+		r.mismatch(r.numError(err))
+		return 0, false
+	}
+	return v, true
+}
+
+// float reads a float of the given bit size, returns false if the value is null.
+func (r *jsonReader) float(bits int) (float64, bool) {
+	lit := r.number()
+	if lit == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(string(lit), bits)
+	if err != nil {
+		r.mismatch(r.numError(err))
+		return 0, false
+	}
+	return v, true
+}
+
+func (r *jsonReader) numError(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrJSONOutOfRange
+	}
+	return ErrJSONUnexpected
+}
+
+// raw returns the next value as is, including null.
+func (r *jsonReader) raw() []byte {
+	r.ws()
+	from := r.pos
+	r.skip()
+	if r.err != nil {
+		return nil
+	}
+	return r.data[from:r.pos]
+}
+
+// skip validates and skips the next value.
+func (r *jsonReader) skip() {
+	switch c := r.peek(); {
+	case c == '{':
+		r.pos++
+		if r.peek() == '}' {
+			r.pos++
+			return
+		}
+		for r.peek() == '"' {
+			r.stringBytes()
+			if !r.expect(':') {
+				return
+			}
+			r.skip()
+			if r.peek() != ',' {
+				r.expect('}')
+				return
+			}
+			r.pos++
+		}
+		r.fail(ErrJSONSyntax)
+	case c == '[':
+		r.pos++
+		if r.peek() == ']' {
+			r.pos++
+			return
+		}
+		for r.err == nil {
+			r.skip()
+			if r.peek() != ',' {
+				r.expect(']')
+				return
+			}
+			r.pos++
+		}
+	case c == '"':
+		r.stringBytes()
+	case c == '-' || c >= '0' && c <= '9':
+		r.scanNumber()
+	case c == 't':
+		r.literal("true")
+	case c == 'f':
+		r.literal("false")
+	case c == 'n':
+		r.literal("null")
+	default:
+		r.fail(ErrJSONSyntax)
+	}
+}
+
+// foldKey returns the member of keys which matches key case-insensitively, as
+// encoding/json does when no exact match exists. Strict decoding reports such keys
+// as unknown, but still decodes them.
+func (r *jsonReader) foldKey(key []byte, keys []string) (string, bool) {
+	for _, k := range keys {
+		if strings.EqualFold(string(key), k) {
+			if r.strict {
+				r.violation(ErrStrictUnknownKey)
+			}
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// unknownKey skips the value of an unknown key.
+func (r *jsonReader) unknownKey() {
+	if r.strict {
+		r.violation(ErrStrictUnknownKey)
+	}
+	r.skip()
+}
+
+// stringOrNumber decodes a StringOrNumber like its UnmarshalJSON method.
+func (r *jsonReader) stringOrNumber(v *StringOrNumber) {
+	if r.peek() == '"' {
+		var s string
+		r.string(&s)
+		*v = StringOrNumber(s)
+		return
+	}
+	n, _ := r.int(0)
+	*v = StringOrNumber(strconv.FormatInt(n, 10))
+}
+
+// quotedInt decodes a number which may be quoted, like the UnmarshalJSON methods of
+// NumberOrString and ContentContext. Null results in zero.
+func (r *jsonReader) quotedInt() int {
+	if r.peek() != '"' {
+		n, _ := r.int(0)
+		return int(n)
+	}
+
+	s := r.stringBytes()
+	if r.err != nil {
+		return 0
+	}
+	if len(s) == 0 {
+		r.fail(ErrJSONSyntax)
+		return 0
+	}
+
+	inner := jsonReader{data: s}
+	n, _ := inner.int(0)
+	if inner.finish(); inner.err != nil {
+		r.mismatch(inner.err)
+	}
+	return int(n)
+}
@@ -0,0 +1,176 @@
+package openrtb
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+//go:generate go run jsongen.go
+
+// ErrJSONUnsupportedFloat is returned when encoding NaN or infinite values
+var ErrJSONUnsupportedFloat = errors.New("openrtb: unsupported float value")
+
+// jsonWriter is the encoding runtime of the generated JSON codec. It mirrors the
+// output of encoding/json, including HTML escaping. The first error is sticky.
+type jsonWriter struct {
+	b   []byte
+	err error
+}
+
+// field starts an object member, the key must include quotes and the colon.
+func (w *jsonWriter) field(key string) {
+	if c := w.b[len(w.b)-1]; c != '{' {
+		w.b = append(w.b, ',')
+	}
+	w.b = append(w.b, key...)
+}
+
+// elem starts an array element
+func (w *jsonWriter) elem() {
+	if c := w.b[len(w.b)-1]; c != '[' {
+		w.b = append(w.b, ',')
+	}
+}
+
+func (w *jsonWriter) null() {
+	w.b = append(w.b, "null"...)
+}
+
+func (w *jsonWriter) int(v int64) {
+	w.b = strconv.AppendInt(w.b, v, 10)
+}
+
+func (w *jsonWriter) uint(v uint64) {
+	w.b = strconv.AppendUint(w.b, v, 10)
+}
+
+func (w *jsonWriter) float(f float64, bits int) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		if w.err == nil {
+			w.err = ErrJSONUnsupportedFloat
+		}
+		w.null()
+		return
+	}
+
+	// same format as encoding/json, i.e. ES6 number to string conversion
+	abs, fmt := math.Abs(f), byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			fmt = 'e'
+		}
+	}
+	w.b = strconv.AppendFloat(w.b, f, fmt, -1, bits)
+	if fmt == 'e' {
+		// clean up e-09 to e-9
+		if n := len(w.b); n >= 4 && w.b[n-4] == 'e' && w.b[n-3] == '-' && w.b[n-2] == '0' {
+			w.b[n-2] = w.b[n-1]
+			w.b = w.b[:n-1]
+		}
+	}
+}
+
+func (w *jsonWriter) string(s string) {
+	const hex = "0123456789abcdef"
+
+	w.b = append(w.b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			w.b = append(w.b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				w.b = append(w.b, '\\', c)
+			case '\b':
+				w.b = append(w.b, '\\', 'b')
+			case '\f':
+				w.b = append(w.b, '\\', 'f')
+			case '\n':
+				w.b = append(w.b, '\\', 'n')
+			case '\r':
+				w.b = append(w.b, '\\', 'r')
+			case '\t':
+				w.b = append(w.b, '\\', 't')
+			default:
+				w.b = append(w.b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			w.b = append(w.b, s[start:i]...)
+			w.b = append(w.b, "\xef\xbf\xbd"...) // utf8.RuneError
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			w.b = append(w.b, s[start:i]...)
+			w.b = append(w.b, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	w.b = append(w.b, s[start:]...)
+	w.b = append(w.b, '"')
+}
+
+// raw appends a json.RawMessage, compacted and HTML-escaped like encoding/json does.
+func (w *jsonWriter) raw(data []byte) {
+	if data == nil {
+		w.null()
+		return
+	}
+
+	r := jsonReader{data: data}
+	r.skip()
+	if r.finish(); r.err != nil {
+		if w.err == nil {
+			w.err = r.err
+		}
+		w.null()
+		return
+	}
+
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if !inString {
+			switch c {
+			case ' ', '\t', '\n', '\r':
+				continue
+			case '"':
+				inString = true
+			}
+			w.b = append(w.b, c)
+			continue
+		}
+
+		switch {
+		case c == '\\':
+			w.b = append(w.b, c, data[i+1])
+			i++
+		case c == '"':
+			inString = false
+			w.b = append(w.b, c)
+		case c == '<' || c == '>' || c == '&':
+			w.b = append(w.b, '\\', 'u', '0', '0', "0123456789abcdef"[c>>4], "0123456789abcdef"[c&0xF])
+		case c == 0xE2 && i+2 < len(data) && data[i+1] == 0x80 && data[i+2]&^1 == 0xA8:
+			w.b = append(w.b, '\\', 'u', '2', '0', '2', "89"[data[i+2]&1])
+			i += 2
+		default:
+			w.b = append(w.b, c)
+		}
+	}
+}
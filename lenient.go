@@ -0,0 +1,53 @@
+package openrtb
+
+import (
+	"strings"
+)
+
+// Coercion is a set of type deviations accepted by lenient decoding.
+type Coercion uint
+
+// Coercions of lenient decoding
+const (
+	// CoerceQuotedNumbers accepts numbers sent as strings, e.g. "w":"300". Empty strings are treated like null.
+	CoerceQuotedNumbers Coercion = 1 << iota
+	// CoerceBoolNumbers accepts booleans for numbers, e.g. "secure":true, as 1 and 0.
+	CoerceBoolNumbers
+	// CoerceSingleArrays accepts a single value instead of an array, e.g. "imp":{...}.
+	CoerceSingleArrays
+	// CoerceNumberStrings accepts numbers for strings, e.g. "cat":[1,2], as their literal text.
+	CoerceNumberStrings
+
+	// CoerceAll accepts all deviations.
+	CoerceAll = CoerceQuotedNumbers | CoerceBoolNumbers | CoerceSingleArrays | CoerceNumberStrings
+)
+
+var coercionNames = []string{"quoted number", "boolean number", "single array", "number string"}
+
+// String returns the names of the coercions in the set.
+func (c Coercion) String() string {
+	var names []string
+	for i, name := range coercionNames {
+		if c&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// AppliedCoercion reports a coercion applied to a value during lenient decoding.
+type AppliedCoercion struct {
+	Path     string   // JSON path of the value, e.g. "imp[0].banner.w"
+	Coercion Coercion // the applied coercion
+}
+
+// DecodeLenient decodes an object from JSON data like DecodeJSON, but additionally accepts the given
+// deviations from the specified types, which some exchanges send. It returns all applied coercions.
+func DecodeLenient(data []byte, v Object, coercions Coercion) ([]AppliedCoercion, error) {
+	r := jsonReader{data: data, track: true, lenient: coercions}
+	v.decodeJSON(&r)
+	if err := r.finish(); err != nil {
+		return nil, err
+	}
+	return r.coercions, nil
+}
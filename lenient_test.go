@@ -0,0 +1,131 @@
+package openrtb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeLenient(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		data      string
+		coercions Coercion
+		applied   []AppliedCoercion
+		exp       *BidRequest
+	}{
+		{
+			name:      "quoted numbers",
+			data:      `{"id":"1","tmax":"120","imp":[{"id":"1","bidfloor":"0.5","banner":{"w":"300","h":""}}]}`,
+			coercions: CoerceQuotedNumbers,
+			applied: []AppliedCoercion{
+				{"tmax", CoerceQuotedNumbers},
+				{"imp[0].bidfloor", CoerceQuotedNumbers},
+				{"imp[0].banner.w", CoerceQuotedNumbers},
+				{"imp[0].banner.h", CoerceQuotedNumbers},
+			},
+			exp: &BidRequest{ID: "1", TMax: 120, Impressions: []Impression{{ID: "1", BidFloor: 0.5, Banner: &Banner{Width: 300}}}},
+		},
+		{
+			name:      "boolean numbers",
+			data:      `{"id":"1","test":true,"imp":[{"id":"1","secure":false,"instl":true}]}`,
+			coercions: CoerceBoolNumbers,
+			applied: []AppliedCoercion{
+				{"test", CoerceBoolNumbers},
+				{"imp[0].secure", CoerceBoolNumbers},
+				{"imp[0].instl", CoerceBoolNumbers},
+			},
+			exp: &BidRequest{ID: "1", Test: 1, Impressions: []Impression{{ID: "1", Interstitial: 1}}},
+		},
+		{
+			name:      "single arrays",
+			data:      `{"id":"1","imp":{"id":"1","banner":{"format":{"w":300,"h":250}}},"bcat":"IAB1","cur":null}`,
+			coercions: CoerceSingleArrays,
+			applied: []AppliedCoercion{
+				{"imp", CoerceSingleArrays},
+				{"imp.banner.format", CoerceSingleArrays}, // paths locate values in the data, which has no array
+				{"bcat", CoerceSingleArrays},
+			},
+			exp: &BidRequest{ID: "1", Impressions: []Impression{{ID: "1", Banner: &Banner{Formats: []Format{{Width: 300, Height: 250}}}}}, BlockedCategories: []ContentCategory{"IAB1"}},
+		},
+		{
+			name:      "number strings",
+			data:      `{"id":1,"imp":[{"id":2,"tagid":-1.5e3}],"bcat":[1,"IAB2"]}`,
+			coercions: CoerceNumberStrings,
+			applied: []AppliedCoercion{
+				{"id", CoerceNumberStrings},
+				{"imp[0].id", CoerceNumberStrings},
+				{"imp[0].tagid", CoerceNumberStrings},
+				{"bcat[0]", CoerceNumberStrings},
+			},
+			exp: &BidRequest{ID: "1", Impressions: []Impression{{ID: "2", TagID: "-1.5e3"}}, BlockedCategories: []ContentCategory{"1", "IAB2"}},
+		},
+		{
+			name:      "all",
+			data:      `{"id":1,"tmax":"120","test":true,"bcat":2}`,
+			coercions: CoerceAll,
+			applied: []AppliedCoercion{
+				{"id", CoerceNumberStrings},
+				{"tmax", CoerceQuotedNumbers},
+				{"test", CoerceBoolNumbers},
+				{"bcat", CoerceSingleArrays},
+				{"bcat", CoerceNumberStrings},
+			},
+			exp: &BidRequest{ID: "1", TMax: 120, Test: 1, BlockedCategories: []ContentCategory{"2"}},
+		},
+		{
+			name:      "conforming",
+			data:      `{"id":"1","tmax":120,"imp":[{"id":"1"}]}`,
+			coercions: CoerceAll,
+			exp:       &BidRequest{ID: "1", TMax: 120, Impressions: []Impression{{ID: "1"}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := new(BidRequest)
+			applied, err := DecodeLenient([]byte(tc.data), got, tc.coercions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(applied, tc.applied) {
+				t.Errorf("expected coercions %v, got %v", tc.applied, applied)
+			}
+			if !reflect.DeepEqual(got, tc.exp) {
+				t.Errorf("expected %+v, got %+v", tc.exp, got)
+			}
+
+			// without the coercions, the deviations are errors
+			if tc.applied != nil {
+				if _, err := DecodeLenient([]byte(tc.data), new(BidRequest), 0); err == nil {
+					t.Error("expected error without coercions")
+				}
+				if err := new(BidRequest).DecodeJSON([]byte(tc.data)); err == nil {
+					t.Error("expected error from DecodeJSON")
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeLenient_invalid(t *testing.T) {
+	for _, data := range []string{
+		`{"tmax":"12x"}`,
+		`{"tmax":"1 2"}`,
+		`{"tmax":"true"}`,
+		`{"test":"yes"}`,
+		`{"id":true}`,
+		`{"imp":"x"}`,
+		`{"tmax":"120"`,
+	} {
+		if _, err := DecodeLenient([]byte(data), new(BidRequest), CoerceAll); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+}
+
+func TestCoercion_String(t *testing.T) {
+	if s := (CoerceQuotedNumbers | CoerceSingleArrays).String(); s != "quoted number|single array" {
+		t.Fatalf("unexpected name %q", s)
+	}
+	if s := CoerceAll.String(); s != "quoted number|boolean number|single array|number string" {
+		t.Fatalf("unexpected name %q", s)
+	}
+}
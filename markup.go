@@ -0,0 +1,165 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Markup describes the ad markup of a bid, as recognized by ClassifyMarkup.
+type Markup struct {
+	MediaType MediaType      // Media type of the markup, 0 if not recognized
+	APIs      []APIFramework // API frameworks used by the markup, those needed for rendering first
+	Insecure  []string       // Non-secure http:// resources, only reported for secure impressions
+}
+
+// Uses reports whether the markup uses the API framework.
+func (m *Markup) Uses(api APIFramework) bool {
+	for _, a := range m.APIs {
+		if a == api {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyMarkup inspects ad markup for the impression, which may be nil. VAST and DAAST
+// documents are recognized as video or audio, JSON with native assets as native, HTML and
+// JavaScript as banner. MRAID, VPAID, SIMID and OMID usage is detected from the markup, and
+// http:// resources are reported if the impression requires secure creatives.
+func ClassifyMarkup(adm string, imp *Impression) *Markup {
+	lower := asciiLower(adm)
+	m := &Markup{MediaType: markupMediaType(lower, imp)}
+	if strings.Contains(lower, "mraid") {
+		m.APIs = append(m.APIs, mraidVersion(lower))
+	}
+	if strings.Contains(lower, `apiframework="vpaid"`) {
+		m.APIs = append(m.APIs, APIFrameworkVPAID2)
+	}
+	if strings.Contains(lower, `apiframework="simid"`) {
+		m.APIs = append(m.APIs, APIFrameworkSIMID1)
+	}
+	if usesOMID(lower) {
+		m.APIs = append(m.APIs, APIFrameworkOMID1)
+	}
+
+	if imp != nil && imp.Secure == 1 {
+		m.Insecure = insecureURLs(adm, lower)
+	}
+	return m
+}
+
+// Classify classifies the markup of the bid for the impression, which may be nil, and fills
+// MarkupType and API if they are not set and can be determined.
+func (bid *Bid) Classify(imp *Impression) *Markup {
+	m := ClassifyMarkup(bid.AdMarkup, imp)
+	if bid.MarkupType == 0 {
+		if t := m.MediaType; t != 0 {
+			bid.MarkupType = t
+		} else if t := bid.MediaType(imp); t != 0 {
+			bid.MarkupType = t
+		}
+	}
+	if bid.API == APIFrameworkUnknown && len(m.APIs) != 0 {
+		bid.API = m.APIs[0]
+	}
+	return m
+}
+
+// markupMediaType recognizes the media type of the lowered markup.
+func markupMediaType(lower string, imp *Impression) MediaType {
+	adm := strings.TrimSpace(lower)
+	switch {
+	case adm == "":
+		return 0
+	case adm[0] == '{':
+		var native struct {
+			Native json.RawMessage `json:"native"`
+			Assets json.RawMessage `json:"assets"`
+		}
+		if json.Unmarshal([]byte(adm), &native) == nil && (native.Native != nil || native.Assets != nil) {
+			return MediaTypeNative
+		}
+	case strings.Contains(adm, "<daast"):
+		return MediaTypeAudio
+	case strings.Contains(adm, "<vast"):
+		audio := strings.Contains(adm, `type="audio/`) && !strings.Contains(adm, `type="video/`)
+		if imp != nil && imp.Audio != nil && (imp.Video == nil || audio) {
+			return MediaTypeAudio
+		}
+		return MediaTypeVideo
+	case adm[0] == '<', strings.Contains(adm, "document.write"):
+		return MediaTypeBanner
+	}
+	return 0
+}
+
+// usesOMID reports whether the lowered markup carries an OM SDK signal: VAST AdVerifications,
+// an omid- prefixed resource or element, the omweb script or the OM SDK session client.
+func usesOMID(lower string) bool {
+	for _, s := range []string{"<adverifications", "omid-", "omweb", "omidsessionclient", "omsdk"} {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// mraidVersion returns the MRAID version required by the functions used in the markup.
+func mraidVersion(lower string) APIFramework {
+	for _, fn := range []string{"mraid.unload", "exposurechange", "audiovolumechange", "getcurrentapporientation"} {
+		if strings.Contains(lower, fn) {
+			return APIFrameworkMRAID3
+		}
+	}
+	for _, fn := range []string{"mraid.resize", "setresizeproperties", "storepicture", "createcalendarevent", "playvideo", "sizechange"} {
+		if strings.Contains(lower, fn) {
+			return APIFrameworkMRAID2
+		}
+	}
+	return APIFrameworkMRAID1
+}
+
+// insecureURLs returns the http:// URLs in the markup, except XML namespaces and schemas.
+func insecureURLs(adm, lower string) []string {
+	var urls []string
+	for i := 0; ; {
+		j := strings.Index(lower[i:], "http:")
+		if j < 0 {
+			return urls
+		}
+		j += i
+		end := j + len("http:")
+		for end < len(adm) && !strings.ContainsRune(" \t\r\n\"'<>()[]", rune(adm[end])) {
+			end++
+		}
+		i = end
+
+		u := strings.ReplaceAll(adm[j:end], `\/`, "/")
+		if !strings.HasPrefix(strings.ToLower(u), "http://") || strings.HasPrefix(u, "http://www.w3.org/") || isNamespaceAttr(adm[:j]) || containsString(urls, u) {
+			continue
+		}
+		urls = append(urls, u)
+	}
+}
+
+// isNamespaceAttr reports whether the markup before a URL ends with an xmlns or schemaLocation attribute.
+func isNamespaceAttr(before string) bool {
+	before = strings.TrimRight(before, `"'`)
+	if !strings.HasSuffix(before, "=") {
+		return false
+	}
+	before = strings.TrimSpace(before[:len(before)-1])
+	name := asciiLower(before[strings.LastIndexAny(before, " \t\r\n")+1:])
+	return strings.HasPrefix(name, "xmlns") || strings.HasSuffix(name, "schemalocation")
+}
+
+// asciiLower lowers ASCII letters only, so that offsets in the result match those in s.
+func asciiLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
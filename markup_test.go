@@ -0,0 +1,68 @@
+package openrtb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyMarkup(t *testing.T) {
+	video := &Impression{Video: &Video{}}
+	audio := &Impression{Audio: &Audio{}}
+	both := &Impression{Video: &Video{}, Audio: &Audio{}}
+	secure := &Impression{Banner: &Banner{}, Secure: 1}
+
+	for _, tc := range []struct {
+		name     string
+		adm      string
+		imp      *Impression
+		exp      MediaType
+		apis     []APIFramework
+		insecure []string
+	}{
+		{name: "empty", adm: "  "},
+		{name: "vast", adm: `<?xml version="1.0"?><VAST version="4.0"></VAST>`, exp: MediaTypeVideo},
+		{name: "vast lower case", adm: `<vast version="3.0"></vast>`, exp: MediaTypeVideo},
+		{name: "vast on audio", adm: `<VAST version="4.0"></VAST>`, imp: audio, exp: MediaTypeAudio},
+		{name: "vast audio files", adm: `<VAST><MediaFile type="audio/mp4"/></VAST>`, imp: both, exp: MediaTypeAudio},
+		{name: "vast video files", adm: `<VAST><MediaFile type="video/mp4"/></VAST>`, imp: both, exp: MediaTypeVideo},
+		{name: "daast", adm: `<DAAST version="1.0"></DAAST>`, imp: video, exp: MediaTypeAudio},
+		{name: "daast lower case", adm: `<daast version="1.0"></daast>`, exp: MediaTypeAudio},
+		{name: "native", adm: `{"native":{"assets":[{"id":1}]}}`, exp: MediaTypeNative},
+		{name: "native assets", adm: `{"ver":"1.2","assets":[]}`, exp: MediaTypeNative},
+		{name: "other json", adm: `{"id":1}`},
+		{name: "html", adm: `<div><img src="https://a.com/1.png"></div>`, exp: MediaTypeBanner},
+		{name: "javascript", adm: `document.write("<img>")`, exp: MediaTypeBanner},
+		{name: "mraid 1", adm: `<script src="mraid.js"></script><script>mraid.open("x")</script>`, exp: MediaTypeBanner, apis: []APIFramework{APIFrameworkMRAID1}},
+		{name: "mraid 2", adm: `<script>mraid.resize()</script>`, exp: MediaTypeBanner, apis: []APIFramework{APIFrameworkMRAID2}},
+		{name: "mraid 3", adm: `<script>mraid.addEventListener("exposureChange", f)</script>`, exp: MediaTypeBanner, apis: []APIFramework{APIFrameworkMRAID3}},
+		{name: "vpaid", adm: `<VAST><MediaFile apiFramework="VPAID"/></VAST>`, exp: MediaTypeVideo, apis: []APIFramework{APIFrameworkVPAID2}},
+		{name: "simid", adm: `<VAST><InteractiveCreativeFile apiFramework="SIMID"/></VAST>`, exp: MediaTypeVideo, apis: []APIFramework{APIFrameworkSIMID1}},
+		{name: "omid verifications", adm: `<VAST><Ad><InLine><AdVerifications></AdVerifications></InLine></Ad></VAST>`, exp: MediaTypeVideo, apis: []APIFramework{APIFrameworkOMID1}},
+		{name: "omid script", adm: `<script src="https://a.com/omweb-v1.js"></script>`, exp: MediaTypeBanner, apis: []APIFramework{APIFrameworkOMID1}},
+		{name: "omid session client", adm: `<script>new OmidSessionClient.AdSession(ctx)</script>`, exp: MediaTypeBanner, apis: []APIFramework{APIFrameworkOMID1}},
+		{name: "omid resource", adm: `<script src="https://a.com/omid-validation-verification-script-v1.js"></script>`, exp: MediaTypeBanner, apis: []APIFramework{APIFrameworkOMID1}},
+		{name: "random id", adm: `<div id="x" data-randomId="1"></div>`, exp: MediaTypeBanner},
+		{name: "bloom index", adm: `<script>var bloomidx = 1;</script>`, exp: MediaTypeBanner},
+		{
+			name:     "insecure",
+			adm:      `<div xmlns="http://www.w3.org/1999/xhtml"><img src="http://a.com/1.png"><img src='http://a.com/1.png'></div>`,
+			imp:      secure,
+			exp:      MediaTypeBanner,
+			insecure: []string{"http://a.com/1.png"},
+		},
+		{name: "insecure not reported", adm: `<img src="http://a.com/1.png">`, exp: MediaTypeBanner},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := ClassifyMarkup(tc.adm, tc.imp)
+			if m.MediaType != tc.exp {
+				t.Errorf("expected media type %v, got %v", tc.exp, m.MediaType)
+			}
+			if !reflect.DeepEqual(m.APIs, tc.apis) {
+				t.Errorf("expected APIs %v, got %v", tc.apis, m.APIs)
+			}
+			if !reflect.DeepEqual(m.Insecure, tc.insecure) {
+				t.Errorf("expected insecure URLs %v, got %v", tc.insecure, m.Insecure)
+			}
+		})
+	}
+}
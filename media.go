@@ -0,0 +1,146 @@
+package openrtb
+
+import "errors"
+
+// Media type errors
+var (
+	ErrBidMediaTypeUnknown    = errors.New("openrtb: bid media type cannot be determined")
+	ErrBidMediaTypeNotOffered = errors.New("openrtb: bid media type is not offered by the impression")
+)
+
+// MediaType is the type of an ad, its values are those of the bid's markup type (mtype).
+type MediaType int
+
+// Media types
+const (
+	MediaTypeBanner MediaType = 1
+	MediaTypeVideo  MediaType = 2
+	MediaTypeAudio  MediaType = 3
+	MediaTypeNative MediaType = 4
+)
+
+func (t MediaType) String() string {
+	switch t {
+	case MediaTypeBanner:
+		return "banner"
+	case MediaTypeVideo:
+		return "video"
+	case MediaTypeAudio:
+		return "audio"
+	case MediaTypeNative:
+		return "native"
+	}
+	return "unknown"
+}
+
+// MediaTypes returns the media types offered by the impression, in the order banner, video,
+// audio, native. Multi-format impressions offer more than one.
+func (imp *Impression) MediaTypes() []MediaType {
+	var types []MediaType
+	if imp.Banner != nil {
+		types = append(types, MediaTypeBanner)
+	}
+	if imp.Video != nil {
+		types = append(types, MediaTypeVideo)
+	}
+	if imp.Audio != nil {
+		types = append(types, MediaTypeAudio)
+	}
+	if imp.Native != nil {
+		types = append(types, MediaTypeNative)
+	}
+	return types
+}
+
+// Offers reports whether the impression offers the media type.
+func (imp *Impression) Offers(t MediaType) bool {
+	switch t {
+	case MediaTypeBanner:
+		return imp.Banner != nil
+	case MediaTypeVideo:
+		return imp.Video != nil
+	case MediaTypeAudio:
+		return imp.Audio != nil
+	case MediaTypeNative:
+		return imp.Native != nil
+	}
+	return false
+}
+
+// only removes all media objects from the impression except the one of the given type.
+func (imp *Impression) only(t MediaType) {
+	if t != MediaTypeBanner {
+		imp.Banner = nil
+	}
+	if t != MediaTypeVideo {
+		imp.Video = nil
+	}
+	if t != MediaTypeAudio {
+		imp.Audio = nil
+	}
+	if t != MediaTypeNative {
+		imp.Native = nil
+	}
+}
+
+// MediaType determines the media type of a bid for the impression, which may be nil. It is
+// taken from the markup type if set, from the markup if it is recognized, from the size of the
+// bid if it matches a single media type, or, as a last resort, from the impression if it offers
+// a single media type. It returns 0 if the media type cannot be determined.
+func (bid *Bid) MediaType(imp *Impression) MediaType {
+	if t := bid.MarkupType; t >= MediaTypeBanner && t <= MediaTypeNative {
+		return t
+	}
+	if t := markupMediaType(asciiLower(bid.AdMarkup), imp); t != 0 {
+		return t
+	}
+	if imp == nil {
+		return 0
+	}
+	if t := sizeMediaType(bid.Width, bid.Height, imp); t != 0 {
+		return t
+	}
+	if types := imp.MediaTypes(); len(types) == 1 {
+		return types[0]
+	}
+	return 0
+}
+
+// CheckMediaType checks that the media type of a bid is offered by the impression. A nil
+// impression offers no media type.
+func (bid *Bid) CheckMediaType(imp *Impression) error {
+	t := bid.MediaType(imp)
+	if t == 0 {
+		return ErrBidMediaTypeUnknown
+	}
+	if imp == nil || !imp.Offers(t) {
+		return ErrBidMediaTypeNotOffered
+	}
+	return nil
+}
+
+// sizeMediaType matches the size of a bid against the banner formats and the video player size.
+func sizeMediaType(w, h int, imp *Impression) MediaType {
+	if w == 0 || h == 0 {
+		return 0
+	}
+
+	var banner, video bool
+	if b := imp.Banner; b != nil {
+		banner = b.Width == w && b.Height == h
+		for _, f := range b.Formats {
+			banner = banner || (f.Width == w && f.Height == h)
+		}
+	}
+	if v := imp.Video; v != nil {
+		video = v.Width == w && v.Height == h
+	}
+
+	switch {
+	case banner && !video:
+		return MediaTypeBanner
+	case video && !banner:
+		return MediaTypeVideo
+	}
+	return 0
+}
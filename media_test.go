@@ -0,0 +1,63 @@
+package openrtb
+
+import "testing"
+
+func TestBid_MediaType(t *testing.T) {
+	multi := &Impression{ID: "1", Banner: &Banner{Width: 300, Height: 250}, Video: &Video{Width: 640, Height: 480}}
+	for _, tc := range []struct {
+		name string
+		bid  Bid
+		imp  *Impression
+		exp  MediaType
+	}{
+		{"mtype", Bid{MarkupType: 2}, multi, MediaTypeVideo},
+		{"single type", Bid{}, &Impression{Native: &Native{}}, MediaTypeNative},
+		{"single type markup", Bid{AdMarkup: `<VAST version="4.0"></VAST>`}, &Impression{Banner: &Banner{}}, MediaTypeVideo},
+		{"vast markup", Bid{AdMarkup: `<VAST version="4.0"></VAST>`}, multi, MediaTypeVideo},
+		{"html markup", Bid{AdMarkup: `<div>ad</div>`}, multi, MediaTypeBanner},
+		{"banner size", Bid{Width: 300, Height: 250}, multi, MediaTypeBanner},
+		{"video size", Bid{Width: 640, Height: 480}, multi, MediaTypeVideo},
+		{"unknown size", Bid{Width: 1, Height: 1}, multi, 0},
+		{"nil imp", Bid{AdMarkup: `<div>ad</div>`}, nil, MediaTypeBanner},
+		{"nil imp unknown", Bid{Width: 300, Height: 250}, nil, 0},
+	} {
+		if got := tc.bid.MediaType(tc.imp); got != tc.exp {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.exp, got)
+		}
+	}
+}
+
+func TestBid_CheckMediaType(t *testing.T) {
+	imp := &Impression{ID: "1", Banner: &Banner{}, Native: &Native{}}
+	banner := &Impression{ID: "1", Banner: &Banner{Width: 300, Height: 250}}
+	for _, tc := range []struct {
+		name string
+		bid  Bid
+		imp  *Impression
+		err  error
+	}{
+		{"offered", Bid{MarkupType: 1}, imp, nil},
+		{"not offered", Bid{MarkupType: 2}, imp, ErrBidMediaTypeNotOffered},
+		{"unknown", Bid{}, imp, ErrBidMediaTypeUnknown},
+		{"nil imp", Bid{MarkupType: 1}, nil, ErrBidMediaTypeNotOffered},
+		{"nil imp unknown", Bid{}, nil, ErrBidMediaTypeUnknown},
+		{"single type", Bid{AdMarkup: "<div>ad</div>"}, banner, nil},
+		{"single type markup", Bid{AdMarkup: `<VAST version="4.0"></VAST>`}, banner, ErrBidMediaTypeNotOffered},
+		{"single type size", Bid{Width: 640, Height: 360}, &Impression{Video: &Video{Width: 640, Height: 360}}, nil},
+	} {
+		if err := tc.bid.CheckMediaType(tc.imp); err != tc.err {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.err, err)
+		}
+	}
+}
+
+func TestImpression_MediaTypes(t *testing.T) {
+	imp := &Impression{Banner: &Banner{}, Audio: &Audio{}}
+	types := imp.MediaTypes()
+	if len(types) != 2 || types[0] != MediaTypeBanner || types[1] != MediaTypeAudio {
+		t.Fatalf("unexpected media types %v", types)
+	}
+	if !imp.Offers(MediaTypeAudio) || imp.Offers(MediaTypeVideo) || imp.Offers(0) {
+		t.Fatal("unexpected offers")
+	}
+}
@@ -0,0 +1,267 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// DefaultCurrency is the currency of bids and floors when none is specified.
+const DefaultCurrency = "USD"
+
+// Merge errors
+var (
+	ErrMergeIDMismatch    = errors.New("openrtb: bid response ID does not match the merged request")
+	ErrMergeCurrency      = errors.New("openrtb: bid currency cannot be converted")
+	ErrMergeDuplicateBid  = errors.New("openrtb: duplicate bid ID")
+	ErrMergeDuplicateSeat = errors.New("openrtb: duplicate seat")
+	ErrMergeBidsExceeded  = errors.New("openrtb: bid exceeds the maximum number of bids per impression")
+	ErrMergeGroupExceeded = errors.New("openrtb: group seat exceeds the maximum number of bids per impression")
+)
+
+// CurrencyConverter converts bid prices between currencies.
+type CurrencyConverter interface {
+	Convert(price float64, from, to string) (float64, error)
+}
+
+// Rates is a CurrencyConverter using fixed exchange rates, given as the value of one unit of
+// a common base currency in each currency, e.g. {"USD": 1, "EUR": 0.92}.
+type Rates map[string]float64
+
+// Convert implements CurrencyConverter
+func (r Rates) Convert(price float64, from, to string) (float64, error) {
+	rf, rt := r[from], r[to]
+	if rf <= 0 || rt <= 0 {
+		return 0, ErrMergeCurrency
+	}
+	return price / rf * rt, nil
+}
+
+// BidderResponse is the response of a named bidder.
+type BidderResponse struct {
+	Bidder   string
+	Response *BidResponse
+}
+
+// Responses returns the responses of the fan-out results with OutcomeBid.
+func Responses(results []FanOutResult) []BidderResponse {
+	var out []BidderResponse
+	for _, r := range results {
+		if r.Result != nil && r.Result.Outcome == OutcomeBid && r.Result.Response != nil {
+			out = append(out, BidderResponse{Bidder: r.Bidder, Response: r.Result.Response})
+		}
+	}
+	return out
+}
+
+// MergeError reports a response or a bid excluded from a merged response.
+type MergeError struct {
+	Bidder string
+	BidID  string // ID of the excluded bid, empty if the whole response was excluded
+	Err    error
+}
+
+func (e *MergeError) Error() string {
+	if e.BidID == "" {
+		return e.Err.Error() + " (bidder " + e.Bidder + ")"
+	}
+	return e.Err.Error() + " (bidder " + e.Bidder + ", bid " + e.BidID + ")"
+}
+
+// Unwrap returns the cause of the exclusion.
+func (e *MergeError) Unwrap() error { return e.Err }
+
+// Merger combines the responses of many bidders to a single request into one response.
+type Merger struct {
+	Currency      string            // Currency of the merged response, defaults to DefaultCurrency
+	Converter     CurrencyConverter // Converts bid prices, if nil only bids in Currency are merged
+	MaxBidsPerImp int               // Maximum number of bids per impression, the highest are kept; 0 keeps all
+}
+
+// Merge returns a response with the seat bids of all responses for the request ID, and the
+// reasons why responses or bids were excluded, as *MergeError. The responses are not modified.
+//
+// Seats without a name are named after their bidder, seat names used by more than one bidder are
+// prefixed with "<bidder>.". Seats whose name is still not unique, e.g. a seat repeated by a
+// bidder or a prefixed name used by another bidder, are excluded. Bid IDs are kept unique: a bid ID repeated by the same bidder is
+// excluded, one used by another bidder is prefixed with "<bidder>.". Bid prices are converted to
+// the merger's currency. If MaxBidsPerImp is set, only the highest bids of each impression are
+// kept, and seats bidding as a group lose all their bids if any of them is cut. The Ext of
+// each response is kept in the Ext of the merged response under the name of its bidder.
+func (m *Merger) Merge(id string, responses []BidderResponse) (*BidResponse, []error) {
+	currency := m.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	var errs []error
+	valid := make([]BidderResponse, 0, len(responses))
+	owners := make(map[string]string) // seat name => bidder, empty if shared
+	for _, br := range responses {
+		if br.Response == nil {
+			continue
+		}
+		if br.Response.ID != id {
+			errs = append(errs, &MergeError{Bidder: br.Bidder, Err: ErrMergeIDMismatch})
+			continue
+		}
+		valid = append(valid, br)
+		for _, sb := range br.Response.SeatBids {
+			seat := seatName(br.Bidder, sb.Seat)
+			if owner, ok := owners[seat]; ok && owner != br.Bidder {
+				owners[seat] = ""
+			} else if !ok {
+				owners[seat] = br.Bidder
+			}
+		}
+	}
+
+	out := &BidResponse{ID: id, Currency: currency}
+	bidders := make([]string, 0, len(valid)) // bidder of each merged seat
+	seats := make(map[string]bool)           // names of the merged seats
+	exts := make(map[string]json.RawMessage)
+	ids := make(map[string]string) // bid ID => bidder
+	for _, br := range valid {
+		resp := br.Response
+		from := resp.Currency
+		if from == "" {
+			from = DefaultCurrency
+		}
+		if from != currency && m.Converter == nil {
+			errs = append(errs, &MergeError{Bidder: br.Bidder, Err: ErrMergeCurrency})
+			continue
+		}
+
+		for _, sb := range resp.SeatBids {
+			seat := seatName(br.Bidder, sb.Seat)
+			if owners[seat] == "" {
+				seat = br.Bidder + "." + seat
+			}
+			if seats[seat] {
+				for _, bid := range sb.Bids {
+					errs = append(errs, &MergeError{Bidder: br.Bidder, BidID: bid.ID, Err: ErrMergeDuplicateSeat})
+				}
+				continue
+			}
+			msb := SeatBid{Seat: seat, Group: sb.Group, Ext: append(sb.Ext[:0:0], sb.Ext...)}
+
+			for i := range sb.Bids {
+				var bid Bid
+				sb.Bids[i].copyTo(&bid)
+
+				if from != currency {
+					price, err := m.Converter.Convert(bid.Price, from, currency)
+					if err != nil {
+						errs = append(errs, &MergeError{Bidder: br.Bidder, BidID: bid.ID, Err: err})
+						continue
+					}
+					bid.Price = price
+				}
+
+				if owner, ok := ids[bid.ID]; ok {
+					if owner == br.Bidder {
+						errs = append(errs, &MergeError{Bidder: br.Bidder, BidID: bid.ID, Err: ErrMergeDuplicateBid})
+						continue
+					}
+					if _, ok := ids[br.Bidder+"."+bid.ID]; ok {
+						errs = append(errs, &MergeError{Bidder: br.Bidder, BidID: bid.ID, Err: ErrMergeDuplicateBid})
+						continue
+					}
+					bid.ID = br.Bidder + "." + bid.ID
+				}
+				ids[bid.ID] = br.Bidder
+				msb.Bids = append(msb.Bids, bid)
+			}
+
+			if len(msb.Bids) != 0 {
+				seats[seat] = true
+				out.SeatBids = append(out.SeatBids, msb)
+				bidders = append(bidders, br.Bidder)
+			}
+		}
+
+		if len(resp.Ext) != 0 {
+			exts[br.Bidder] = resp.Ext
+		}
+	}
+
+	if m.MaxBidsPerImp > 0 {
+		errs = m.limit(out, bidders, errs)
+	}
+
+	if len(exts) != 0 {
+		ext, err := json.Marshal(exts)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			out.Ext = ext
+		}
+	}
+	return out, errs
+}
+
+// limit keeps the highest MaxBidsPerImp bids of each impression. Group seats which lose a bid
+// are removed altogether, and the selection is repeated without them.
+func (m *Merger) limit(resp *BidResponse, bidders []string, errs []error) []error {
+	type ref struct{ seat, bid int }
+
+	for {
+		byImp := make(map[string][]ref)
+		for i, sb := range resp.SeatBids {
+			for j := range sb.Bids {
+				byImp[sb.Bids[j].ImpID] = append(byImp[sb.Bids[j].ImpID], ref{i, j})
+			}
+		}
+
+		cut := make(map[ref]bool)
+		group := -1
+		for _, refs := range byImp {
+			if len(refs) <= m.MaxBidsPerImp {
+				continue
+			}
+			sort.SliceStable(refs, func(a, b int) bool {
+				return resp.SeatBids[refs[a].seat].Bids[refs[a].bid].Price > resp.SeatBids[refs[b].seat].Bids[refs[b].bid].Price
+			})
+			for _, r := range refs[m.MaxBidsPerImp:] {
+				cut[r] = true
+				if resp.SeatBids[r.seat].Group == 1 && (group < 0 || r.seat < group) {
+					group = r.seat
+				}
+			}
+		}
+
+		if group >= 0 {
+			for _, bid := range resp.SeatBids[group].Bids {
+				errs = append(errs, &MergeError{Bidder: bidders[group], BidID: bid.ID, Err: ErrMergeGroupExceeded})
+			}
+			resp.SeatBids = append(resp.SeatBids[:group], resp.SeatBids[group+1:]...)
+			bidders = append(bidders[:group], bidders[group+1:]...)
+			continue
+		}
+
+		seats := resp.SeatBids[:0]
+		for i, sb := range resp.SeatBids {
+			bids := sb.Bids[:0]
+			for j, bid := range sb.Bids {
+				if cut[ref{i, j}] {
+					errs = append(errs, &MergeError{Bidder: bidders[i], BidID: bid.ID, Err: ErrMergeBidsExceeded})
+				} else {
+					bids = append(bids, bid)
+				}
+			}
+			if len(bids) != 0 {
+				sb.Bids = bids
+				seats = append(seats, sb)
+			}
+		}
+		resp.SeatBids = seats
+		return errs
+	}
+}
+
+func seatName(bidder, seat string) string {
+	if seat == "" {
+		return bidder
+	}
+	return seat
+}
@@ -0,0 +1,200 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// mergedBids returns the bids of a merged response as "seat/id" strings.
+func mergedBids(resp *BidResponse) []string {
+	var out []string
+	for _, sb := range resp.SeatBids {
+		for _, bid := range sb.Bids {
+			out = append(out, sb.Seat+"/"+bid.ID)
+		}
+	}
+	return out
+}
+
+// mergeErrors returns the errors of a merge as "bidder/id: error" strings.
+func mergeErrors(t *testing.T, errs []error) []string {
+	t.Helper()
+	var out []string
+	for _, err := range errs {
+		me, ok := err.(*MergeError)
+		if !ok {
+			t.Fatalf("unexpected error %v", err)
+		}
+		out = append(out, me.Bidder+"/"+me.BidID+": "+me.Err.Error())
+	}
+	return out
+}
+
+func TestMerger_Merge(t *testing.T) {
+	responses := []BidderResponse{
+		{"a", &BidResponse{ID: "1", SeatBids: []SeatBid{
+			{Seat: "s1", Bids: []Bid{{ID: "1", ImpID: "1", Price: 1}, {ID: "1", ImpID: "1", Price: 2}}},
+			{Bids: []Bid{{ID: "2", ImpID: "1", Price: 1}}},
+		}, Ext: json.RawMessage(`{"debug":1}`)}},
+		{"b", &BidResponse{ID: "1", SeatBids: []SeatBid{
+			{Seat: "s1", Bids: []Bid{{ID: "1", ImpID: "1", Price: 3}}},
+			{Seat: "s2", Bids: []Bid{{ID: "3", ImpID: "2", Price: 3}}},
+		}}},
+		{"c", &BidResponse{ID: "2", SeatBids: []SeatBid{{Bids: []Bid{{ID: "4", ImpID: "1", Price: 1}}}}}},
+		{"d", nil},
+	}
+
+	resp, errs := new(Merger).Merge("1", responses)
+	if exp := []string{"a.s1/1", "a/2", "b.s1/b.1", "s2/3"}; !reflect.DeepEqual(mergedBids(resp), exp) {
+		t.Errorf("expected bids %v, got %v", exp, mergedBids(resp))
+	}
+	if exp := []string{"c/: " + ErrMergeIDMismatch.Error(), "a/1: " + ErrMergeDuplicateBid.Error()}; !reflect.DeepEqual(mergeErrors(t, errs), exp) {
+		t.Errorf("expected errors %v, got %v", exp, mergeErrors(t, errs))
+	}
+	if resp.ID != "1" || resp.Currency != DefaultCurrency || string(resp.Ext) != `{"a":{"debug":1}}` {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if responses[1].Response.SeatBids[0].Bids[0].ID != "1" {
+		t.Error("responses were modified")
+	}
+}
+
+func TestMerger_Merge_currency(t *testing.T) {
+	responses := []BidderResponse{
+		{"usd", &BidResponse{ID: "1", SeatBids: []SeatBid{{Bids: []Bid{{ID: "1", ImpID: "1", Price: 2}}}}}},
+		{"eur", &BidResponse{ID: "1", Currency: "EUR", SeatBids: []SeatBid{{Bids: []Bid{{ID: "2", ImpID: "1", Price: 1.84}}}}}},
+		{"xxx", &BidResponse{ID: "1", Currency: "XXX", SeatBids: []SeatBid{{Bids: []Bid{{ID: "3", ImpID: "1", Price: 1}}}}}},
+	}
+
+	// without a converter, only bids in the merger's currency are kept
+	resp, errs := (&Merger{Currency: "EUR"}).Merge("1", responses)
+	if exp := []string{"eur/2"}; !reflect.DeepEqual(mergedBids(resp), exp) {
+		t.Errorf("expected bids %v, got %v", exp, mergedBids(resp))
+	}
+	if exp := []string{"usd/: " + ErrMergeCurrency.Error(), "xxx/: " + ErrMergeCurrency.Error()}; !reflect.DeepEqual(mergeErrors(t, errs), exp) {
+		t.Errorf("expected errors %v, got %v", exp, mergeErrors(t, errs))
+	}
+
+	resp, errs = (&Merger{Currency: "EUR", Converter: Rates{"USD": 1, "EUR": 0.92}}).Merge("1", responses)
+	if exp := []string{"usd/1", "eur/2"}; !reflect.DeepEqual(mergedBids(resp), exp) {
+		t.Fatalf("expected bids %v, got %v", exp, mergedBids(resp))
+	}
+	if p := resp.SeatBids[0].Bids[0].Price; math.Abs(p-1.84) > 1e-9 || resp.SeatBids[1].Bids[0].Price != 1.84 || resp.Currency != "EUR" {
+		t.Errorf("unexpected prices %v, %v", p, resp.SeatBids[1].Bids[0].Price)
+	}
+	if exp := []string{"xxx/3: " + ErrMergeCurrency.Error()}; !reflect.DeepEqual(mergeErrors(t, errs), exp) {
+		t.Errorf("expected errors %v, got %v", exp, mergeErrors(t, errs))
+	}
+	if responses[0].Response.SeatBids[0].Bids[0].Price != 2 {
+		t.Error("responses were modified")
+	}
+}
+
+func TestMerger_Merge_limit(t *testing.T) {
+	responses := []BidderResponse{
+		{"a", &BidResponse{ID: "1", SeatBids: []SeatBid{{Bids: []Bid{
+			{ID: "a1", ImpID: "1", Price: 5},
+			{ID: "a2", ImpID: "1", Price: 1},
+			{ID: "a3", ImpID: "2", Price: 1},
+		}}}}},
+		{"b", &BidResponse{ID: "1", SeatBids: []SeatBid{{Bids: []Bid{
+			{ID: "b1", ImpID: "1", Price: 4},
+			{ID: "b2", ImpID: "2", Price: 2},
+		}}}}},
+		{"c", &BidResponse{ID: "1", SeatBids: []SeatBid{{Group: 1, Bids: []Bid{
+			{ID: "c1", ImpID: "1", Price: 3},
+			{ID: "c2", ImpID: "2", Price: 9},
+		}}}}},
+	}
+
+	// c's group loses its bid for impression 1, and with it the one for impression 2
+	resp, errs := (&Merger{MaxBidsPerImp: 2}).Merge("1", responses)
+	if exp := []string{"a/a1", "a/a3", "b/b1", "b/b2"}; !reflect.DeepEqual(mergedBids(resp), exp) {
+		t.Errorf("expected bids %v, got %v", exp, mergedBids(resp))
+	}
+	exp := []string{
+		"c/c1: " + ErrMergeGroupExceeded.Error(),
+		"c/c2: " + ErrMergeGroupExceeded.Error(),
+		"a/a2: " + ErrMergeBidsExceeded.Error(),
+	}
+	if !reflect.DeepEqual(mergeErrors(t, errs), exp) {
+		t.Errorf("expected errors %v, got %v", exp, mergeErrors(t, errs))
+	}
+
+	resp, errs = (&Merger{MaxBidsPerImp: 1}).Merge("1", responses[:2])
+	if exp := []string{"a/a1", "b/b2"}; !reflect.DeepEqual(mergedBids(resp), exp) || len(errs) != 3 {
+		t.Errorf("expected bids %v, got %v, %v", exp, mergedBids(resp), errs)
+	}
+}
+
+func TestMerger_Merge_seatCollisions(t *testing.T) {
+	bid := func(id string) []Bid { return []Bid{{ID: id, ImpID: "1", Price: 1}} }
+	for _, tc := range []struct {
+		name      string
+		responses []BidderResponse
+		bids      []string
+		errs      []string
+	}{
+		{
+			name: "seat repeated by a bidder",
+			responses: []BidderResponse{
+				{"a", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "s", Bids: bid("1")}, {Seat: "s", Bids: bid("2")}}}},
+			},
+			bids: []string{"s/1"},
+			errs: []string{"a/2: " + ErrMergeDuplicateSeat.Error()},
+		},
+		{
+			name: "unnamed seat repeated by a bidder",
+			responses: []BidderResponse{
+				{"a", &BidResponse{ID: "1", SeatBids: []SeatBid{{Bids: bid("1")}, {Seat: "a", Bids: bid("2")}}}},
+			},
+			bids: []string{"a/1"},
+			errs: []string{"a/2: " + ErrMergeDuplicateSeat.Error()},
+		},
+		{
+			name: "prefixed names",
+			responses: []BidderResponse{
+				{"x", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "a.b", Bids: bid("1")}}}},
+				{"y", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "a.b", Bids: bid("2")}}}},
+				{"x.a", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "b", Bids: bid("3")}}}},
+				{"z", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "b", Bids: bid("4")}}}},
+			},
+			bids: []string{"x.a.b/1", "y.a.b/2", "z.b/4"},
+			errs: []string{"x.a/3: " + ErrMergeDuplicateSeat.Error()},
+		},
+		{
+			name: "prefixed and literal names",
+			responses: []BidderResponse{
+				{"y", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "x.s", Bids: bid("1")}}}},
+				{"x", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "s", Bids: bid("2")}}}},
+				{"z", &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "s", Bids: bid("3")}}}},
+			},
+			bids: []string{"x.s/1", "z.s/3"},
+			errs: []string{"x/2: " + ErrMergeDuplicateSeat.Error()},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, errs := new(Merger).Merge("1", tc.responses)
+			if !reflect.DeepEqual(mergedBids(resp), tc.bids) {
+				t.Errorf("expected bids %v, got %v", tc.bids, mergedBids(resp))
+			}
+			if !reflect.DeepEqual(mergeErrors(t, errs), tc.errs) {
+				t.Errorf("expected errors %v, got %v", tc.errs, mergeErrors(t, errs))
+			}
+		})
+	}
+}
+
+func TestResponses(t *testing.T) {
+	resp := &BidResponse{ID: "1"}
+	results := []FanOutResult{
+		{Bidder: "a", Result: &BidderResult{Outcome: OutcomeBid, Response: resp}},
+		{Bidder: "b", Result: &BidderResult{Outcome: OutcomeNoBid, Response: resp}},
+		{Bidder: "c", Err: ErrSkipBidder},
+	}
+	if got := Responses(results); !reflect.DeepEqual(got, []BidderResponse{{"a", resp}}) {
+		t.Fatalf("unexpected responses %+v", got)
+	}
+}
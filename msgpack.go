@@ -0,0 +1,256 @@
+package openrtb
+
+import (
+	"math"
+)
+
+// MarshalMsgpack encodes an object as MessagePack, using the attribute names of its JSON encoding.
+// It is transcoded from the output of AppendJSON, so it costs a JSON encoding and parse on top
+// of writing MessagePack.
+func MarshalMsgpack(v Object) ([]byte, error) {
+	return marshalBinary(v, msgpackEncoder{})
+}
+
+// UnmarshalMsgpack decodes an object from MessagePack data. The data is transcoded to JSON,
+// which is then decoded with DecodeJSON.
+func UnmarshalMsgpack(data []byte, v Object) error {
+	d := msgpackDecoder{data: data}
+	w := binaryWriter{jsonWriter: jsonWriter{b: make([]byte, 0, 2*len(data))}}
+	d.value(&w)
+	if w.err == nil && d.pos != len(d.data) {
+		w.fail(ErrBinaryTrailing)
+	}
+	return unmarshalBinary(&w, v)
+}
+
+type msgpackEncoder struct{}
+
+func (msgpackEncoder) null(b []byte) []byte { return append(b, 0xc0) }
+
+func (msgpackEncoder) bool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 0xc3)
+	}
+	return append(b, 0xc2)
+}
+
+func (e msgpackEncoder) int(b []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return e.uint(b, uint64(v))
+	case v >= -32:
+		return append(b, byte(v))
+	case v >= math.MinInt8:
+		return append(b, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		return appendBigEndian(append(b, 0xd1), uint64(v), 2)
+	case v >= math.MinInt32:
+		return appendBigEndian(append(b, 0xd2), uint64(v), 4)
+	}
+	return appendBigEndian(append(b, 0xd3), uint64(v), 8)
+}
+
+func (msgpackEncoder) uint(b []byte, v uint64) []byte {
+	switch {
+	case v < 0x80:
+		return append(b, byte(v))
+	case v <= math.MaxUint8:
+		return append(b, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return appendBigEndian(append(b, 0xcd), v, 2)
+	case v <= math.MaxUint32:
+		return appendBigEndian(append(b, 0xce), v, 4)
+	}
+	return appendBigEndian(append(b, 0xcf), v, 8)
+}
+
+func (msgpackEncoder) float(b []byte, v float64) []byte {
+	if isFloat32(v) {
+		return appendBigEndian(append(b, 0xca), uint64(math.Float32bits(float32(v))), 4)
+	}
+	return appendBigEndian(append(b, 0xcb), math.Float64bits(v), 8)
+}
+
+func (msgpackEncoder) string(b []byte, s []byte) []byte {
+	switch n := uint64(len(s)); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = appendBigEndian(append(b, 0xda), n, 2)
+	default:
+		b = appendBigEndian(append(b, 0xdb), n, 4)
+	}
+	return append(b, s...)
+}
+
+func (msgpackEncoder) array(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return appendBigEndian(append(b, 0xdc), uint64(n), 2)
+	}
+	return appendBigEndian(append(b, 0xdd), uint64(n), 4)
+}
+
+func (msgpackEncoder) object(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return appendBigEndian(append(b, 0xde), uint64(n), 2)
+	}
+	return appendBigEndian(append(b, 0xdf), uint64(n), 4)
+}
+
+func appendBigEndian(b []byte, v uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
+
+// --------------------------------------------------------------------
+
+type msgpackDecoder struct {
+	data []byte
+	pos  int
+}
+
+// read returns the next n bytes, or nil if the data is truncated.
+func (d *msgpackDecoder) read(w *binaryWriter, n uint64) []byte {
+	if uint64(len(d.data)-d.pos) < n {
+		w.fail(ErrBinaryTruncated)
+		d.pos = len(d.data)
+		return nil
+	}
+	p := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return p
+}
+
+func (d *msgpackDecoder) uint(w *binaryWriter, size int) uint64 {
+	p := d.read(w, uint64(size))
+	if p == nil {
+		return 0
+	}
+	var v uint64
+	for _, c := range p {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// length reads a container length, which is limited by the remaining data.
+func (d *msgpackDecoder) length(w *binaryWriter, size int, perElem int) int {
+	n := d.uint(w, size)
+	if n > uint64(len(d.data)-d.pos)/uint64(perElem) {
+		w.fail(ErrBinaryTruncated)
+		return 0
+	}
+	return int(n)
+}
+
+// value decodes the next value and writes it as JSON.
+func (d *msgpackDecoder) value(w *binaryWriter) {
+	if w.err != nil {
+		return
+	}
+	p := d.read(w, 1)
+	if p == nil {
+		return
+	}
+
+	switch c := p[0]; {
+	case c < 0x80:
+		w.int(int64(c))
+	case c >= 0xe0:
+		w.int(int64(int8(c)))
+	case c&0xf0 == 0x80:
+		d.object(w, int(c&0x0f))
+	case c&0xf0 == 0x90:
+		d.array(w, int(c&0x0f))
+	case c&0xe0 == 0xa0:
+		d.string(w, uint64(c&0x1f))
+	case c == 0xc0:
+		w.null()
+	case c == 0xc2:
+		w.b = append(w.b, "false"...)
+	case c == 0xc3:
+		w.b = append(w.b, "true"...)
+	case c == 0xca:
+		w.float(float64(math.Float32frombits(uint32(d.uint(w, 4)))), 64)
+	case c == 0xcb:
+		w.float(math.Float64frombits(d.uint(w, 8)), 64)
+	case c >= 0xcc && c <= 0xcf:
+		w.uint(d.uint(w, 1<<(c-0xcc)))
+	case c >= 0xd0 && c <= 0xd3:
+		size := 1 << (c - 0xd0)
+		v := d.uint(w, size)
+		w.int(int64(v<<(64-8*size)) >> (64 - 8*size)) // sign extension
+	case c >= 0xd9 && c <= 0xdb:
+		d.string(w, d.uint(w, 1<<(c-0xd9)))
+	case c == 0xdc || c == 0xdd:
+		d.array(w, d.length(w, 2<<(c-0xdc), 1))
+	case c == 0xde || c == 0xdf:
+		d.object(w, d.length(w, 2<<(c-0xde), 2))
+	default: // bin and ext types
+		w.fail(ErrBinaryUnsupported)
+	}
+}
+
+func (d *msgpackDecoder) string(w *binaryWriter, n uint64) {
+	if s := d.read(w, n); w.err == nil {
+		w.string(string(s))
+	}
+}
+
+func (d *msgpackDecoder) array(w *binaryWriter, n int) {
+	if !w.enter() {
+		return
+	}
+	w.b = append(w.b, '[')
+	for i := 0; i < n && w.err == nil; i++ {
+		w.separator(i)
+		d.value(w)
+	}
+	w.b = append(w.b, ']')
+	w.depth--
+}
+
+func (d *msgpackDecoder) object(w *binaryWriter, n int) {
+	if !w.enter() {
+		return
+	}
+	w.b = append(w.b, '{')
+	for i := 0; i < n && w.err == nil; i++ {
+		w.separator(i)
+		d.key(w)
+		d.value(w)
+	}
+	w.b = append(w.b, '}')
+	w.depth--
+}
+
+func (d *msgpackDecoder) key(w *binaryWriter) {
+	p := d.read(w, 1)
+	if p == nil {
+		return
+	}
+
+	var n uint64
+	switch c := p[0]; {
+	case c&0xe0 == 0xa0:
+		n = uint64(c & 0x1f)
+	case c >= 0xd9 && c <= 0xdb:
+		n = d.uint(w, 1<<(c-0xd9))
+	default:
+		w.fail(ErrBinaryKey)
+		return
+	}
+	if s := d.read(w, n); w.err == nil {
+		w.key(s)
+	}
+}
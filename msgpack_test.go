@@ -0,0 +1,114 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalMsgpack_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 500; n++ {
+		assertBinaryRoundTrip(t, rng, MarshalMsgpack, UnmarshalMsgpack)
+	}
+}
+
+func FuzzMarshalMsgpack(f *testing.F) {
+	f.Add(int64(0))
+	f.Add(int64(1))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		assertBinaryRoundTrip(t, rand.New(rand.NewSource(seed)), MarshalMsgpack, UnmarshalMsgpack)
+	})
+}
+
+func FuzzUnmarshalMsgpack(f *testing.F) {
+	for _, s := range []string{goldenRequest, `{"id":"1","imp":[{"id":"1","ext":{"a":[null,true,1.5,-3]}}]}`} {
+		var req BidRequest
+		if err := req.DecodeJSON([]byte(s)); err != nil {
+			f.Fatal(err)
+		}
+		data, err := MarshalMsgpack(&req)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		assertBinaryDecode(t, data, MarshalMsgpack, UnmarshalMsgpack)
+	})
+}
+
+// assertBinaryRoundTrip checks that random requests and responses survive a round-trip through
+// a binary encoding, with Ext values re-encoded in compact JSON.
+func assertBinaryRoundTrip(t *testing.T, rng *rand.Rand, marshal func(Object) ([]byte, error), unmarshal func([]byte, Object) error) {
+	t.Helper()
+
+	for _, typ := range []reflect.Type{reflect.TypeOf(BidRequest{}), reflect.TypeOf(BidResponse{})} {
+		v := reflect.New(typ)
+		fill(rng, v.Elem(), 0)
+		exp, err := v.Interface().(Object).AppendJSON(nil)
+		if err != nil {
+			continue // invalid raw JSON in Ext
+		}
+
+		data, err := marshal(v.Interface().(Object))
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", typ, err, exp)
+		}
+		got := reflect.New(typ)
+		if err := unmarshal(data, got.Interface().(Object)); err != nil {
+			t.Fatalf("%s: %v\n%s", typ, err, exp)
+		}
+		out, err := got.Interface().(Object).AppendJSON(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertJSONEqual(t, exp, out)
+	}
+}
+
+// assertBinaryDecode checks that binary data which decodes to a request encodes to the same
+// request again. Requests are compared by their JSON encoding, as empty and nil slices are
+// both omitted.
+func assertBinaryDecode(t *testing.T, data []byte, marshal func(Object) ([]byte, error), unmarshal func([]byte, Object) error) {
+	t.Helper()
+
+	var req BidRequest
+	if unmarshal(data, &req) != nil {
+		return
+	}
+	enc, err := marshal(&req)
+	if err != nil {
+		t.Fatalf("%v for %+v", err, req)
+	}
+	var got BidRequest
+	if err := unmarshal(enc, &got); err != nil {
+		t.Fatalf("%v for %x", err, enc)
+	}
+	exp, err := req.AppendJSON(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := got.AppendJSON(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSONEqual(t, exp, out)
+}
+
+func assertJSONEqual(t *testing.T, exp, got []byte) {
+	t.Helper()
+
+	var e, g bytes.Buffer
+	if err := json.Compact(&e, exp); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Compact(&g, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(e.Bytes(), g.Bytes()) {
+		t.Fatalf("expected\n%s\ngot\n%s", e.Bytes(), g.Bytes())
+	}
+}
@@ -501,6 +501,10 @@ const (
 	APIFrameworkMRAID1  APIFramework = 3
 	APIFrameworkORMMA   APIFramework = 4
 	APIFrameworkMRAID2  APIFramework = 5
+	APIFrameworkMRAID3  APIFramework = 6
+	APIFrameworkOMID1   APIFramework = 7
+	APIFrameworkSIMID1  APIFramework = 8
+	APIFrameworkSIMID11 APIFramework = 9
 )
 
 // VideoLinearity as defined in section 5.7.
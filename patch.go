@@ -0,0 +1,493 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Patch errors
+var (
+	ErrPatchInvalid    = errors.New("openrtb: invalid patch document")
+	ErrPatchMalformed  = errors.New("openrtb: malformed patch operation")
+	ErrPatchOperation  = errors.New("openrtb: unknown patch operation")
+	ErrPatchTestFailed = errors.New("openrtb: patch test failed")
+)
+
+// PatchError reports the failing operation of a JSON Patch, or the value of the patched
+// document which cannot be decoded.
+type PatchError struct {
+	Index int    // Index of the operation in the patch document, -1 if unknown or for merge patches
+	Op    string // Operation, e.g. "replace"
+	Path  string // JSON Pointer the operation applies to, or of the value which cannot be decoded
+	Err   error  // ErrPatchMalformed, ErrPatchOperation, ErrPatchTestFailed, ErrInvalidPath, ErrPathNotFound or a decoding error
+}
+
+func (e *PatchError) Error() string {
+	if e.Index < 0 {
+		return e.Err.Error() + " at " + strconv.Quote(e.Path)
+	}
+	return e.Err.Error() + " (operation " + strconv.Itoa(e.Index) + ": " + e.Op + " " + strconv.Quote(e.Path) + ")"
+}
+
+func (e *PatchError) Unwrap() error { return e.Err }
+
+// ApplyJSONPatch returns a copy of the request with a JSON Patch (RFC 6902) applied.
+func (req *BidRequest) ApplyJSONPatch(patch []byte) (*BidRequest, error) {
+	out := new(BidRequest)
+	if err := applyPatch(req, out, patch, jsonPatch); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplyMergePatch returns a copy of the request with a JSON Merge Patch (RFC 7396) applied.
+func (req *BidRequest) ApplyMergePatch(patch []byte) (*BidRequest, error) {
+	out := new(BidRequest)
+	if err := applyPatch(req, out, patch, mergePatch); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplyJSONPatch returns a copy of the impression with a JSON Patch (RFC 6902) applied.
+func (imp *Impression) ApplyJSONPatch(patch []byte) (*Impression, error) {
+	out := new(Impression)
+	if err := applyPatch(imp, out, patch, jsonPatch); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplyMergePatch returns a copy of the impression with a JSON Merge Patch (RFC 7396) applied.
+func (imp *Impression) ApplyMergePatch(patch []byte) (*Impression, error) {
+	out := new(Impression)
+	if err := applyPatch(imp, out, patch, mergePatch); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplyJSONPatch returns a copy of the bid with a JSON Patch (RFC 6902) applied.
+func (bid *Bid) ApplyJSONPatch(patch []byte) (*Bid, error) {
+	out := new(Bid)
+	if err := applyPatch(bid, out, patch, jsonPatch); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplyMergePatch returns a copy of the bid with a JSON Merge Patch (RFC 7396) applied.
+func (bid *Bid) ApplyMergePatch(patch []byte) (*Bid, error) {
+	out := new(Bid)
+	if err := applyPatch(bid, out, patch, mergePatch); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// applyPatch applies a patch to the JSON representation of v and decodes the result into out.
+func applyPatch(v, out Object, patch []byte, apply func(doc, patch interface{}) (interface{}, error)) error {
+	data, err := v.AppendJSON(nil)
+	if err != nil {
+		return err
+	}
+	doc, err := decodeTree(data)
+	if err != nil {
+		return err
+	}
+	p, err := decodeTree(patch)
+	if err != nil {
+		return ErrPatchInvalid
+	}
+
+	if doc, err = apply(doc, p); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(doc); err != nil {
+		return err
+	}
+
+	r := jsonReader{data: data, track: true}
+	out.decodeJSON(&r)
+	if err := r.finish(); err != nil {
+		index, op := patchOperationAt(p, r.errPointer)
+		return &PatchError{Index: index, Op: op, Path: r.errPointer, Err: err}
+	}
+	return nil
+}
+
+// patchOperationAt returns the index and name of the last operation of a JSON Patch which
+// changed the value at the pointer or one containing it, -1 if there is none.
+func patchOperationAt(patch interface{}, pointer string) (int, string) {
+	ops, _ := patch.([]interface{})
+	path, _ := parsePointer(pointer)
+	for i := len(ops) - 1; i >= 0; i-- {
+		op, err := parsePatchOperation(ops[i])
+		if err != nil || op.op == "test" || op.op == "remove" {
+			continue
+		}
+		if p, err := parsePointer(op.path); err == nil && (isPrefix(p, path) || isPrefix(path, p)) {
+			return i, op.op
+		}
+	}
+	return -1, ""
+}
+
+// decodeTree decodes JSON into maps, slices and scalars, keeping numbers as json.Number.
+func decodeTree(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, ErrJSONTrailingData
+	}
+	return v, nil
+}
+
+// --------------------------------------------------------------------
+
+type patchOperation struct {
+	op    string
+	path  string
+	from  string
+	value interface{}
+
+	hasPath, hasFrom, hasValue bool
+}
+
+func jsonPatch(doc, patch interface{}) (interface{}, error) {
+	ops, ok := patch.([]interface{})
+	if !ok {
+		return nil, ErrPatchInvalid
+	}
+
+	for i, o := range ops {
+		op, err := parsePatchOperation(o)
+		if err == nil {
+			doc, err = op.apply(doc)
+		}
+		if err != nil {
+			return nil, &PatchError{Index: i, Op: op.op, Path: op.path, Err: err}
+		}
+	}
+	return doc, nil
+}
+
+// parsePatchOperation parses an operation object, the result is filled as far as possible on errors.
+func parsePatchOperation(o interface{}) (*patchOperation, error) {
+	op := new(patchOperation)
+	m, ok := o.(map[string]interface{})
+	if !ok {
+		return op, ErrPatchMalformed
+	}
+
+	var valid bool
+	op.op, valid = m["op"].(string)
+	op.path, op.hasPath = m["path"].(string)
+	op.from, op.hasFrom = m["from"].(string)
+	op.value, op.hasValue = m["value"]
+	if _, ok := m["from"]; ok && !op.hasFrom {
+		valid = false
+	}
+
+	switch op.op {
+	case "add", "replace", "test":
+		valid = valid && op.hasValue
+	case "move", "copy":
+		valid = valid && op.hasFrom
+	}
+	if !valid || !op.hasPath {
+		return op, ErrPatchMalformed
+	}
+	return op, nil
+}
+
+func (op *patchOperation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(op.path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.op {
+	case "add":
+		return addValue(doc, path, op.value)
+	case "remove":
+		doc, _, err = removeValue(doc, path)
+		return doc, err
+	case "replace":
+		if doc, _, err = removeValue(doc, path); err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, op.value)
+	case "test":
+		v, err := lookupValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !treeEqual(v, op.value) {
+			return nil, ErrPatchTestFailed
+		}
+		return doc, nil
+	case "move", "copy":
+		from, err := parsePointer(op.from)
+		if err != nil {
+			return nil, err
+		}
+		var v interface{}
+		if op.op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, ErrInvalidPath // a location cannot be moved into one of its children
+			}
+			doc, v, err = removeValue(doc, from)
+		} else {
+			v, err = lookupValue(doc, from)
+			v = copyTree(v)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+	}
+	return nil, ErrPatchOperation
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped reference tokens.
+func parsePointer(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if s[0] != '/' {
+		return nil, ErrInvalidPath
+	}
+	tokens := strings.Split(s[1:], "/")
+	for i, t := range tokens {
+		if strings.IndexByte(t, '~') >= 0 {
+			t = strings.ReplaceAll(t, "~1", "/")
+			tokens[i] = strings.ReplaceAll(t, "~0", "~")
+		}
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, t := range prefix {
+		if path[i] != t {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex parses an array index token, "-" refers to the end of the array if allowed.
+func arrayIndex(token string, n int, end bool) (int, error) {
+	if token == "-" && end {
+		return n, nil
+	}
+	if token == "" || (token[0] == '0' && len(token) > 1) || token[0] == '+' || token[0] == '-' {
+		return 0, ErrInvalidPath
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, ErrInvalidPath
+	}
+	if i > n || (i == n && !end) {
+		return 0, ErrPathNotFound
+	}
+	return i, nil
+}
+
+func lookupValue(doc interface{}, path []string) (interface{}, error) {
+	for _, t := range path {
+		switch c := doc.(type) {
+		case map[string]interface{}:
+			v, ok := c[t]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			doc = v
+		case []interface{}:
+			i, err := arrayIndex(t, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = c[i]
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+	return doc, nil
+}
+
+// addValue adds a value to the document and returns the document, which is replaced if path is empty.
+func addValue(doc interface{}, path []string, v interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	parent, err := lookupValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	t := path[len(path)-1]
+	switch c := parent.(type) {
+	case map[string]interface{}:
+		c[t] = v
+	case []interface{}:
+		i, err := arrayIndex(t, len(c), true)
+		if err != nil {
+			return nil, err
+		}
+		c = append(c, nil)
+		copy(c[i+1:], c[i:])
+		c[i] = v
+		return setValue(doc, path[:len(path)-1], c)
+	default:
+		return nil, ErrPathNotFound
+	}
+	return doc, nil
+}
+
+// removeValue removes a value from the document and returns the document and the removed value.
+func removeValue(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := lookupValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t := path[len(path)-1]
+	switch c := parent.(type) {
+	case map[string]interface{}:
+		v, ok := c[t]
+		if !ok {
+			return nil, nil, ErrPathNotFound
+		}
+		delete(c, t)
+		return doc, v, nil
+	case []interface{}:
+		i, err := arrayIndex(t, len(c), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		v := c[i]
+		c = append(c[:i], c[i+1:]...)
+		doc, err = setValue(doc, path[:len(path)-1], c)
+		return doc, v, err
+	}
+	return nil, nil, ErrPathNotFound
+}
+
+// setValue replaces an existing value, which is required for arrays that changed their length.
+func setValue(doc interface{}, path []string, v interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	parent, err := lookupValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := parent.(type) {
+	case map[string]interface{}:
+		c[path[len(path)-1]] = v
+	case []interface{}:
+		i, err := arrayIndex(path[len(path)-1], len(c), false)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = v
+	}
+	return doc, nil
+}
+
+func copyTree(v interface{}) interface{} {
+	switch c := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(c))
+		for k, e := range c {
+			m[k] = copyTree(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(c))
+		for i, e := range c {
+			s[i] = copyTree(e)
+		}
+		return s
+	}
+	return v
+}
+
+// treeEqual compares decoded values as required by the test operation, numbers by value.
+func treeEqual(a, b interface{}) bool {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			if w, ok := y[k]; !ok || !treeEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !treeEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if x == y {
+			return true
+		}
+		fx, ex := x.Float64()
+		fy, ey := y.Float64()
+		return ex == nil && ey == nil && fx == fy
+	}
+	return a == b
+}
+
+// --------------------------------------------------------------------
+
+func mergePatch(doc, patch interface{}) (interface{}, error) {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch, nil
+	}
+
+	target, ok := doc.(map[string]interface{})
+	if !ok {
+		target = make(map[string]interface{}, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		target[k], _ = mergePatch(target[k], v)
+	}
+	return target, nil
+}
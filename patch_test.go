@@ -0,0 +1,90 @@
+package openrtb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBidRequest_ApplyJSONPatch(t *testing.T) {
+	req := &BidRequest{ID: "1", Impressions: []Impression{{ID: "1", BidFloor: 1}}}
+
+	out, err := req.ApplyJSONPatch([]byte(`[{"op":"replace","path":"/imp/0/bidfloor","value":2.5},{"op":"add","path":"/tmax","value":100}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Impressions[0].BidFloor != 2.5 || out.TMax != 100 || req.Impressions[0].BidFloor != 1 {
+		t.Fatalf("unexpected result %+v", out)
+	}
+
+	out, err = req.ApplyJSONPatch([]byte(`[{"op":"test","path":"/id","value":"2"}]`))
+	if out != nil || !errors.Is(err, ErrPatchTestFailed) {
+		t.Fatalf("expected ErrPatchTestFailed and no request, got %+v, %v", out, err)
+	}
+	out, err = req.ApplyJSONPatch([]byte(`{`))
+	if out != nil || err == nil {
+		t.Fatalf("expected error and no request, got %+v, %v", out, err)
+	}
+}
+
+func TestBidRequest_ApplyMergePatch(t *testing.T) {
+	req := &BidRequest{ID: "1", TMax: 100, Impressions: []Impression{{ID: "1"}}}
+
+	out, err := req.ApplyMergePatch([]byte(`{"tmax":null,"cur":["EUR"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.TMax != 0 || len(out.Currencies) != 1 || out.Impressions[0].ID != "1" {
+		t.Fatalf("unexpected result %+v", out)
+	}
+
+	out, err = req.ApplyMergePatch([]byte(`{"tmax":"x"}`))
+	var pe *PatchError
+	if out != nil || !errors.As(err, &pe) || *pe != (PatchError{Index: -1, Path: "/tmax", Err: ErrJSONUnexpected}) {
+		t.Fatalf("expected patch error at /tmax and no request, got %+v, %v", out, err)
+	}
+	if s := err.Error(); s != `openrtb: unexpected JSON value type at "/tmax"` {
+		t.Fatalf("unexpected message %q", s)
+	}
+}
+
+func TestApplyPatch_decodeErrors(t *testing.T) {
+	req := &BidRequest{ID: "1", TMax: 100, Impressions: []Impression{{ID: "1"}}}
+	for _, tc := range []struct {
+		patch string
+		exp   PatchError
+	}{
+		{`[{"op":"replace","path":"/tmax","value":"x"}]`, PatchError{Index: 0, Op: "replace", Path: "/tmax", Err: ErrJSONUnexpected}},
+		{`[{"op":"replace","path":"/tmax","value":1},{"op":"test","path":"/id","value":"1"},{"op":"add","path":"/imp/0/banner","value":{"w":"x"}}]`, PatchError{Index: 2, Op: "add", Path: "/imp/0/banner/w", Err: ErrJSONUnexpected}},
+		{`[{"op":"replace","path":"/imp","value":[{"id":"1"},{"id":2}]},{"op":"add","path":"/tmax","value":1}]`, PatchError{Index: 0, Op: "replace", Path: "/imp/1/id", Err: ErrJSONUnexpected}},
+		{`[{"op":"copy","from":"/id","path":"/at"}]`, PatchError{Index: 0, Op: "copy", Path: "/at", Err: ErrJSONUnexpected}},
+		{`[{"op":"add","path":"/ext","value":{"a/b":1}},{"op":"move","from":"/ext","path":"/imp/0/id"}]`, PatchError{Index: 1, Op: "move", Path: "/imp/0/id", Err: ErrJSONUnexpected}},
+	} {
+		out, err := req.ApplyJSONPatch([]byte(tc.patch))
+		var pe *PatchError
+		if out != nil || !errors.As(err, &pe) || *pe != tc.exp {
+			t.Errorf("%s: expected %+v and no request, got %+v, %v", tc.patch, tc.exp, out, err)
+		}
+	}
+
+	_, err := (&Bid{ID: "1"}).ApplyMergePatch([]byte(`{"ext":{},"adomain":["a",1]}`))
+	var pe *PatchError
+	if !errors.As(err, &pe) || *pe != (PatchError{Index: -1, Path: "/adomain/1", Err: ErrJSONUnexpected}) {
+		t.Fatalf("expected patch error at /adomain/1, got %v", err)
+	}
+}
+
+func TestApplyPatch_errors(t *testing.T) {
+	patch := []byte(`[{"op":"move","path":"/x"}]`)
+	if out, err := (&Impression{ID: "1"}).ApplyJSONPatch(patch); out != nil || err == nil {
+		t.Errorf("impression: expected error and no result, got %+v, %v", out, err)
+	}
+	if out, err := (&Impression{ID: "1"}).ApplyMergePatch([]byte(`[`)); out != nil || err == nil {
+		t.Errorf("impression: expected error and no result, got %+v, %v", out, err)
+	}
+	if out, err := (&Bid{ID: "1"}).ApplyJSONPatch(patch); out != nil || err == nil {
+		t.Errorf("bid: expected error and no result, got %+v, %v", out, err)
+	}
+	if out, err := (&Bid{ID: "1"}).ApplyMergePatch([]byte(`{"price":"x"}`)); out != nil || !errors.Is(err, ErrJSONUnexpected) {
+		t.Errorf("bid: expected %v and no result, got %+v, %v", ErrJSONUnexpected, out, err)
+	}
+}
@@ -0,0 +1,548 @@
+package openrtb
+
+import (
+	"errors"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pod errors
+var (
+	ErrPodNoImpressions = errors.New("openrtb: ad pod has no impressions")
+	ErrPodNoVideo       = errors.New("openrtb: ad pod impression has no video")
+)
+
+// podSearchLimit bounds the number of combinations tried when assembling a pod.
+const podSearchLimit = 1 << 20
+
+// PodImpressions returns the video impressions belonging to the ad pod with the ID.
+func (req *BidRequest) PodImpressions(podID int) []Impression {
+	var imps []Impression
+	for _, imp := range req.Impressions {
+		if imp.Video != nil && imp.Video.PoDid == podID {
+			imps = append(imps, imp)
+		}
+	}
+	return imps
+}
+
+// Pod is an assembled ad pod.
+type Pod struct {
+	Bids      []*Bid  // Selected bids in play order
+	Duration  int     // Total duration in seconds
+	Price     float64 // Sum of the bid prices
+	Truncated bool    // The search was stopped at its limit, a better selection may exist
+}
+
+// PodAssembler selects the bids filling an ad pod.
+type PodAssembler struct {
+	SeparateCategories bool // No two ads of a pod may share a category
+	SeparateDomains    bool // No two ads of a pod may share an advertiser domain
+}
+
+// Assemble selects the revenue-maximizing set of bids for the impressions of an ad pod.
+//
+// Impressions of a structured pod are filled by one bid each, impressions describing a dynamic
+// pod (with PodDur or MaxSeq) by up to MaxSeq bids with a total duration of at most PodDur.
+// Bids must have a Duration that is one of RqdDurs, or between MinDuration and MaxDuration,
+// and a price of at least BidFloor and MinCPMPerSec times their duration. A bid for a specific
+// slot (SlotInPod) is only eligible for impressions guaranteeing that slot, and is placed
+// there. Bids without a Duration or for other impressions are ignored.
+//
+// The search tries at most 2^20 combinations. If it is stopped there, the best pod
+// found so far is returned with Truncated set.
+func (a *PodAssembler) Assemble(imps []Impression, bids []*Bid) (*Pod, error) {
+	if len(imps) == 0 {
+		return nil, ErrPodNoImpressions
+	}
+
+	s := &podSearch{assembler: a, slots: make([]podSlot, len(imps))}
+	for i := range imps {
+		if imps[i].Video == nil {
+			return nil, ErrPodNoVideo
+		}
+		v := imps[i].Video
+		s.slots[i] = podSlot{imp: &imps[i], dynamic: v.PodDur > 0 || v.MaxSeq > 0}
+	}
+
+	for _, bid := range bids {
+		for i := range s.slots {
+			if s.slots[i].imp.ID == bid.ImpID {
+				if pos, ok := s.slots[i].eligible(bid); ok {
+					s.candidates = append(s.candidates, podCandidate{bid: bid, slot: i, pos: pos})
+				}
+				break
+			}
+		}
+	}
+	sort.SliceStable(s.candidates, func(i, j int) bool {
+		return s.candidates[i].bid.Price > s.candidates[j].bid.Price
+	})
+	s.prefix = make([]float64, len(s.candidates)+1)
+	for i, c := range s.candidates {
+		s.prefix[i+1] = s.prefix[i] + c.bid.Price
+	}
+
+	s.selected = make([]bool, len(s.candidates))
+	s.best = make([]bool, len(s.candidates))
+	s.run(0, 0)
+	return s.pod(), nil
+}
+
+type podSlot struct {
+	imp      *Impression
+	dynamic  bool
+	count    int
+	duration int
+}
+
+// eligible checks a bid against the constraints of the slot, and returns the position
+// it requires: 1 for the first slot, -1 for the last, 2 for either, 0 for any.
+func (s *podSlot) eligible(bid *Bid) (int, bool) {
+	v := s.imp.Video
+	dur := bid.Duration
+	if dur <= 0 {
+		return 0, false
+	}
+	if len(v.RqdDurs) != 0 {
+		found := false
+		for _, d := range v.RqdDurs {
+			found = found || d == dur
+		}
+		if !found {
+			return 0, false
+		}
+	} else if (v.MinDuration > 0 && dur < v.MinDuration) || (v.MaxDuration > 0 && dur > v.MaxDuration) {
+		return 0, false
+	}
+	if s.dynamic && v.PodDur > 0 && dur > v.PodDur {
+		return 0, false
+	}
+	if bid.Price < s.imp.BidFloor || bid.Price < minCPMFloor(v.MinCPMPerSec, dur) {
+		return 0, false
+	}
+
+	guarantee := v.SlotInPod
+	switch bid.SlotInPod {
+	case 0:
+		if !s.dynamic && (guarantee == 1 || guarantee == -1) {
+			return guarantee, true
+		}
+		return 0, true
+	case 1, -1:
+		if guarantee != bid.SlotInPod && guarantee != 2 {
+			return 0, false
+		}
+	case 2:
+		if guarantee == 0 {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return bid.SlotInPod, true
+}
+
+// fits reports whether another bid of the duration fits the slot.
+func (s *podSlot) fits(dur int) bool {
+	v := s.imp.Video
+	if !s.dynamic {
+		return s.count == 0
+	}
+	return (v.MaxSeq <= 0 || s.count < v.MaxSeq) && (v.PodDur <= 0 || s.duration+dur <= v.PodDur)
+}
+
+type podCandidate struct {
+	bid  *Bid
+	slot int
+	pos  int
+}
+
+// podSearch is a branch and bound search over the candidates, ordered by price.
+type podSearch struct {
+	assembler  *PodAssembler
+	slots      []podSlot
+	candidates []podCandidate
+	prefix     []float64 // Sums of the candidate prices
+
+	selected  []bool
+	positions [3]int // Selected bids requiring the first, the last, and either slot
+	nodes     int
+
+	best      []bool
+	bestPrice float64
+}
+
+func (s *podSearch) run(i int, price float64) {
+	if price > s.bestPrice {
+		s.bestPrice = price
+		copy(s.best, s.selected)
+	}
+	if i == len(s.candidates) || s.nodes >= podSearchLimit {
+		return
+	}
+	s.nodes++
+
+	capacity := 0
+	for _, sl := range s.slots {
+		switch {
+		case !sl.dynamic:
+			capacity += 1 - sl.count
+		case sl.imp.Video.MaxSeq > 0:
+			capacity += sl.imp.Video.MaxSeq - sl.count
+		default:
+			capacity += len(s.candidates)
+		}
+	}
+	end := i + capacity
+	if end > len(s.candidates) {
+		end = len(s.candidates)
+	}
+	if price+s.prefix[end]-s.prefix[i] <= s.bestPrice {
+		return
+	}
+
+	c := s.candidates[i]
+	sl := &s.slots[c.slot]
+	if sl.fits(c.bid.Duration) && s.placeable(c.pos) && s.separated(c.bid) {
+		sl.count++
+		sl.duration += c.bid.Duration
+		s.place(c.pos, 1)
+		s.selected[i] = true
+
+		s.run(i+1, price+c.bid.Price)
+
+		s.selected[i] = false
+		s.place(c.pos, -1)
+		sl.duration -= c.bid.Duration
+		sl.count--
+	}
+	s.run(i+1, price)
+}
+
+func (s *podSearch) place(pos, n int) {
+	switch pos {
+	case 1:
+		s.positions[0] += n
+	case -1:
+		s.positions[1] += n
+	case 2:
+		s.positions[2] += n
+	}
+}
+
+// placeable reports whether a bid requiring the position can still be placed.
+func (s *podSearch) placeable(pos int) bool {
+	first, last, either := s.positions[0], s.positions[1], s.positions[2]
+	switch pos {
+	case 1:
+		first++
+	case -1:
+		last++
+	case 2:
+		either++
+	}
+	return first <= 1 && last <= 1 && first+last+either <= 2
+}
+
+// separated reports whether the bid shares no category or advertiser domain with the selected bids.
+func (s *podSearch) separated(bid *Bid) bool {
+	a := s.assembler
+	if !a.SeparateCategories && !a.SeparateDomains {
+		return true
+	}
+	for i, sel := range s.selected {
+		if !sel {
+			continue
+		}
+		other := s.candidates[i].bid
+		if a.SeparateCategories {
+			for _, c := range bid.Categories {
+				for _, oc := range other.Categories {
+					if c == oc {
+						return false
+					}
+				}
+			}
+		}
+		if a.SeparateDomains {
+			for _, d := range bid.AdvDomains {
+				for _, od := range other.AdvDomains {
+					if strings.EqualFold(d, od) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// pod orders the best selection: bids for the first slot, then the others by impression and
+// price, then bids for the last slot.
+func (s *podSearch) pod() *Pod {
+	var first, last *Bid
+	var either, middle []podCandidate
+	for i, sel := range s.best {
+		if !sel {
+			continue
+		}
+		c := s.candidates[i]
+		switch c.pos {
+		case 1:
+			first = c.bid
+		case -1:
+			last = c.bid
+		case 2:
+			either = append(either, c)
+		default:
+			middle = append(middle, c)
+		}
+	}
+	for _, c := range either {
+		if first == nil {
+			first = c.bid
+		} else {
+			last = c.bid
+		}
+	}
+	sort.SliceStable(middle, func(i, j int) bool { return middle[i].slot < middle[j].slot })
+
+	pod := new(Pod)
+	if first != nil {
+		pod.Bids = append(pod.Bids, first)
+	}
+	for _, c := range middle {
+		pod.Bids = append(pod.Bids, c.bid)
+	}
+	if last != nil {
+		pod.Bids = append(pod.Bids, last)
+	}
+	for _, bid := range pod.Bids {
+		pod.Duration += bid.Duration
+		pod.Price += bid.Price
+	}
+	pod.Truncated = s.nodes >= podSearchLimit
+	return pod
+}
+
+// --------------------------------------------------------------------
+
+// Pod expansion errors
+var (
+	ErrPodNotDynamic = errors.New("openrtb: impression does not describe a dynamic ad pod")
+	ErrPodUnbounded  = errors.New("openrtb: ad pod durations are not bounded")
+	ErrPodMixed      = errors.New("openrtb: impressions belong to different ad pods")
+)
+
+// maxPodSlots bounds the number of impressions ExpandPod creates for a pod.
+const maxPodSlots = 100
+
+// ExpandPod converts an impression describing a dynamic ad pod, with PodDur and optionally
+// MaxSeq, into the impressions of an equivalent structured pod, one per slot. Slot IDs are the
+// impression ID suffixed with "-" and the slot number, starting at 1. Slots share the PoDid
+// of the impression, or one derived from its ID if it has none.
+//
+// The number of slots is the number of shortest ads fitting the pod duration, limited by
+// MaxSeq, pods with more than 100 slots are rejected with ErrPodUnbounded. As structured pods
+// cannot limit the total duration, slots allowing longer ads are assigned from the first slot
+// on while their total fits PodDur. Each slot keeps the required durations (RqdDurs) it allows
+// and MinCPMPerSec, its BidFloor is raised to the price of its shortest ad at MinCPMPerSec.
+// Slot guarantees (SlotInPod) move to the first and last slots.
+func ExpandPod(imp *Impression) ([]Impression, error) {
+	v := imp.Video
+	if v == nil || v.PodDur <= 0 {
+		return nil, ErrPodNotDynamic
+	}
+
+	var durs []int
+	for _, d := range v.RqdDurs {
+		if d > 0 && d <= v.PodDur {
+			durs = append(durs, d)
+		}
+	}
+	sort.Ints(durs)
+
+	shortest, longest := v.MinDuration, v.MaxDuration
+	if len(v.RqdDurs) != 0 {
+		if len(durs) == 0 {
+			return nil, ErrPodUnbounded
+		}
+		shortest, longest = durs[0], durs[len(durs)-1]
+	}
+	if shortest <= 0 {
+		if v.MaxSeq <= 0 {
+			return nil, ErrPodUnbounded
+		}
+		shortest = 1
+	}
+	if longest <= 0 || longest > v.PodDur {
+		longest = v.PodDur
+	}
+	if shortest > longest {
+		return nil, ErrPodUnbounded
+	}
+
+	n := v.PodDur / shortest
+	if v.MaxSeq > 0 && v.MaxSeq < n {
+		n = v.MaxSeq
+	}
+	if n > maxPodSlots {
+		return nil, ErrPodUnbounded
+	}
+	budget := v.PodDur - n*shortest // Seconds available for ads longer than the shortest
+
+	podID := v.PoDid
+	if podID == 0 {
+		podID = derivedPodID(imp.ID)
+	}
+
+	slots := make([]Impression, n)
+	for k := range slots {
+		slot := &slots[k]
+		imp.copyTo(slot)
+		slot.ID = imp.ID + "-" + strconv.Itoa(k+1)
+
+		sv := slot.Video
+		sv.PodDur, sv.MaxSeq, sv.SlotInPod, sv.PoDid = 0, 0, 0, podID
+		if len(durs) != 0 {
+			i := len(durs) - 1
+			for durs[i]-shortest > budget {
+				i--
+			}
+			sv.RqdDurs = append(sv.RqdDurs[:0], durs[:i+1]...)
+			budget -= durs[i] - shortest
+		} else {
+			maxDur := longest
+			if maxDur-shortest > budget {
+				maxDur = shortest + budget
+			}
+			sv.MaxDuration = maxDur
+			budget -= maxDur - shortest
+		}
+
+		if floor := minCPMFloor(v.MinCPMPerSec, shortest); floor > slot.BidFloor {
+			slot.BidFloor = floor
+		}
+	}
+
+	if v.SlotInPod == 1 || v.SlotInPod == 2 {
+		slots[0].Video.SlotInPod = 1
+	}
+	if v.SlotInPod == -1 || v.SlotInPod == 2 {
+		if n == 1 {
+			slots[0].Video.SlotInPod = v.SlotInPod
+		} else {
+			slots[n-1].Video.SlotInPod = -1
+		}
+	}
+	return slots, nil
+}
+
+// CollapsePod converts the impressions of a structured ad pod into an impression describing
+// the equivalent dynamic pod, the reverse of ExpandPod. PodDur is the sum of the longest ads
+// of the slots, MaxSeq their number, and RqdDurs the union of their required durations. The
+// floors are the lowest of the slots, so a BidFloor raised by ExpandPod to the MinCPMPerSec
+// floor is not restored. A PoDid derived by ExpandPod is removed.
+func CollapsePod(imps []Impression) (*Impression, error) {
+	if len(imps) == 0 {
+		return nil, ErrPodNoImpressions
+	}
+
+	out := new(Impression)
+	imps[0].copyTo(out)
+	if out.Video == nil {
+		return nil, ErrPodNoVideo
+	}
+	if id := strings.TrimSuffix(out.ID, "-1"); id != out.ID && id != "" {
+		out.ID = id
+	}
+
+	v := out.Video
+	v.PodDur, v.MaxSeq, v.SlotInPod, v.RqdDurs = 0, len(imps), 0, nil
+	for i := range imps {
+		sv := imps[i].Video
+		if sv == nil {
+			return nil, ErrPodNoVideo
+		}
+		if sv.PoDid != v.PoDid {
+			return nil, ErrPodMixed
+		}
+
+		longest := sv.MaxDuration
+		if len(sv.RqdDurs) != 0 {
+			longest = 0
+		}
+		for _, d := range sv.RqdDurs {
+			if d > longest {
+				longest = d
+			}
+			if !containsInt(v.RqdDurs, d) {
+				v.RqdDurs = append(v.RqdDurs, d)
+			}
+		}
+		if longest <= 0 {
+			return nil, ErrPodUnbounded
+		}
+		v.PodDur += longest
+
+		if sv.MinDuration < v.MinDuration {
+			v.MinDuration = sv.MinDuration
+		}
+		if sv.MaxDuration > v.MaxDuration {
+			v.MaxDuration = sv.MaxDuration
+		}
+		if sv.MinCPMPerSec < v.MinCPMPerSec {
+			v.MinCPMPerSec = sv.MinCPMPerSec
+		}
+		if imps[i].BidFloor < out.BidFloor {
+			out.BidFloor = imps[i].BidFloor
+		}
+	}
+	if v.PoDid == derivedPodID(out.ID) {
+		v.PoDid = 0
+	}
+	sort.Ints(v.RqdDurs)
+	if len(v.RqdDurs) != 0 {
+		v.MinDuration, v.MaxDuration = 0, 0
+	}
+
+	first, last := imps[0].Video.SlotInPod, imps[len(imps)-1].Video.SlotInPod
+	switch {
+	case first == 1 && last == -1, first == 2:
+		v.SlotInPod = 2
+	case first == 1:
+		v.SlotInPod = 1
+	case last == -1:
+		v.SlotInPod = -1
+	}
+	return out, nil
+}
+
+// minCPMFloor returns the price of an ad of the duration at the CPM per second. The rate is
+// multiplied as float32 and converted by its decimal value, so that 0.1 for 15s yields 1.5.
+func minCPMFloor(rate float32, dur int) float64 {
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(rate*float32(dur)), 'g', -1, 32), 64)
+	return f
+}
+
+// derivedPodID returns a positive pod ID for the impression ID.
+func derivedPodID(impID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(impID))
+	if id := int(h.Sum32() & 0x7fffffff); id != 0 {
+		return id
+	}
+	return 1
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,257 @@
+package openrtb
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func assertPodBids(t *testing.T, pod *Pod, ids ...string) {
+	t.Helper()
+	var got []string
+	for _, bid := range pod.Bids {
+		got = append(got, bid.ID)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("expected bids %v, got %v", ids, got)
+	}
+	for i := range ids {
+		if got[i] != ids[i] {
+			t.Fatalf("expected bids %v, got %v", ids, got)
+		}
+	}
+}
+
+func TestPodAssembler_Assemble(t *testing.T) {
+	imps := []Impression{{ID: "1", Video: &Video{PodDur: 60, MaxSeq: 3}}}
+	bids := []*Bid{
+		{ID: "a", ImpID: "1", Price: 5, Duration: 40},
+		{ID: "b", ImpID: "1", Price: 3, Duration: 30},
+		{ID: "c", ImpID: "1", Price: 4, Duration: 30},
+		{ID: "d", ImpID: "1", Price: 4, Duration: 30},
+		{ID: "e", ImpID: "2", Price: 9, Duration: 10},
+		{ID: "f", ImpID: "1", Price: 9},
+	}
+	pod, err := new(PodAssembler).Assemble(imps, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a greedy selection would take a and fill the rest with c
+	assertPodBids(t, pod, "c", "d")
+	if pod.Duration != 60 || pod.Price != 8 || pod.Truncated {
+		t.Fatalf("unexpected pod %+v", pod)
+	}
+
+	if _, err := new(PodAssembler).Assemble(nil, bids); err != ErrPodNoImpressions {
+		t.Fatalf("expected %v, got %v", ErrPodNoImpressions, err)
+	}
+	if _, err := new(PodAssembler).Assemble([]Impression{{ID: "1"}}, bids); err != ErrPodNoVideo {
+		t.Fatalf("expected %v, got %v", ErrPodNoVideo, err)
+	}
+}
+
+func TestPodAssembler_Assemble_rqdDurs(t *testing.T) {
+	imps := []Impression{{ID: "1", Video: &Video{PodDur: 60, RqdDurs: []int{15, 30}, MinDuration: 5, MaxDuration: 60}}}
+	bids := []*Bid{
+		{ID: "a", ImpID: "1", Price: 9, Duration: 20},
+		{ID: "b", ImpID: "1", Price: 2, Duration: 15},
+		{ID: "c", ImpID: "1", Price: 3, Duration: 30},
+		{ID: "d", ImpID: "1", Price: 9, Duration: 45},
+	}
+	pod, err := new(PodAssembler).Assemble(imps, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPodBids(t, pod, "c", "b")
+}
+
+func TestPodAssembler_Assemble_slotInPod(t *testing.T) {
+	imps := []Impression{
+		{ID: "1", Video: &Video{PoDid: 1, SlotInPod: -1}},
+		{ID: "2", Video: &Video{PoDid: 1}},
+		{ID: "3", Video: &Video{PoDid: 1, SlotInPod: 1}},
+	}
+	bids := []*Bid{
+		{ID: "a", ImpID: "1", Price: 3, Duration: 15},
+		{ID: "b", ImpID: "2", Price: 2, Duration: 15},
+		{ID: "c", ImpID: "3", Price: 1, Duration: 15},
+	}
+	pod, err := new(PodAssembler).Assemble(imps, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPodBids(t, pod, "c", "b", "a")
+
+	// bids for a slot the impression does not guarantee are not eligible
+	bids = []*Bid{
+		{ID: "a", ImpID: "1", Price: 9, Duration: 15, SlotInPod: 1},
+		{ID: "b", ImpID: "2", Price: 9, Duration: 15, SlotInPod: -1},
+		{ID: "c", ImpID: "2", Price: 8, Duration: 15, SlotInPod: 2},
+		{ID: "d", ImpID: "3", Price: 1, Duration: 15, SlotInPod: 1},
+		{ID: "e", ImpID: "1", Price: 1, Duration: 15, SlotInPod: -1},
+	}
+	pod, err = new(PodAssembler).Assemble(imps, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPodBids(t, pod, "d", "e")
+
+	// a dynamic pod guaranteeing both ends places at most one bid at each
+	imps = []Impression{{ID: "1", Video: &Video{PodDur: 120, SlotInPod: 2}}}
+	bids = []*Bid{
+		{ID: "a", ImpID: "1", Price: 4, Duration: 15, SlotInPod: -1},
+		{ID: "b", ImpID: "1", Price: 3, Duration: 15, SlotInPod: -1},
+		{ID: "c", ImpID: "1", Price: 2, Duration: 15},
+		{ID: "d", ImpID: "1", Price: 1, Duration: 15, SlotInPod: 1},
+	}
+	pod, err = new(PodAssembler).Assemble(imps, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPodBids(t, pod, "d", "c", "a")
+}
+
+func TestPodAssembler_Assemble_minCPMPerSec(t *testing.T) {
+	imps := []Impression{{ID: "1", BidFloor: 1, Video: &Video{PodDur: 90, MinCPMPerSec: 0.1}}}
+	bids := []*Bid{
+		{ID: "a", ImpID: "1", Price: 2.9, Duration: 30},
+		{ID: "b", ImpID: "1", Price: 3.01, Duration: 30},
+		{ID: "c", ImpID: "1", Price: 0.9, Duration: 5},
+		{ID: "d", ImpID: "1", Price: 1, Duration: 5},
+	}
+	pod, err := new(PodAssembler).Assemble(imps, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPodBids(t, pod, "b", "d")
+}
+
+func TestPodAssembler_Assemble_separation(t *testing.T) {
+	imps := []Impression{{ID: "1", Video: &Video{PodDur: 90}}}
+	bids := []*Bid{
+		{ID: "a", ImpID: "1", Price: 5, Duration: 30, Categories: []ContentCategory{"IAB1"}, AdvDomains: []string{"a.com"}},
+		{ID: "b", ImpID: "1", Price: 4, Duration: 30, Categories: []ContentCategory{"IAB2", "IAB1"}, AdvDomains: []string{"b.com"}},
+		{ID: "c", ImpID: "1", Price: 3, Duration: 30, Categories: []ContentCategory{"IAB3"}, AdvDomains: []string{"A.com"}},
+	}
+
+	for _, tc := range []struct {
+		assembler PodAssembler
+		ids       []string
+	}{
+		{PodAssembler{}, []string{"a", "b", "c"}},
+		{PodAssembler{SeparateCategories: true}, []string{"a", "c"}},
+		{PodAssembler{SeparateDomains: true}, []string{"a", "b"}},
+		{PodAssembler{SeparateCategories: true, SeparateDomains: true}, []string{"b", "c"}},
+	} {
+		pod, err := tc.assembler.Assemble(imps, bids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertPodBids(t, pod, tc.ids...)
+	}
+}
+
+func TestPodAssembler_Assemble_truncated(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	imps := []Impression{{ID: "1", Video: &Video{PodDur: 600}}}
+	var bids []*Bid
+	for i := 0; i < 200; i++ {
+		dur := 5 + rng.Intn(60)
+		bids = append(bids, &Bid{ID: strconv.Itoa(i), ImpID: "1", Duration: dur, Price: float64(dur) * (1 + rng.Float64()/10)})
+	}
+	pod, err := new(PodAssembler).Assemble(imps, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pod.Truncated || len(pod.Bids) == 0 || pod.Duration > 600 {
+		t.Fatalf("unexpected pod %+v", pod)
+	}
+}
+
+func TestExpandPod(t *testing.T) {
+	imp := &Impression{ID: "p", BidFloor: 1, Video: &Video{
+		MIMEs: []string{"video/mp4"}, PodDur: 120, MaxSeq: 6, RqdDurs: []int{15, 30}, SlotInPod: 2, MinCPMPerSec: 0.1,
+	}}
+	slots, err := ExpandPod(imp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 6 {
+		t.Fatalf("expected 6 slots, got %d", len(slots))
+	}
+	for k, slot := range slots {
+		v := slot.Video
+		durs := []int{15}
+		if k < 2 {
+			durs = []int{15, 30}
+		}
+		slotInPod := 0
+		switch k {
+		case 0:
+			slotInPod = 1
+		case 5:
+			slotInPod = -1
+		}
+		if slot.ID != "p-"+strconv.Itoa(k+1) || slot.BidFloor != 1.5 || v.PoDid == 0 || v.PoDid != slots[0].Video.PoDid ||
+			!reflect.DeepEqual(v.RqdDurs, durs) || v.SlotInPod != slotInPod || v.PodDur != 0 || v.MaxSeq != 0 {
+			t.Fatalf("unexpected slot %d: %+v %+v", k, slot, v)
+		}
+	}
+	if imp.Video.PoDid != 0 {
+		t.Fatal("expected source impression to be unchanged")
+	}
+
+	if _, err := ExpandPod(&Impression{ID: "1", Video: &Video{MaxSeq: 2}}); err != ErrPodNotDynamic {
+		t.Fatalf("expected %v, got %v", ErrPodNotDynamic, err)
+	}
+	for _, v := range []*Video{
+		{PodDur: 60},
+		{PodDur: 100000000, MinDuration: 1},
+		{PodDur: 1000, MinDuration: 5},
+		{PodDur: 1000, MaxSeq: 101},
+	} {
+		if _, err := ExpandPod(&Impression{ID: "1", Video: v}); err != ErrPodUnbounded {
+			t.Fatalf("%+v: expected %v, got %v", v, ErrPodUnbounded, err)
+		}
+	}
+	if slots, err := ExpandPod(&Impression{ID: "1", Video: &Video{PodDur: 100000000, MinDuration: 1, MaxSeq: 100}}); err != nil || len(slots) != 100 {
+		t.Fatalf("expected 100 slots, got %d, %v", len(slots), err)
+	}
+}
+
+func TestCollapsePod(t *testing.T) {
+	for _, floor := range []float64{1, 2} {
+		imp := &Impression{ID: "p", BidFloor: floor, Video: &Video{
+			MIMEs: []string{"video/mp4"}, PodDur: 120, MaxSeq: 6, RqdDurs: []int{15, 30}, SlotInPod: 2, MinCPMPerSec: 0.1,
+		}}
+		slots, err := ExpandPod(imp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := CollapsePod(slots)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// a floor below the MinCPMPerSec floor of the shortest ad is raised to it
+		exp := imp.Clone()
+		if exp.BidFloor < 1.5 {
+			exp.BidFloor = 1.5
+		}
+		expJSON, err := exp.AppendJSON(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotJSON, err := got.AppendJSON(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertJSONEqual(t, expJSON, gotJSON)
+	}
+
+	slots := []Impression{{ID: "1", Video: &Video{PoDid: 1, MaxDuration: 30}}, {ID: "2", Video: &Video{PoDid: 2, MaxDuration: 30}}}
+	if _, err := CollapsePod(slots); err != ErrPodMixed {
+		t.Fatalf("expected %v, got %v", ErrPodMixed, err)
+	}
+}
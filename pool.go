@@ -0,0 +1,67 @@
+package openrtb
+
+import (
+	"sync"
+)
+
+// RequestDecoder decodes JSON bid requests into a reusable BidRequest. The memory of nested
+// objects and slices is recycled between calls and recurring short strings are interned,
+// so that steady-state decoding allocates little more than the unique string values.
+// A decoder must not be used concurrently.
+//
+// Reset clears the recycled objects, so that a reset or released decoder holds no data of the
+// previous request except for the intern table, which keeps a bounded number of short string
+// values, but no objects, to be shared with later requests.
+type RequestDecoder struct {
+	req     BidRequest
+	alloc   jsonAlloc
+	scratch []byte
+	intern  map[string]string
+}
+
+// Decode decodes a request from JSON data. The returned request and all of its nested objects
+// and slices are owned by the decoder and only valid until the next call to Decode or Reset.
+func (d *RequestDecoder) Decode(data []byte) (*BidRequest, error) {
+	d.Reset()
+	if d.intern == nil {
+		d.intern = make(map[string]string)
+	}
+
+	r := jsonReader{data: data, scratch: d.scratch, alloc: &d.alloc, intern: d.intern}
+	d.req.decodeJSON(&r)
+	d.scratch, d.intern = r.scratch, r.intern
+	if err := r.finish(); err != nil {
+		return nil, err
+	}
+	return &d.req, nil
+}
+
+// Reset clears the last decoded request, it must no longer be referenced.
+func (d *RequestDecoder) Reset() {
+	d.req.Reset()
+	d.alloc.release()
+	scratch := d.scratch[:cap(d.scratch)]
+	for i := range scratch {
+		scratch[i] = 0
+	}
+	d.scratch = d.scratch[:0]
+}
+
+var requestDecoderPool = sync.Pool{
+	New: func() interface{} { return new(RequestDecoder) },
+}
+
+// AcquireRequestDecoder returns a decoder from a pool.
+func AcquireRequestDecoder() *RequestDecoder {
+	return requestDecoderPool.Get().(*RequestDecoder)
+}
+
+// ReleaseRequestDecoder resets the decoder and returns it to the pool. Neither the decoder
+// nor the requests decoded by it may be used after it was released.
+func ReleaseRequestDecoder(d *RequestDecoder) {
+	if d == nil {
+		return
+	}
+	d.Reset()
+	requestDecoderPool.Put(d)
+}
@@ -0,0 +1,136 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRequestDecoder_reuse(t *testing.T) {
+	d := AcquireRequestDecoder()
+	if _, err := d.Decode([]byte(goldenRequest)); err != nil {
+		t.Fatal(err)
+	}
+	ReleaseRequestDecoder(d)
+	assertZeroMemory(t, reflect.ValueOf(d).Elem().FieldByName("req"), "req")
+	assertZeroMemory(t, reflect.ValueOf(d).Elem().FieldByName("alloc"), "alloc")
+	assertZeroMemory(t, reflect.ValueOf(d).Elem().FieldByName("scratch"), "scratch")
+
+	d = AcquireRequestDecoder()
+	defer ReleaseRequestDecoder(d)
+
+	// decode requests of random shapes with the same decoder, each must be equal to a
+	// request decoded by a fresh decoder
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 500; n++ {
+		src := new(BidRequest)
+		fill(rng, reflect.ValueOf(src).Elem(), 0)
+		data, err := json.Marshal(src)
+		if err != nil {
+			continue // invalid raw JSON in Ext
+		}
+
+		exp := new(BidRequest)
+		if err := exp.DecodeJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		got, err := d.Decode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Fatalf("reused decoder differs for\n%s", data)
+		}
+	}
+}
+
+// assertZeroMemory checks that v holds no data: scalars are zero, recycled objects are reset
+// and slices are zero up to their capacity.
+func assertZeroMemory(t *testing.T, v reflect.Value, path string) {
+	t.Helper()
+
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() != 0 {
+			t.Errorf("%s holds %q", path, v.String())
+		}
+	case reflect.Bool:
+		if v.Bool() {
+			t.Errorf("%s is set", path)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() != 0 {
+			t.Errorf("%s holds %d", path, v.Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() != 0 {
+			t.Errorf("%s holds %d", path, v.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		if v.Float() != 0 {
+			t.Errorf("%s holds %v", path, v.Float())
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			assertZeroMemory(t, v.Elem(), path)
+		}
+	case reflect.Slice:
+		v = v.Slice(0, v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			assertZeroMemory(t, v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			assertZeroMemory(t, v.Field(i), path+"."+v.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestRequestDecoder_error(t *testing.T) {
+	var d RequestDecoder
+	if _, err := d.Decode([]byte(goldenRequest)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Decode([]byte(`{"id":"1","imp":[`)); err == nil {
+		t.Fatal("expected error")
+	}
+	req, err := d.Decode([]byte(`{"id":"2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(req, &BidRequest{ID: "2"}) {
+		t.Fatalf("unexpected request %+v", req)
+	}
+}
+
+func BenchmarkRequestDecoder_Decode(b *testing.B) {
+	data := []byte(goldenRequest)
+	d := AcquireRequestDecoder()
+	defer ReleaseRequestDecoder(d)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Decode(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRequestDecoder_pool(b *testing.B) {
+	data := []byte(goldenRequest)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			d := AcquireRequestDecoder()
+			if _, err := d.Decode(data); err != nil {
+				b.Fatal(err)
+			}
+			ReleaseRequestDecoder(d)
+		}
+	})
+}
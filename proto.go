@@ -0,0 +1,434 @@
+package openrtb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// Protobuf errors
+var (
+	ErrProtoTruncated = errors.New("openrtb: truncated protobuf message")
+	ErrProtoWireType  = errors.New("openrtb: unexpected protobuf wire type")
+)
+
+// MarshalProto encodes the request in the protobuf wire format of the OpenRTB 2.x
+// openrtb.proto schema, see protoFields for the mapping of attributes to field numbers.
+// Ext attributes are written as raw JSON bytes in field 100, which the standard schema
+// reserves for extensions, see protoExtField.
+func (req *BidRequest) MarshalProto() ([]byte, error) {
+	return marshalProto(reflect.ValueOf(req).Elem())
+}
+
+// UnmarshalProto decodes a request from the protobuf wire format.
+func (req *BidRequest) UnmarshalProto(data []byte) error {
+	return unmarshalProto(data, reflect.ValueOf(req).Elem())
+}
+
+// MarshalProto encodes the response in the protobuf wire format of the OpenRTB 2.x
+// openrtb.proto schema, see protoFields for the mapping of attributes to field numbers.
+// Ext attributes are written as raw JSON bytes in field 100, see protoExtField.
+func (res *BidResponse) MarshalProto() ([]byte, error) {
+	return marshalProto(reflect.ValueOf(res).Elem())
+}
+
+// UnmarshalProto decodes a response from the protobuf wire format.
+func (res *BidResponse) UnmarshalProto(data []byte) error {
+	return unmarshalProto(data, reflect.ValueOf(res).Elem())
+}
+
+// protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+type protoKind int
+
+const (
+	protoKindString protoKind = iota
+	protoKindVarint
+	protoKindDouble
+	protoKindFloat
+	protoKindMessage
+	protoKindRaw // json.RawMessage, encoded as bytes
+)
+
+type protoField struct {
+	num      int
+	index    []int
+	kind     protoKind
+	repeated bool
+	ptr      bool // pointer to a scalar or message
+}
+
+type protoMessage struct {
+	fields []protoField
+	byNum  map[int]*protoField
+}
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+	protoCache     sync.Map // map[reflect.Type]*protoMessage
+)
+
+func protoMessageOf(t reflect.Type) *protoMessage {
+	if m, ok := protoCache.Load(t); ok {
+		return m.(*protoMessage)
+	}
+
+	nums, ok := protoFields[t]
+	if !ok {
+		panic("openrtb: no protobuf mapping for " + t.String())
+	}
+
+	m := &protoMessage{byNum: make(map[int]*protoField)}
+	collectProtoFields(t, nil, nums, m)
+	for i := range m.fields {
+		m.byNum[m.fields[i].num] = &m.fields[i]
+	}
+
+	protoCache.Store(t, m)
+	return m
+}
+
+func collectProtoFields(t reflect.Type, index []int, nums map[string]int, m *protoMessage) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		path := append(append([]int{}, index...), i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			collectProtoFields(sf.Type, path, nums, m)
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		num, ok := nums[sf.Name]
+		if !ok {
+			panic("openrtb: no protobuf field number for " + t.String() + "." + sf.Name)
+		}
+
+		f := protoField{num: num, index: path}
+		ft := sf.Type
+		if ft == rawMessageType {
+			f.kind = protoKindRaw
+			m.fields = append(m.fields, f)
+			continue
+		}
+		if ft.Kind() == reflect.Slice {
+			f.repeated = true
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Ptr {
+			f.ptr = true
+			ft = ft.Elem()
+		}
+
+		switch ft.Kind() {
+		case reflect.String:
+			f.kind = protoKindString
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			f.kind = protoKindVarint
+		case reflect.Float64:
+			f.kind = protoKindDouble
+		case reflect.Float32:
+			f.kind = protoKindFloat
+		case reflect.Struct:
+			f.kind = protoKindMessage
+		default:
+			panic("openrtb: unsupported protobuf field type " + sf.Type.String())
+		}
+		m.fields = append(m.fields, f)
+	}
+}
+
+// --------------------------------------------------------------------
+
+func marshalProto(v reflect.Value) ([]byte, error) {
+	return appendProtoMessage(nil, v), nil
+}
+
+func appendProtoMessage(b []byte, v reflect.Value) []byte {
+	m := protoMessageOf(v.Type())
+	for i := range m.fields {
+		f := &m.fields[i]
+		fv := v.FieldByIndex(f.index)
+
+		switch {
+		case f.kind == protoKindRaw:
+			if fv.Len() != 0 {
+				b = appendProtoTag(b, f.num, wireBytes)
+				b = appendProtoBytes(b, fv.Bytes())
+			}
+		case f.repeated:
+			for j := 0; j < fv.Len(); j++ {
+				ev := fv.Index(j)
+				if f.ptr {
+					if ev.IsNil() {
+						continue
+					}
+					ev = ev.Elem()
+				}
+				b = appendProtoValue(b, f, ev)
+			}
+		case f.ptr:
+			if !fv.IsNil() {
+				b = appendProtoValue(b, f, fv.Elem())
+			}
+		case !fv.IsZero():
+			b = appendProtoValue(b, f, fv)
+		}
+	}
+	return b
+}
+
+func appendProtoValue(b []byte, f *protoField, v reflect.Value) []byte {
+	switch f.kind {
+	case protoKindString:
+		b = appendProtoTag(b, f.num, wireBytes)
+		b = appendProtoBytes(b, []byte(v.String()))
+	case protoKindVarint:
+		b = appendProtoTag(b, f.num, wireVarint)
+		if v.CanInt() {
+			b = appendVarint(b, uint64(v.Int()))
+		} else {
+			b = appendVarint(b, v.Uint())
+		}
+	case protoKindDouble:
+		b = appendProtoTag(b, f.num, wireFixed64)
+		b = appendFixed(b, math.Float64bits(v.Float()), 8)
+	case protoKindFloat:
+		b = appendProtoTag(b, f.num, wireFixed32)
+		b = appendFixed(b, uint64(math.Float32bits(float32(v.Float()))), 4)
+	case protoKindMessage:
+		b = appendProtoTag(b, f.num, wireBytes)
+		b = appendProtoBytes(b, appendProtoMessage(nil, v))
+	}
+	return b
+}
+
+func appendProtoTag(b []byte, num, wire int) []byte {
+	return appendVarint(b, uint64(num)<<3|uint64(wire))
+}
+
+func appendProtoBytes(b, p []byte) []byte {
+	b = appendVarint(b, uint64(len(p)))
+	return append(b, p...)
+}
+
+func appendFixed(b []byte, v uint64, size int) []byte {
+	for i := 0; i < size; i++ {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// --------------------------------------------------------------------
+
+func unmarshalProto(data []byte, v reflect.Value) error {
+	return decodeProtoMessage(data, v)
+}
+
+func decodeProtoMessage(data []byte, v reflect.Value) error {
+	m := protoMessageOf(v.Type())
+	for len(data) != 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrProtoTruncated
+		}
+		data = data[n:]
+
+		num, wire := int(tag>>3), int(tag&7)
+		f, ok := m.byNum[num]
+		if !ok {
+			if n = skipProtoField(data, wire); n < 0 {
+				return ErrProtoTruncated
+			}
+			data = data[n:]
+			continue
+		}
+
+		var err error
+		if data, err = decodeProtoField(data, wire, f, v.FieldByIndex(f.index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeProtoField(data []byte, wire int, f *protoField, fv reflect.Value) ([]byte, error) {
+	// packed repeated scalars
+	if f.repeated && wire == wireBytes && (f.kind == protoKindVarint || f.kind == protoKindDouble || f.kind == protoKindFloat) {
+		p, rest, err := readProtoBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		for len(p) != 0 {
+			ev := reflect.New(fv.Type().Elem()).Elem()
+			if p, err = decodeProtoScalar(p, f.kind, scalarWireType(f.kind), ev); err != nil {
+				return nil, err
+			}
+			fv.Set(reflect.Append(fv, ev))
+		}
+		return rest, nil
+	}
+
+	if f.kind == protoKindRaw {
+		if wire != wireBytes {
+			return nil, ErrProtoWireType
+		}
+		p, rest, err := readProtoBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		fv.SetBytes(append(json.RawMessage{}, p...))
+		return rest, nil
+	}
+
+	t := fv.Type()
+	if f.repeated {
+		t = t.Elem()
+	}
+	if f.ptr {
+		t = t.Elem()
+	}
+
+	ev := reflect.New(t).Elem()
+	if !f.repeated && !f.ptr {
+		ev = fv
+	} else if !f.repeated && !fv.IsNil() {
+		ev = fv.Elem() // merge into existing message
+	}
+
+	var err error
+	if f.kind == protoKindMessage {
+		if wire != wireBytes {
+			return nil, ErrProtoWireType
+		}
+		var p []byte
+		if p, data, err = readProtoBytes(data); err != nil {
+			return nil, err
+		}
+		if err = decodeProtoMessage(p, ev); err != nil {
+			return nil, err
+		}
+	} else if data, err = decodeProtoScalar(data, f.kind, wire, ev); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case f.repeated && f.ptr:
+		fv.Set(reflect.Append(fv, ev.Addr()))
+	case f.repeated:
+		fv.Set(reflect.Append(fv, ev))
+	case f.ptr && fv.IsNil():
+		fv.Set(ev.Addr())
+	}
+	return data, nil
+}
+
+func scalarWireType(kind protoKind) int {
+	switch kind {
+	case protoKindDouble:
+		return wireFixed64
+	case protoKindFloat:
+		return wireFixed32
+	case protoKindVarint:
+		return wireVarint
+	}
+	return wireBytes
+}
+
+func decodeProtoScalar(data []byte, kind protoKind, wire int, v reflect.Value) ([]byte, error) {
+	if wire != scalarWireType(kind) {
+		return nil, ErrProtoWireType
+	}
+
+	switch kind {
+	case protoKindString:
+		p, rest, err := readProtoBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		v.SetString(string(p))
+		return rest, nil
+	case protoKindVarint:
+		u, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, ErrProtoTruncated
+		}
+		if v.CanInt() {
+			v.SetInt(int64(u))
+		} else {
+			v.SetUint(u)
+		}
+		return data[n:], nil
+	case protoKindDouble:
+		if len(data) < 8 {
+			return nil, ErrProtoTruncated
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		return data[8:], nil
+	case protoKindFloat:
+		if len(data) < 4 {
+			return nil, ErrProtoTruncated
+		}
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+		return data[4:], nil
+	}
+	return nil, ErrProtoWireType
+}
+
+func readProtoBytes(data []byte) ([]byte, []byte, error) {
+	l, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < l {
+		return nil, nil, ErrProtoTruncated
+	}
+	end := n + int(l)
+	return data[n:end], data[end:], nil
+}
+
+// skipProtoField returns the length of the field value, or -1 if it is truncated or invalid.
+func skipProtoField(data []byte, wire int) int {
+	switch wire {
+	case wireVarint:
+		_, n := binary.Uvarint(data)
+		if n <= 0 {
+			return -1
+		}
+		return n
+	case wireFixed64:
+		if len(data) < 8 {
+			return -1
+		}
+		return 8
+	case wireFixed32:
+		if len(data) < 4 {
+			return -1
+		}
+		return 4
+	case wireBytes:
+		_, rest, err := readProtoBytes(data)
+		if err != nil {
+			return -1
+		}
+		return len(data) - len(rest)
+	}
+	return -1
+}
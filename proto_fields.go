@@ -0,0 +1,156 @@
+package openrtb
+
+import (
+	"reflect"
+)
+
+// protoExtField is the field number carrying the Ext attribute of each object, written as the
+// raw JSON bytes of the ext object. This is not compatible with the standard openrtb.proto
+// schema, which reserves the numbers 100 to 9999 for proto2 extensions of each message:
+// decoders generated from it keep field 100 as an unknown field, or fail if an extension with
+// another type is registered under that number. Peers exchanging Ext in protobuf must declare
+// it as "extend <Message> { optional bytes ext = 100; }" or drop it.
+const protoExtField = 100
+
+// protoFields maps the attributes of each object to the field numbers of the openrtb.proto schema.
+// Attributes that were added after the schema (e.g. by OpenRTB 2.6) use the next free numbers.
+// Enumerations and boolean flags are both encoded as varints, matching the wire format of proto enums and bools.
+var protoFields = map[reflect.Type]map[string]int{
+	reflect.TypeOf(BidRequest{}): {
+		"ID": 1, "Impressions": 2, "Site": 3, "App": 4, "Device": 5, "User": 6, "AuctionType": 7, "TMax": 8,
+		"Seats": 9, "AllImpressions": 10, "Currencies": 11, "BlockedCategories": 12, "BlockedAdvDomains": 13,
+		"Regulations": 14, "Test": 15, "BlockedApps": 16, "BlockedSeats": 17, "Languages": 18, "Source": 19,
+		"CategoryTaxonomies": 21, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Source{}): {
+		"FinalSaleDecision": 1, "TransactionID": 2, "PaymentChain": 3, "SupplyChain": 4, "Ext": protoExtField,
+	},
+	reflect.TypeOf(SupplyChain{}): {
+		"Complete": 1, "Node": 2, "Version": 3, "Ext": protoExtField,
+	},
+	reflect.TypeOf(SupplyChainNode{}): {
+		"ASI": 1, "SID": 2, "RequestId": 3, "Name": 4, "Domain": 5, "HP": 6, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Impression{}): {
+		"ID": 1, "Banner": 2, "Video": 3, "DisplayManager": 4, "DisplayManagerVersion": 5, "Interstitial": 6,
+		"TagID": 7, "BidFloor": 8, "BidFloorCurrency": 9, "IFrameBusters": 10, "PMP": 11, "Secure": 12,
+		"Native": 13, "Exp": 14, "Audio": 15, "ClickBrowser": 16, "Metric": 17, "RWDD": 18, "SSAI": 19,
+		"Ext": protoExtField,
+	},
+	reflect.TypeOf(Metric{}): {
+		"Type": 1, "Value": 2, "Vendor": 3, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Banner{}): {
+		"Width": 1, "Height": 2, "ID": 3, "Position": 4, "BlockedTypes": 5, "BlockedAttrs": 6, "MIMEs": 7,
+		"TopFrame": 8, "ExpDirs": 9, "APIs": 10, "Formats": 15, "VCM": 16, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Format{}): {
+		"Width": 1, "Height": 2, "WidthRatio": 3, "HeightRatio": 4, "WidthMin": 5, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Video{}): {
+		"MIMEs": 1, "Linearity": 2, "MinDuration": 3, "MaxDuration": 4, "Protocol": 5, "Width": 6, "Height": 7,
+		"StartDelay": 8, "Sequence": 9, "BlockedAttrs": 10, "MaxExtended": 11, "MinBitrate": 12, "MaxBitrate": 13,
+		"BoxingAllowed": 14, "PlaybackMethods": 15, "Delivery": 16, "Position": 17, "CompanionAds": 18, "APIs": 19,
+		"CompanionTypes": 20, "Protocols": 21, "Skip": 23, "SkipMin": 24, "SkipAfter": 25, "Placement": 26,
+		"MaxSeq": 28, "PodDur": 29, "RqdDurs": 30, "PoDid": 31, "PodSeq": 32, "SlotInPod": 33, "MinCPMPerSec": 34,
+		"Ext": protoExtField,
+	},
+	reflect.TypeOf(Audio{}): {
+		"MIMEs": 1, "MinDuration": 2, "MaxDuration": 3, "Protocols": 4, "StartDelay": 5, "Sequence": 6,
+		"BlockedAttrs": 7, "MaxExtended": 8, "MinBitrate": 9, "MaxBitrate": 10, "Delivery": 11, "CompanionAds": 12,
+		"APIs": 13, "CompanionTypes": 20, "MaxSequence": 21, "Feed": 22, "Stitched": 23, "VolumeNorm": 24,
+		"PodDur": 25, "RqdDurs": 26, "PoDid": 27, "PodSeq": 28, "SlotInPod": 29, "MinCPMPerSec": 30,
+		"Ext": protoExtField,
+	},
+	reflect.TypeOf(Native{}): {
+		"Request": 1, "Version": 2, "APIs": 3, "BlockedAttrs": 4, "Ext": protoExtField,
+	},
+	reflect.TypeOf(PMP{}): {
+		"Private": 1, "Deals": 2, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Deal{}): {
+		"ID": 1, "BidFloor": 2, "BidFloorCurrency": 3, "Seats": 4, "AdvDomains": 5, "AuctionType": 6,
+		"Ext": protoExtField,
+	},
+	reflect.TypeOf(Site{}): {
+		"ID": 1, "Name": 2, "Domain": 3, "Categories": 4, "SectionCategories": 5, "PageCategories": 6, "Page": 7,
+		"PrivacyPolicy": 8, "Referrer": 9, "Search": 10, "Publisher": 11, "Content": 12, "Keywords": 13,
+		"Mobile": 15, "CategoryTaxonomies": 16, "KeywordArray": 17, "Ext": protoExtField,
+	},
+	reflect.TypeOf(App{}): {
+		"ID": 1, "Name": 2, "Domain": 3, "Categories": 4, "SectionCategories": 5, "PageCategories": 6, "Version": 7,
+		"Bundle": 8, "PrivacyPolicy": 9, "Paid": 10, "Publisher": 11, "Content": 12, "Keywords": 13,
+		"StoreURL": 16, "CategoryTaxonomies": 17, "KeywordArray": 18, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Publisher{}): {
+		"ID": 1, "Name": 2, "Categories": 3, "Domain": 4, "CategoryTaxonomies": 5, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Producer{}): {
+		"ID": 1, "Name": 2, "Categories": 3, "Domain": 4, "CategoryTaxonomies": 5, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Content{}): {
+		"ID": 1, "Episode": 2, "Title": 3, "Series": 4, "Season": 5, "URL": 6, "Categories": 7, "Keywords": 9,
+		"ContentRating": 10, "UserRating": 11, "LiveStream": 13, "SourceRelationship": 14, "Producer": 15,
+		"Length": 16, "MediaRating": 17, "Embeddable": 18, "Language": 19, "Context": 20, "Artist": 21,
+		"Genre": 22, "Album": 23, "ISRC": 24, "ProductionQuality": 25, "Data": 26, "Network": 27, "Channel": 28,
+		"CategoryTaxonomies": 29, "KeywordArray": 30, "LangB": 31, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Network{}): {
+		"ID": 1, "Name": 2, "Domain": 3, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Channel{}): {
+		"ID": 1, "Name": 2, "Domain": 3, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Device{}): {
+		"DNT": 1, "UA": 2, "IP": 3, "Geo": 4, "IDSHA1": 5, "IDMD5": 6, "PIDSHA1": 7, "PIDMD5": 8, "IPv6": 9,
+		"Carrier": 10, "Language": 11, "Make": 12, "Model": 13, "OS": 14, "OSVersion": 15, "JS": 16,
+		"ConnType": 17, "DeviceType": 18, "FlashVersion": 19, "IFA": 20, "MacSHA1": 21, "MacMD5": 22, "LMT": 23,
+		"HWVersion": 24, "Width": 25, "Height": 26, "PPI": 27, "PixelRatio": 28, "GeoFetch": 29, "MCCMNC": 30,
+		"StructuredUserAgent": 31, "LangB": 32, "Ext": protoExtField,
+	},
+	reflect.TypeOf(UserAgent{}): {
+		"Browsers": 1, "PMPlatform": 2, "Mobile": 3, "Architecture": 4, "Bitness": 5, "Model": 6, "Source": 7,
+		"Ext": protoExtField,
+	},
+	reflect.TypeOf(BrandVersion{}): {
+		"Brand": 1, "Source": 2, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Geo{}): {
+		"Latitude": 1, "Longitude": 2, "Country": 3, "Region": 4, "RegionFIPS104": 5, "Metro": 6, "City": 7,
+		"ZIP": 8, "Type": 9, "UTCOffset": 10, "Accuracy": 11, "LastFix": 12, "IPService": 13,
+		"Ext": protoExtField,
+	},
+	reflect.TypeOf(User{}): {
+		"ID": 1, "BuyerUID": 2, "YearOfBirth": 3, "Gender": 4, "Keywords": 5, "CustomData": 6, "Geo": 7,
+		"Data": 8, "BuyerID": 9, "Consent": 10, "Eids": 11, "KeywordArray": 12, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Data{}): {
+		"ID": 1, "Name": 2, "Segment": 3, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Segment{}): {
+		"ID": 1, "Name": 2, "Value": 3, "Ext": protoExtField,
+	},
+	reflect.TypeOf(EID{}): {
+		"Source": 1, "UIDs": 2, "Ext": protoExtField,
+	},
+	reflect.TypeOf(UID{}): {
+		"Id": 1, "AtType": 2, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Regulations{}): {
+		"COPPA": 1, "GPP": 2, "GPPSID": 3, "GDPR": 4, "UsPrivacy": 5, "Ext": protoExtField,
+	},
+	reflect.TypeOf(BidResponse{}): {
+		"ID": 1, "SeatBids": 2, "BidID": 3, "Currency": 4, "CustomData": 5, "NBR": 6, "Ext": protoExtField,
+	},
+	reflect.TypeOf(SeatBid{}): {
+		"Bids": 1, "Seat": 2, "Group": 3, "Ext": protoExtField,
+	},
+	reflect.TypeOf(Bid{}): {
+		"ID": 1, "ImpID": 2, "Price": 3, "AdID": 4, "NoticeURL": 5, "AdMarkup": 6, "AdvDomains": 7,
+		"ImageURL": 8, "CampaignID": 9, "CreativeID": 10, "Attrs": 11, "DealID": 13, "Bundle": 14,
+		"Categories": 15, "Width": 16, "Height": 17, "API": 18, "Protocol": 19, "MediaRating": 20, "Exp": 21,
+		"BillingURL": 22, "LossURL": 23, "Tactic": 24, "Language": 25, "WidthRatio": 26, "HeightRatio": 27,
+		"MarkupType": 28, "SlotInPod": 29, "Duration": 30, "CategoryTaxonomies": 31, "LangB": 32,
+		"Ext": protoExtField,
+	},
+}
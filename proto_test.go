@@ -0,0 +1,217 @@
+package openrtb
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Every object reachable from a request or response must be mapped, otherwise
+// protoMessageOf panics when it is encoded.
+func TestProtoFields_complete(t *testing.T) {
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type, path string)
+	walk = func(rt reflect.Type, path string) {
+		if seen[rt] {
+			return
+		}
+		seen[rt] = true
+
+		nums, ok := protoFields[rt]
+		if !ok {
+			t.Errorf("%s: no protobuf mapping for %s", path, rt)
+			return
+		}
+		byNum := make(map[int]string)
+		for name, num := range nums {
+			if prev, ok := byNum[num]; ok {
+				t.Errorf("%s: %s and %s share field number %d", rt, prev, name, num)
+			}
+			byNum[num] = name
+		}
+
+		var fields func(st reflect.Type)
+		fields = func(st reflect.Type) {
+			for i := 0; i < st.NumField(); i++ {
+				sf := st.Field(i)
+				if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+					fields(sf.Type)
+					continue
+				}
+				if sf.PkgPath != "" {
+					continue
+				}
+				if _, ok := nums[sf.Name]; !ok {
+					t.Errorf("%s: no protobuf field number for %s.%s", path, rt, sf.Name)
+				}
+
+				ft := sf.Type
+				for ft.Kind() == reflect.Slice || ft.Kind() == reflect.Ptr {
+					if ft == rawMessageType {
+						break
+					}
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, path+"."+sf.Name)
+				}
+			}
+		}
+		fields(rt)
+	}
+	walk(reflect.TypeOf(BidRequest{}), "BidRequest")
+	walk(reflect.TypeOf(BidResponse{}), "BidResponse")
+
+	for rt := range protoFields {
+		if !seen[rt] {
+			t.Errorf("%s is mapped but not reachable", rt)
+		}
+	}
+}
+
+func TestBidRequest_MarshalProto_wire(t *testing.T) {
+	req := &BidRequest{ID: "1", TMax: 120, Impressions: []Impression{{ID: "a", BidFloor: 0.5}}, Ext: json.RawMessage(`{}`)}
+	data, err := req.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1: "1", 2: {1: "a", 8: 0.5}, 8: 120, 100: "{}"
+	if exp := "0a0131120c0a016141000000000000e03f4078a206027b7d"; hex.EncodeToString(data) != exp {
+		t.Fatalf("expected %s, got %x", exp, data)
+	}
+}
+
+// Golden objects for JSON -> proto -> JSON round-trips. Defaults set by UnmarshalJSON, such as
+// video.linearity and deal.at, are spelled out.
+var goldenRequest = `{
+	"id": "req-1",
+	"imp": [{
+		"id": "1",
+		"banner": {"w": 300, "h": 250, "format": [{"w": 300, "h": 250}, {"w": 320, "h": 50}], "btype": [1, 4], "api": [3, 5]},
+		"video": {"mimes": ["video/mp4"], "minduration": 5, "maxduration": 30, "protocols": [2, 3], "w": 640, "h": 480, "rqddurs": [15, 30], "linearity": 1, "sequence": 1, "mincpmpersec": 0.1},
+		"native": {"request": "{\"ver\":\"1.2\"}", "ver": "1.2"},
+		"pmp": {"private_auction": 1, "deals": [{"id": "deal-1", "bidfloor": 2.5, "at": 2, "wseat": ["seat-1"]}]},
+		"metric": [{"type": "viewability", "value": 0.85, "vendor": "vendor.com"}],
+		"bidfloor": 1.25,
+		"bidfloorcur": "EUR",
+		"secure": 1,
+		"ext": {"gpid": "/1234/home"}
+	}],
+	"site": {
+		"id": "site-1",
+		"domain": "example.com",
+		"cat": ["IAB1"],
+		"page": "https://example.com/page",
+		"publisher": {"id": "pub-1", "name": "Publisher"},
+		"content": {"id": "content-1", "title": "Title", "data": [{"id": "data-1", "segment": [{"id": "seg-1", "value": "v"}]}]}
+	},
+	"device": {
+		"ua": "Mozilla/5.0",
+		"geo": {"lat": 52.52, "lon": 13.405, "country": "DEU", "type": 2},
+		"ip": "192.168.1.1",
+		"devicetype": 2,
+		"sua": {"browsers": [{"version": ["120", "0"]}], "mobile": 1, "model": "Pixel"}
+	},
+	"user": {"id": "user-1", "eids": [{"source": "id.example", "uids": [{"id": "uid-1", "atype": 1}]}], "ext": {"k": [1, 2]}},
+	"at": 1,
+	"tmax": 120,
+	"cur": ["EUR", "USD"],
+	"bcat": ["IAB25"],
+	"source": {"tid": "tid-1", "schain": {"complete": 1, "ver": "1.0", "nodes": [{"asi": "exchange.com", "sid": "1", "hp": 1}]}},
+	"regs": {"coppa": 1, "gdpr": 1, "gpp": "DBABM~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", "gpp_sid": [2]},
+	"ext": {"prebid": {"debug": true}}
+}`
+
+var goldenResponse = `{
+	"id": "req-1",
+	"seatbid": [{
+		"bid": [{
+			"id": "bid-1",
+			"impid": "1",
+			"price": 1.5,
+			"adid": "ad-1",
+			"nurl": "https://dsp.com/win?price=${AUCTION_PRICE}",
+			"adm": "<div>ad</div>",
+			"adomain": ["advertiser.com"],
+			"cat": ["IAB1-1"],
+			"attr": [1, 2],
+			"dealid": "deal-1",
+			"w": 300,
+			"h": 250,
+			"mtype": 1,
+			"ext": {"k": "v"}
+		}],
+		"seat": "seat-1"
+	}],
+	"bidid": "resp-1",
+	"cur": "EUR",
+	"ext": {"processing": 12}
+}`
+
+func TestBidRequest_MarshalProto_golden(t *testing.T) {
+	var req BidRequest
+	if err := json.Unmarshal([]byte(goldenRequest), &req); err != nil {
+		t.Fatal(err)
+	}
+	data, err := req.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got BidRequest
+	if err := got.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+	assertProtoGolden(t, goldenRequest, &got)
+}
+
+func TestBidResponse_MarshalProto_golden(t *testing.T) {
+	var res BidResponse
+	if err := json.Unmarshal([]byte(goldenResponse), &res); err != nil {
+		t.Fatal(err)
+	}
+	data, err := res.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got BidResponse
+	if err := got.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+	assertProtoGolden(t, goldenResponse, &got)
+}
+
+func TestBidRequest_UnmarshalProto_invalid(t *testing.T) {
+	data, err := (&BidRequest{ID: "1", Ext: json.RawMessage(`{}`)}).MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(BidRequest).UnmarshalProto(data[:len(data)-1]); err != ErrProtoTruncated {
+		t.Fatalf("expected ErrProtoTruncated, got %v", err)
+	}
+	// field 1 (id) as a varint
+	if err := new(BidRequest).UnmarshalProto([]byte{0x08, 0x01}); err != ErrProtoWireType {
+		t.Fatalf("expected ErrProtoWireType, got %v", err)
+	}
+}
+
+func assertProtoGolden(t *testing.T, golden string, v interface{}) {
+	t.Helper()
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exp, got interface{}
+	if err := json.Unmarshal([]byte(golden), &exp); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Fatalf("expected %s, got %s", golden, out)
+	}
+}
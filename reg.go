@@ -11,5 +11,44 @@ type Regulations struct {
 	COPPA     int             `json:"coppa,omitempty"`      // Flag indicating if this request is subject to the COPPA regulations established by the USA FTC, where 0 = no, 1 = yes.
 	GDPR      int             `json:"gdpr,omitempty"`       // Flag that indicates whether or not the request is subject to GDPR regulations 0 = No, 1 = Yes, omission indicates Unknown. Refer to Section 7.5 for more information
 	UsPrivacy string          `json:"us_privacy,omitempty"` // Communicates signals regarding consumer privacy under US privacy regulation. See US Privacy String specifications. Refer to Section 7.5 for more information
+	GPP       string          `json:"gpp,omitempty"`        // Contains the Global Privacy Platform's consent string. See the Global Privacy Platform specification for more details.
+	GPPSID    []GPPSectionID  `json:"gpp_sid,omitempty"`    // Array of the section(s) of the string which should be applied for this transaction. Generally will contain one and only one value, but there are edge cases where more than one may apply.
 	Ext       json.RawMessage `json:"ext,omitempty"`
 }
+
+// DecodeUSPrivacy parses the US Privacy string, returns nil if none is present
+func (r *Regulations) DecodeUSPrivacy() (*USPrivacy, error) {
+	if r == nil || r.UsPrivacy == "" {
+		return nil, nil
+	}
+	return ParseUSPrivacy(r.UsPrivacy)
+}
+
+// DecodeGPP parses the GPP string, returns nil if none is present
+func (r *Regulations) DecodeGPP() (*GPP, error) {
+	if r == nil || r.GPP == "" {
+		return nil, nil
+	}
+	return ParseGPP(r.GPP)
+}
+
+// SaleOptOut returns true if either the US Privacy string or the applicable sections of the
+// GPP string signal that the user opted out of the sale of personal data.
+// Strings which cannot be parsed are ignored.
+func (r *Regulations) SaleOptOut() bool {
+	if usp, _ := r.DecodeUSPrivacy(); usp != nil && usp.IsOptOut() {
+		return true
+	}
+	if gpp, _ := r.DecodeGPP(); gpp != nil && gpp.SaleOptOut(r.GPPSID...) {
+		return true
+	}
+	return false
+}
+
+// SharingOptOut returns true if the applicable sections of the GPP string signal that the user
+// opted out of the sharing of personal data or of targeted advertising.
+// Strings which cannot be parsed are ignored.
+func (r *Regulations) SharingOptOut() bool {
+	gpp, _ := r.DecodeGPP()
+	return gpp != nil && gpp.SharingOptOut(r.GPPSID...)
+}
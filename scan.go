@@ -0,0 +1,395 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Creative check errors
+var (
+	ErrCreativeBlockedAttr     = errors.New("openrtb: creative has a blocked attribute")
+	ErrCreativeBlockedDomain   = errors.New("openrtb: creative advertiser domain is blocked")
+	ErrCreativeBlockedCategory = errors.New("openrtb: creative category is blocked")
+	ErrCreativeBlockedApp      = errors.New("openrtb: creative app is blocked")
+	ErrCreativeUndeclaredAttr  = errors.New("openrtb: creative attribute is not declared by the bid")
+)
+
+// Creative holds what scanners found in the markup of a bid.
+type Creative struct {
+	Attrs      []CreativeAttribute
+	AdvDomains []string // Domains of click-through URLs
+	Bundles    []string // Application identifiers of advertised apps
+	Categories []ContentCategory
+}
+
+// CreativeScanner inspects the markup of a bid for an impression, which may be nil, and adds its
+// findings to the creative.
+type CreativeScanner interface {
+	Scan(bid *Bid, imp *Impression, found *Creative)
+}
+
+// CreativeScannerFunc is a function implementing CreativeScanner.
+type CreativeScannerFunc func(bid *Bid, imp *Impression, found *Creative)
+
+// Scan implements CreativeScanner
+func (f CreativeScannerFunc) Scan(bid *Bid, imp *Impression, found *Creative) {
+	f(bid, imp, found)
+}
+
+// CreativeViolation is a bid breaking a blocklist of the request, or failing to declare
+// an attribute of its creative.
+type CreativeViolation struct {
+	BidID    string
+	Value    string // Offending attribute, domain, category or app
+	Detected bool   // The value was found by a scanner rather than declared by the bid
+	Err      error
+}
+
+func (v *CreativeViolation) Error() string {
+	return v.Err.Error() + " (bid " + v.BidID + ": " + v.Value + ")"
+}
+
+// Unwrap returns the kind of the violation.
+func (v *CreativeViolation) Unwrap() error { return v.Err }
+
+// CreativeChecker checks bids against the blocklists of requests: the blocked attributes of
+// the impression (battr), advertiser domains (badv), categories (bcat) and apps (bapp).
+// Both the metadata declared by a bid and the findings of the scanners are checked.
+type CreativeChecker struct {
+	Scanners []CreativeScanner // Defaults to HeuristicScanner
+}
+
+// Check checks all bids of a response to the request.
+func (c *CreativeChecker) Check(req *BidRequest, resp *BidResponse) []CreativeViolation {
+	var violations []CreativeViolation
+	for i := range resp.SeatBids {
+		for j := range resp.SeatBids[i].Bids {
+			bid := &resp.SeatBids[i].Bids[j]
+			var imp *Impression
+			for k := range req.Impressions {
+				if req.Impressions[k].ID == bid.ImpID {
+					imp = &req.Impressions[k]
+					break
+				}
+			}
+			violations = append(violations, c.CheckBid(req, imp, bid)...)
+		}
+	}
+	return violations
+}
+
+// CheckBid checks a bid for an impression of the request, the impression may be nil.
+func (c *CreativeChecker) CheckBid(req *BidRequest, imp *Impression, bid *Bid) []CreativeViolation {
+	var found Creative
+	if len(c.Scanners) == 0 {
+		HeuristicScanner{}.Scan(bid, imp, &found)
+	}
+	for _, s := range c.Scanners {
+		s.Scan(bid, imp, &found)
+	}
+
+	var violations []CreativeViolation
+	report := func(err error, value string, detected bool) {
+		for _, v := range violations {
+			if v.Err == err && v.Value == value {
+				return
+			}
+		}
+		violations = append(violations, CreativeViolation{BidID: bid.ID, Value: value, Detected: detected, Err: err})
+	}
+
+	blocked := blockedAttrs(imp, bid)
+	for _, a := range bid.Attrs {
+		if containsAttr(blocked, a) {
+			report(ErrCreativeBlockedAttr, strconv.Itoa(int(a)), false)
+		}
+	}
+	for _, a := range found.Attrs {
+		switch {
+		case containsAttr(blocked, a):
+			report(ErrCreativeBlockedAttr, strconv.Itoa(int(a)), true)
+		case !containsAttr(bid.Attrs, a):
+			report(ErrCreativeUndeclaredAttr, strconv.Itoa(int(a)), true)
+		}
+	}
+
+	for _, d := range bid.AdvDomains {
+		if domainBlocked(d, req.BlockedAdvDomains) {
+			report(ErrCreativeBlockedDomain, d, false)
+		}
+	}
+	for _, d := range found.AdvDomains {
+		if domainBlocked(d, req.BlockedAdvDomains) {
+			report(ErrCreativeBlockedDomain, d, true)
+		}
+	}
+
+	for _, cat := range bid.Categories {
+		if categoryBlocked(cat, req.BlockedCategories) {
+			report(ErrCreativeBlockedCategory, string(cat), false)
+		}
+	}
+	for _, cat := range found.Categories {
+		if categoryBlocked(cat, req.BlockedCategories) {
+			report(ErrCreativeBlockedCategory, string(cat), true)
+		}
+	}
+
+	if bid.Bundle != "" && containsString(req.BlockedApps, bid.Bundle) {
+		report(ErrCreativeBlockedApp, bid.Bundle, false)
+	}
+	for _, b := range found.Bundles {
+		if containsString(req.BlockedApps, b) {
+			report(ErrCreativeBlockedApp, b, true)
+		}
+	}
+	return violations
+}
+
+// blockedAttrs returns the attributes blocked by the media object the bid is for, or by all
+// media objects of the impression if the media type of the bid is unknown. The type is sniffed
+// from the markup before the sole offered type is assumed, so VAST on a banner-only impression
+// is not checked against the banner's battr.
+func blockedAttrs(imp *Impression, bid *Bid) []CreativeAttribute {
+	if imp == nil {
+		return nil
+	}
+
+	var blocked []CreativeAttribute
+	t := bid.MediaType(imp)
+	if imp.Banner != nil && (t == 0 || t == MediaTypeBanner) {
+		blocked = append(blocked, imp.Banner.BlockedAttrs...)
+	}
+	if imp.Video != nil && (t == 0 || t == MediaTypeVideo) {
+		blocked = append(blocked, imp.Video.BlockedAttrs...)
+	}
+	if imp.Audio != nil && (t == 0 || t == MediaTypeAudio) {
+		blocked = append(blocked, imp.Audio.BlockedAttrs...)
+	}
+	if imp.Native != nil && (t == 0 || t == MediaTypeNative) {
+		blocked = append(blocked, imp.Native.BlockedAttrs...)
+	}
+	return blocked
+}
+
+func containsAttr(list []CreativeAttribute, a CreativeAttribute) bool {
+	for _, v := range list {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+// domainBlocked reports whether the domain, or a parent domain of it, is blocked.
+func domainBlocked(domain string, blocked []string) bool {
+	domain = strings.TrimPrefix(strings.ToLower(domain), "www.")
+	for _, b := range blocked {
+		b = strings.TrimPrefix(strings.ToLower(b), "www.")
+		if b != "" && (domain == b || strings.HasSuffix(domain, "."+b)) {
+			return true
+		}
+	}
+	return false
+}
+
+// categoryBlocked reports whether the category, or its tier-1 parent, is blocked.
+func categoryBlocked(cat ContentCategory, blocked []ContentCategory) bool {
+	for _, b := range blocked {
+		if cat == b || strings.HasPrefix(string(cat), string(b)+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// --------------------------------------------------------------------
+
+// HeuristicScanner recognizes creative attributes and click-through destinations in markup:
+// autoplaying audio and video, pop-ups via window.open, dialogs, MRAID expansion and Flash, as
+// well as the domains of click-through URLs and apps linked in app stores.
+type HeuristicScanner struct{}
+
+// Scan implements CreativeScanner
+func (HeuristicScanner) Scan(bid *Bid, imp *Impression, found *Creative) {
+	adm := bid.AdMarkup
+	if adm == "" {
+		return
+	}
+	lower := asciiLower(adm)
+
+	add := func(a CreativeAttribute) {
+		if !containsAttr(found.Attrs, a) {
+			found.Attrs = append(found.Attrs, a)
+		}
+	}
+
+	for _, tag := range htmlTags(lower, "<audio") {
+		if strings.Contains(tag, "autoplay") {
+			add(CreativeAttributeAudioAdAutoPlay)
+		}
+	}
+	if strings.Contains(lower, "new audio(") && strings.Contains(lower, ".play()") {
+		add(CreativeAttributeAudioAdAutoPlay)
+	}
+	for _, tag := range htmlTags(lower, "<video") {
+		if strings.Contains(tag, "autoplay") {
+			add(CreativeAttributeInBannerVideoAdAutoPlay)
+			if !strings.Contains(tag, "muted") {
+				add(CreativeAttributeAudioAdAutoPlay)
+			}
+		}
+	}
+	if strings.Contains(lower, "window.open(") {
+		add(CreativeAttributePop)
+	}
+	for _, fn := range []string{"alert(", "confirm(", "prompt("} {
+		if callsFunc(lower, fn) {
+			add(CreativeAttributeWindowsDialogOrAlert)
+		}
+	}
+	if strings.Contains(lower, "mraid.expand") || strings.Contains(lower, "mraid.resize") {
+		if strings.Contains(lower, "click") {
+			add(CreativeAttributeExpandableUserInitiatedClick)
+		} else {
+			add(CreativeAttributeExpandableAuto)
+		}
+	}
+	if strings.Contains(lower, ".swf") || strings.Contains(lower, "x-shockwave-flash") {
+		add(CreativeAttributeAdobeFlash)
+	}
+
+	for _, raw := range clickURLs(adm, lower) {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || u.Host == "" {
+			continue
+		}
+		host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		if !containsString(found.AdvDomains, host) {
+			found.AdvDomains = append(found.AdvDomains, host)
+		}
+		if b := storeBundle(host, u); b != "" && !containsString(found.Bundles, b) {
+			found.Bundles = append(found.Bundles, b)
+		}
+	}
+}
+
+// htmlTags returns the opening tags starting with prefix.
+func htmlTags(lower, prefix string) []string {
+	var tags []string
+	for i := 0; ; {
+		j := strings.Index(lower[i:], prefix)
+		if j < 0 {
+			return tags
+		}
+		j += i
+		end := strings.IndexByte(lower[j:], '>')
+		if end < 0 {
+			return append(tags, lower[j:])
+		}
+		tags = append(tags, lower[j:j+end])
+		i = j + end
+	}
+}
+
+// callsFunc reports whether fn is called as a global or window function.
+func callsFunc(lower, fn string) bool {
+	for i := 0; ; {
+		j := strings.Index(lower[i:], fn)
+		if j < 0 {
+			return false
+		}
+		j += i
+		if j == 0 || strings.HasSuffix(lower[:j], "window.") || (!isIdentByte(lower[j-1]) && lower[j-1] != '.') {
+			return true
+		}
+		i = j + len(fn)
+	}
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z'
+}
+
+// clickURLs extracts click-through URLs from links, window.open calls, VAST ClickThrough
+// elements and native links.
+func clickURLs(adm, lower string) []string {
+	var urls []string
+	for _, prefix := range []string{"href=", "window.open("} {
+		for i := 0; ; {
+			j := strings.Index(lower[i:], prefix)
+			if j < 0 {
+				break
+			}
+			j += i + len(prefix)
+			i = j
+
+			if j < len(adm) && (adm[j] == '"' || adm[j] == '\'') {
+				if end := strings.IndexByte(adm[j+1:], adm[j]); end >= 0 {
+					urls = append(urls, adm[j+1:j+1+end])
+				}
+			} else if end := strings.IndexAny(adm[j:], " \t\r\n>"); end > 0 {
+				urls = append(urls, adm[j:j+end])
+			}
+		}
+	}
+
+	for i := 0; ; {
+		j := strings.Index(lower[i:], "<clickthrough")
+		if j < 0 {
+			break
+		}
+		j += i
+		start := strings.IndexByte(lower[j:], '>')
+		if start < 0 {
+			break
+		}
+		start += j + 1
+		end := strings.Index(lower[start:], "</clickthrough")
+		if end < 0 {
+			break
+		}
+		i = start + end
+
+		u := strings.TrimSpace(adm[start:i])
+		u = strings.TrimSuffix(strings.TrimPrefix(u, "<![CDATA["), "]]>")
+		urls = append(urls, u)
+	}
+
+	if adm = strings.TrimSpace(adm); adm != "" && adm[0] == '{' {
+		type link struct {
+			URL string `json:"url"`
+		}
+		var native struct {
+			Link   link `json:"link"`
+			Native struct {
+				Link link `json:"link"`
+			} `json:"native"`
+		}
+		if json.Unmarshal([]byte(adm), &native) == nil {
+			for _, u := range []string{native.Link.URL, native.Native.Link.URL} {
+				if u != "" {
+					urls = append(urls, u)
+				}
+			}
+		}
+	}
+	return urls
+}
+
+// storeBundle returns the application identifier of App Store and Google Play links.
+func storeBundle(host string, u *url.URL) string {
+	switch host {
+	case "apps.apple.com", "itunes.apple.com":
+		seg := u.Path[strings.LastIndexByte(u.Path, '/')+1:]
+		if strings.HasPrefix(seg, "id") && len(seg) > 2 {
+			return seg[2:]
+		}
+	case "play.google.com":
+		return u.Query().Get("id")
+	}
+	return ""
+}
@@ -0,0 +1,233 @@
+package openrtb
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// violations returns the violations as "error: value" strings, marking detected values with "*".
+func violations(vs []CreativeViolation) []string {
+	var out []string
+	for _, v := range vs {
+		s := v.Err.Error() + ": " + v.Value
+		if v.Detected {
+			s += "*"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestHeuristicScanner_Scan(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		adm  string
+		exp  Creative
+	}{
+		{name: "empty"},
+		{
+			name: "autoplay",
+			adm:  `<video autoplay muted src="a.mp4"></video><audio AUTOPLAY src="a.mp3"></audio>`,
+			exp:  Creative{Attrs: []CreativeAttribute{CreativeAttributeAudioAdAutoPlay, CreativeAttributeInBannerVideoAdAutoPlay}},
+		},
+		{
+			name: "unmuted video",
+			adm:  `<video autoplay src="a.mp4"></video>`,
+			exp:  Creative{Attrs: []CreativeAttribute{CreativeAttributeInBannerVideoAdAutoPlay, CreativeAttributeAudioAdAutoPlay}},
+		},
+		{
+			name: "pop and dialog",
+			adm:  `<script>window.open("https://a.com/x"); alert("hi"); obj.confirm(1); myprompt(2)</script>`,
+			exp: Creative{
+				Attrs:      []CreativeAttribute{CreativeAttributePop, CreativeAttributeWindowsDialogOrAlert},
+				AdvDomains: []string{"a.com"},
+			},
+		},
+		{
+			name: "expandable",
+			adm:  `<script>mraid.expand()</script>`,
+			exp:  Creative{Attrs: []CreativeAttribute{CreativeAttributeExpandableAuto}},
+		},
+		{
+			name: "expandable on click",
+			adm:  `<div onclick="mraid.expand()"></div>`,
+			exp:  Creative{Attrs: []CreativeAttribute{CreativeAttributeExpandableUserInitiatedClick}},
+		},
+		{
+			name: "flash",
+			adm:  `<embed src="ad.SWF">`,
+			exp:  Creative{Attrs: []CreativeAttribute{CreativeAttributeAdobeFlash}},
+		},
+		{
+			name: "links",
+			adm:  `<a href="https://www.A.com/x"><a href='https://a.com/y'><a href=https://b.com/z>`,
+			exp:  Creative{AdvDomains: []string{"a.com", "b.com"}},
+		},
+		{
+			name: "store bundles",
+			adm: `<a href="https://apps.apple.com/us/app/game/id123456">` +
+				`<a href="https://play.google.com/store/apps/details?id=com.foo.game&hl=en">` +
+				`<a href="https://apps.apple.com/us/app/other">`,
+			exp: Creative{
+				AdvDomains: []string{"apps.apple.com", "play.google.com"},
+				Bundles:    []string{"123456", "com.foo.game"},
+			},
+		},
+		{
+			name: "vast click-through",
+			adm:  `<VAST><ClickThrough id="1"><![CDATA[ https://c.com/click ]]></ClickThrough></VAST>`,
+			exp:  Creative{AdvDomains: []string{"c.com"}},
+		},
+		{
+			name: "native link",
+			adm:  `{"native":{"link":{"url":"https:\/\/d.com\/x"}}}`,
+			exp:  Creative{AdvDomains: []string{"d.com"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Creative
+			HeuristicScanner{}.Scan(&Bid{AdMarkup: tc.adm}, nil, &got)
+			if !reflect.DeepEqual(got, tc.exp) {
+				t.Fatalf("expected %+v, got %+v", tc.exp, got)
+			}
+		})
+	}
+}
+
+func TestCreativeChecker_CheckBid(t *testing.T) {
+	req := &BidRequest{
+		BlockedAdvDomains: []string{"www.blocked.com", "bad.org"},
+		BlockedCategories: []ContentCategory{"IAB7", "IAB9-1"},
+		BlockedApps:       []string{"com.blocked.app", "999"},
+	}
+	imp := &Impression{
+		Banner: &Banner{BlockedAttrs: []CreativeAttribute{CreativeAttributePop}},
+		Video:  &Video{BlockedAttrs: []CreativeAttribute{CreativeAttributeAdobeFlash}},
+	}
+
+	for _, tc := range []struct {
+		name string
+		imp  *Impression
+		bid  Bid
+		exp  []string
+	}{
+		{name: "clean", imp: imp, bid: Bid{AdMarkup: `<a href="https://ok.com">`, AdvDomains: []string{"ok.com"}}},
+		{
+			name: "parent domains",
+			bid:  Bid{AdvDomains: []string{"Blocked.com", "shop.blocked.com", "notblocked.com", "bad.org.uk"}},
+			exp: []string{
+				ErrCreativeBlockedDomain.Error() + ": Blocked.com",
+				ErrCreativeBlockedDomain.Error() + ": shop.blocked.com",
+			},
+		},
+		{
+			name: "detected domains",
+			bid:  Bid{AdMarkup: `<a href="https://x.bad.org/click">`, AdvDomains: []string{"x.bad.org"}},
+			exp:  []string{ErrCreativeBlockedDomain.Error() + ": x.bad.org"},
+		},
+		{
+			name: "tier-1 categories",
+			bid:  Bid{Categories: []ContentCategory{"IAB7", "IAB7-12", "IAB70", "IAB9", "IAB9-1", "IAB9-10"}},
+			exp: []string{
+				ErrCreativeBlockedCategory.Error() + ": IAB7",
+				ErrCreativeBlockedCategory.Error() + ": IAB7-12",
+				ErrCreativeBlockedCategory.Error() + ": IAB9-1",
+			},
+		},
+		{
+			name: "apps",
+			bid: Bid{Bundle: "com.blocked.app", AdMarkup: `<a href="https://apps.apple.com/app/id999">` +
+				`<a href="https://play.google.com/store/apps/details?id=com.blocked.app">`},
+			exp: []string{
+				ErrCreativeBlockedApp.Error() + ": com.blocked.app",
+				ErrCreativeBlockedApp.Error() + ": 999*",
+			},
+		},
+		{
+			name: "attributes",
+			imp:  imp,
+			bid: Bid{
+				AdMarkup: `<script>window.open("x")</script><video autoplay muted></video>`,
+				Attrs:    []CreativeAttribute{CreativeAttributePop, CreativeAttributeTextOnly},
+			},
+			exp: []string{
+				ErrCreativeBlockedAttr.Error() + ": 8",
+				ErrCreativeUndeclaredAttr.Error() + ": 6*",
+			},
+		},
+		{
+			name: "video attributes",
+			imp:  imp,
+			bid:  Bid{AdMarkup: `<VAST><MediaFile type="application/x-shockwave-flash"/></VAST>`, Attrs: []CreativeAttribute{CreativeAttributePop}},
+			exp:  []string{ErrCreativeBlockedAttr.Error() + ": 17*"},
+		},
+		{
+			name: "unknown media type",
+			imp:  imp,
+			bid:  Bid{Attrs: []CreativeAttribute{CreativeAttributePop, CreativeAttributeAdobeFlash}},
+			exp: []string{
+				ErrCreativeBlockedAttr.Error() + ": 8",
+				ErrCreativeBlockedAttr.Error() + ": 17",
+			},
+		},
+		{
+			name: "sniffed media type",
+			imp:  &Impression{Banner: &Banner{BlockedAttrs: []CreativeAttribute{CreativeAttributeInBannerVideoAdAutoPlay}}},
+			bid:  Bid{AdMarkup: `<VAST></VAST>`, Attrs: []CreativeAttribute{CreativeAttributeInBannerVideoAdAutoPlay}},
+		},
+		{
+			name: "sole media type",
+			imp:  &Impression{Banner: &Banner{BlockedAttrs: []CreativeAttribute{CreativeAttributeInBannerVideoAdAutoPlay}}},
+			bid:  Bid{Attrs: []CreativeAttribute{CreativeAttributeInBannerVideoAdAutoPlay}},
+			exp:  []string{ErrCreativeBlockedAttr.Error() + ": 6"},
+		},
+		{
+			name: "declared and detected",
+			bid: Bid{
+				AdMarkup:   `<a href="https://blocked.com/a"><a href="https://blocked.com/b">`,
+				AdvDomains: []string{"blocked.com", "blocked.com"},
+			},
+			exp: []string{ErrCreativeBlockedDomain.Error() + ": blocked.com"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bid := tc.bid
+			bid.ID = "b"
+			got := new(CreativeChecker).CheckBid(req, tc.imp, &bid)
+			if !reflect.DeepEqual(violations(got), tc.exp) {
+				t.Fatalf("expected %v, got %v", tc.exp, violations(got))
+			}
+		})
+	}
+}
+
+func TestCreativeChecker_Check(t *testing.T) {
+	req := &BidRequest{
+		BlockedAdvDomains: []string{"blocked.com"},
+		BlockedCategories: []ContentCategory{"IAB25"},
+		Impressions:       []Impression{{ID: "1", Banner: &Banner{BlockedAttrs: []CreativeAttribute{CreativeAttributePop}}}},
+	}
+	resp := &BidResponse{SeatBids: []SeatBid{{Bids: []Bid{
+		{ID: "a", ImpID: "1", Attrs: []CreativeAttribute{CreativeAttributePop}},
+		{ID: "b", ImpID: "2", Attrs: []CreativeAttribute{CreativeAttributePop}, AdvDomains: []string{"blocked.com"}},
+	}}}}
+
+	// scanners replace the heuristic scanner, impression 2 does not exist
+	tagged := CreativeScannerFunc(func(bid *Bid, imp *Impression, found *Creative) {
+		found.Categories = append(found.Categories, "IAB25")
+	})
+	got := (&CreativeChecker{Scanners: []CreativeScanner{tagged}}).Check(req, resp)
+	exp := []CreativeViolation{
+		{BidID: "a", Value: "8", Err: ErrCreativeBlockedAttr},
+		{BidID: "a", Value: "IAB25", Detected: true, Err: ErrCreativeBlockedCategory},
+		{BidID: "b", Value: "blocked.com", Err: ErrCreativeBlockedDomain},
+		{BidID: "b", Value: "IAB25", Detected: true, Err: ErrCreativeBlockedCategory},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, got)
+	}
+	if err := error(&got[0]); !errors.Is(err, ErrCreativeBlockedAttr) || err.Error() != ErrCreativeBlockedAttr.Error()+" (bid a: 8)" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,289 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+)
+
+// ScrubPolicy describes which personal data to remove from, or coarsen in, a bid request
+// before it is forwarded. The zero value leaves the request untouched.
+type ScrubPolicy struct {
+	IPv4Bits           int  // Number of leading bits of Device.IP to retain, e.g. 24; zero leaves the IP untouched
+	IPv6Bits           int  // Number of leading bits of Device.IPv6 to retain, e.g. 56; zero leaves the IP untouched
+	RemoveDeviceIDs    bool // Remove Device.IFA, all hashed device and platform IDs and the vendor IDs in Device.Ext
+	RemoveUserIDs      bool // Remove User.ID, User.BuyerID, User.BuyerUID, User.Eids and the IDs in User.Ext
+	RemoveDemographics bool // Remove User.YearOfBirth, User.Gender, User.Keywords and the User.Data segments
+	GeoDecimals        int  // Number of decimals of Geo.Latitude/Longitude to retain when CoarsenGeo is set
+	CoarsenGeo         bool // Round Geo.Latitude/Longitude to GeoDecimals
+	RemoveGeoDetails   bool // Remove Geo.Latitude/Longitude, Metro, City and ZIP altogether
+}
+
+// Standard scrub policies.
+var (
+	// ScrubPolicyCOPPA applies to requests subject to COPPA regulations
+	ScrubPolicyCOPPA = ScrubPolicy{
+		IPv4Bits:           24,
+		IPv6Bits:           56,
+		RemoveDeviceIDs:    true,
+		RemoveUserIDs:      true,
+		RemoveDemographics: true,
+		RemoveGeoDetails:   true,
+	}
+
+	// ScrubPolicyNoTracking applies to requests with limited ad tracking, without GDPR
+	// consent or with a US privacy opt-out
+	ScrubPolicyNoTracking = ScrubPolicy{
+		IPv4Bits:        24,
+		IPv6Bits:        56,
+		RemoveDeviceIDs: true,
+		RemoveUserIDs:   true,
+		GeoDecimals:     2,
+		CoarsenGeo:      true,
+	}
+)
+
+// IsZero returns true if the policy leaves requests untouched
+func (p ScrubPolicy) IsZero() bool {
+	return p == ScrubPolicy{}
+}
+
+// Merge returns the union of both policies, i.e. the stricter setting of each attribute.
+func (p ScrubPolicy) Merge(o ScrubPolicy) ScrubPolicy {
+	p.IPv4Bits = minBits(p.IPv4Bits, o.IPv4Bits)
+	p.IPv6Bits = minBits(p.IPv6Bits, o.IPv6Bits)
+	p.RemoveDeviceIDs = p.RemoveDeviceIDs || o.RemoveDeviceIDs
+	p.RemoveUserIDs = p.RemoveUserIDs || o.RemoveUserIDs
+	p.RemoveDemographics = p.RemoveDemographics || o.RemoveDemographics
+	p.RemoveGeoDetails = p.RemoveGeoDetails || o.RemoveGeoDetails
+	if o.CoarsenGeo && (!p.CoarsenGeo || o.GeoDecimals < p.GeoDecimals) {
+		p.GeoDecimals = o.GeoDecimals
+	}
+	p.CoarsenGeo = p.CoarsenGeo || o.CoarsenGeo
+	return p
+}
+
+func minBits(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// ScrubPolicyFor derives the policy required by the regulations and device flags of a request:
+// COPPA, limited ad tracking, GDPR without purpose 1 consent and US privacy opt-outs.
+func ScrubPolicyFor(req *BidRequest) ScrubPolicy {
+	var p ScrubPolicy
+	if regs := req.Regulations; regs != nil {
+		if regs.COPPA == 1 {
+			p = p.Merge(ScrubPolicyCOPPA)
+		}
+		if regs.GDPR == 1 && !req.hasGDPRConsent() {
+			p = p.Merge(ScrubPolicyNoTracking)
+		}
+		if regs.SaleOptOut() || regs.SharingOptOut() {
+			p = p.Merge(ScrubPolicyNoTracking)
+		}
+	}
+	if req.Device != nil && req.Device.LMT == 1 {
+		p = p.Merge(ScrubPolicyNoTracking)
+	}
+	return p
+}
+
+// hasGDPRConsent returns true if the TCF consent string in User.Consent, or in the GPP
+// string as a fallback, grants consent for purpose 1 (store and/or access information on a device).
+func (req *BidRequest) hasGDPRConsent() bool {
+	var consent *TCFConsent
+	if req.User != nil && req.User.Consent != "" {
+		consent, _ = ParseTCFConsent(req.User.Consent)
+	} else if gpp, _ := req.Regulations.DecodeGPP(); gpp != nil {
+		consent = gpp.TCFEUv2
+	}
+	return consent != nil && consent.PurposeConsent(1)
+}
+
+// ScrubReport lists the attributes that were affected by scrubbing, addressed by their JSON paths.
+type ScrubReport struct {
+	Removed   []string // Attributes that were removed
+	Coarsened []string // Attributes that were truncated or reduced in precision
+}
+
+// IsEmpty returns true if no attributes were affected
+func (r *ScrubReport) IsEmpty() bool {
+	return len(r.Removed) == 0 && len(r.Coarsened) == 0
+}
+
+func (r *ScrubReport) removeString(path string, v *string) {
+	if *v != "" {
+		*v = ""
+		r.Removed = append(r.Removed, path)
+	}
+}
+
+// Scrub returns a deep copy of the request with personal data removed or coarsened according to
+// the policy, along with a report of the affected attributes. The original request is left untouched.
+//
+// Of User.Ext and Device.Ext only the identifier keys are removed: eids, digitrust and tpid of
+// User.Ext, ifv and idfv of Device.Ext. Other keys are kept, as they carry signals such as the consent string of OpenRTB
+// 2.5 requests, which are needed to process the scrubbed request. An ext that is not a JSON
+// object is removed as a whole.
+func (req *BidRequest) Scrub(p ScrubPolicy) (*BidRequest, *ScrubReport) {
+	out := req.Clone()
+	rep := new(ScrubReport)
+
+	if out.Device != nil {
+		scrubDevice(out.Device, p, rep)
+	}
+	if out.User != nil {
+		scrubUser(out.User, p, rep)
+	}
+	return out, rep
+}
+
+// ScrubPrivacy scrubs the request according to its own regulations, see ScrubPolicyFor.
+func (req *BidRequest) ScrubPrivacy() (*BidRequest, *ScrubReport) {
+	return req.Scrub(ScrubPolicyFor(req))
+}
+
+func scrubDevice(dev *Device, p ScrubPolicy, rep *ScrubReport) {
+	if p.IPv4Bits != 0 && dev.IP != "" {
+		if ip := maskIP(dev.IP, p.IPv4Bits, 32); ip == "" {
+			rep.removeString("device.ip", &dev.IP)
+		} else if ip != dev.IP {
+			dev.IP = ip
+			rep.Coarsened = append(rep.Coarsened, "device.ip")
+		}
+	}
+	if p.IPv6Bits != 0 && dev.IPv6 != "" {
+		if ip := maskIP(dev.IPv6, p.IPv6Bits, 128); ip == "" {
+			rep.removeString("device.ipv6", &dev.IPv6)
+		} else if ip != dev.IPv6 {
+			dev.IPv6 = ip
+			rep.Coarsened = append(rep.Coarsened, "device.ipv6")
+		}
+	}
+	if p.RemoveDeviceIDs {
+		rep.removeString("device.ifa", &dev.IFA)
+		rep.removeString("device.didsha1", &dev.IDSHA1)
+		rep.removeString("device.didmd5", &dev.IDMD5)
+		rep.removeString("device.dpidsha1", &dev.PIDSHA1)
+		rep.removeString("device.dpidmd5", &dev.PIDMD5)
+		rep.removeString("device.macsha1", &dev.MacSHA1)
+		rep.removeString("device.macmd5", &dev.MacMD5)
+		removeExtKeys("device.ext", &dev.Ext, rep, deviceExtIDs)
+	}
+	if dev.Geo != nil {
+		scrubGeo("device.geo", dev.Geo, p, rep)
+	}
+}
+
+func scrubUser(user *User, p ScrubPolicy, rep *ScrubReport) {
+	if p.RemoveUserIDs {
+		rep.removeString("user.id", &user.ID)
+		rep.removeString("user.buyerid", &user.BuyerID)
+		rep.removeString("user.buyeruid", &user.BuyerUID)
+		if user.Eids != nil {
+			user.Eids = nil
+			rep.Removed = append(rep.Removed, "user.eids")
+		}
+		removeExtKeys("user.ext", &user.Ext, rep, userExtIDs)
+	}
+	if p.RemoveDemographics {
+		if user.YearOfBirth != 0 {
+			user.YearOfBirth = 0
+			rep.Removed = append(rep.Removed, "user.yob")
+		}
+		rep.removeString("user.gender", &user.Gender)
+		rep.removeString("user.keywords", &user.Keywords)
+		if user.KeywordArray != nil {
+			user.KeywordArray = nil
+			rep.Removed = append(rep.Removed, "user.kwarray")
+		}
+		if user.Data != nil {
+			user.Data = nil
+			rep.Removed = append(rep.Removed, "user.data")
+		}
+	}
+	if user.Geo != nil {
+		scrubGeo("user.geo", user.Geo, p, rep)
+	}
+}
+
+// Identifier keys of User.Ext and Device.Ext, as used by OpenRTB 2.5 requests and common extensions.
+var (
+	userExtIDs   = []string{"eids", "digitrust", "tpid"}
+	deviceExtIDs = []string{"ifv", "idfv"}
+)
+
+// removeExtKeys removes the keys from an ext, or the ext itself if it is not a JSON object.
+func removeExtKeys(path string, ext *json.RawMessage, rep *ScrubReport, keys []string) {
+	if len(*ext) == 0 {
+		return
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(*ext, &m); err != nil || m == nil {
+		*ext = nil
+		rep.Removed = append(rep.Removed, path)
+		return
+	}
+
+	n := len(m)
+	for _, key := range keys {
+		if _, ok := m[key]; ok {
+			delete(m, key)
+			rep.Removed = append(rep.Removed, path+"."+key)
+		}
+	}
+	if len(m) == n {
+		return
+	}
+	if len(m) == 0 {
+		*ext = nil
+		return
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		*ext = nil
+		return
+	}
+	*ext = data
+}
+
+func scrubGeo(path string, geo *Geo, p ScrubPolicy, rep *ScrubReport) {
+	if p.RemoveGeoDetails {
+		if geo.Latitude != 0 || geo.Longitude != 0 {
+			geo.Latitude, geo.Longitude, geo.Accuracy = 0, 0, 0
+			rep.Removed = append(rep.Removed, path+".lat", path+".lon")
+		}
+		rep.removeString(path+".metro", &geo.Metro)
+		rep.removeString(path+".city", &geo.City)
+		rep.removeString(path+".zip", &geo.ZIP)
+	} else if p.CoarsenGeo {
+		scale := math.Pow10(p.GeoDecimals)
+		if lat := math.Round(geo.Latitude*scale) / scale; lat != geo.Latitude {
+			geo.Latitude = lat
+			rep.Coarsened = append(rep.Coarsened, path+".lat")
+		}
+		if lon := math.Round(geo.Longitude*scale) / scale; lon != geo.Longitude {
+			geo.Longitude = lon
+			rep.Coarsened = append(rep.Coarsened, path+".lon")
+		}
+	}
+}
+
+// maskIP zeroes all but the leading bits of an IP address, returns an empty string
+// if the address cannot be parsed.
+func maskIP(s string, bits, size int) string {
+	ip := net.ParseIP(s)
+	if ip == nil || bits > size {
+		return ""
+	}
+	if size == 32 {
+		if ip = ip.To4(); ip == nil {
+			return ""
+		}
+	}
+	return ip.Mask(net.CIDRMask(bits, size)).String()
+}
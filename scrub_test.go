@@ -0,0 +1,64 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBidRequest_Scrub(t *testing.T) {
+	req := &BidRequest{
+		ID: "req",
+		Device: &Device{
+			IP:  "192.168.1.77",
+			IFA: "ifa",
+			Geo: &Geo{Latitude: 52.5163, Longitude: 13.3777, City: "Berlin"},
+			Ext: json.RawMessage(`{"ifv":"v","atts":3}`),
+		},
+		User: &User{
+			ID:          "user",
+			YearOfBirth: 1980,
+			Data:        []Data{{ID: "seg"}},
+			Eids:        []EID{{Source: "id.example"}},
+			Ext:         json.RawMessage(`{"consent":"c","eids":[{"source":"id.example"}]}`),
+		},
+	}
+	orig := req.Clone()
+
+	out, rep := req.Scrub(ScrubPolicyCOPPA)
+	if !reflect.DeepEqual(req, orig) {
+		t.Fatal("original request was modified")
+	}
+
+	if out.Device.IP != "192.168.1.0" || out.Device.IFA != "" || out.Device.Geo.City != "" || out.Device.Geo.Latitude != 0 {
+		t.Fatalf("unexpected device %+v", out.Device)
+	}
+	if string(out.Device.Ext) != `{"atts":3}` {
+		t.Fatalf("unexpected device ext %s", out.Device.Ext)
+	}
+	if out.User.ID != "" || out.User.YearOfBirth != 0 || out.User.Data != nil || out.User.Eids != nil {
+		t.Fatalf("unexpected user %+v", out.User)
+	}
+	if string(out.User.Ext) != `{"consent":"c"}` {
+		t.Fatalf("unexpected user ext %s", out.User.Ext)
+	}
+
+	exp := []string{
+		"device.ifa", "device.ext.ifv", "device.geo.lat", "device.geo.lon", "device.geo.city",
+		"user.id", "user.eids", "user.ext.eids", "user.yob", "user.data",
+	}
+	if !reflect.DeepEqual(rep.Removed, exp) {
+		t.Fatalf("expected removed %v, got %v", exp, rep.Removed)
+	}
+	if !reflect.DeepEqual(rep.Coarsened, []string{"device.ip"}) {
+		t.Fatalf("unexpected coarsened %v", rep.Coarsened)
+	}
+}
+
+func TestBidRequest_Scrub_invalidExt(t *testing.T) {
+	req := &BidRequest{User: &User{Ext: json.RawMessage(`[1]`)}}
+	out, rep := req.Scrub(ScrubPolicy{RemoveUserIDs: true})
+	if out.User.Ext != nil || !reflect.DeepEqual(rep.Removed, []string{"user.ext"}) {
+		t.Fatalf("unexpected result %s, %v", out.User.Ext, rep.Removed)
+	}
+}
@@ -0,0 +1,64 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SellerType as defined by the sellers.json specification.
+type SellerType string
+
+// SellerType values.
+const (
+	SellerTypePublisher    SellerType = "PUBLISHER"
+	SellerTypeIntermediary SellerType = "INTERMEDIARY"
+	SellerTypeBoth         SellerType = "BOTH"
+)
+
+// SellersJSON is the sellers.json file published by an advertising system, listing the entities
+// that are authorized to sell inventory through it.
+type SellersJSON struct {
+	ContactEmail   string                  `json:"contact_email,omitempty"`   // Email address to use to contact the advertising system for questions or inquiries
+	ContactAddress string                  `json:"contact_address,omitempty"` // Business address of the advertising system
+	Version        string                  `json:"version"`                   // The version of the sellers.json specification used
+	Identifiers    []SellersJSONIdentifier `json:"identifiers,omitempty"`     // Array of business identifiers of the advertising system
+	Sellers        []Seller                `json:"sellers"`                   // The list of all sellers of the advertising system
+	Ext            json.RawMessage         `json:"ext,omitempty"`
+}
+
+// SellersJSONIdentifier is a business identifier of the advertising system, e.g. a TAG-ID.
+type SellersJSONIdentifier struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Seller is an entity authorized to sell inventory through the advertising system.
+type Seller struct {
+	SellerID       StringOrNumber  `json:"seller_id"`                 // The identifier associated with the seller or reseller account within the advertising system, matches SupplyChainNode.SID
+	IsConfidential int             `json:"is_confidential,omitempty"` // Indicates whether the identity of the seller is confidential, where 0 = not confidential, 1 = confidential
+	SellerType     SellerType      `json:"seller_type"`               // Whether the account is a publisher, an intermediary or both
+	IsPassthrough  int             `json:"is_passthrough,omitempty"`  // Whether the advertising system is only passing through the payment to the seller
+	Name           string          `json:"name,omitempty"`            // The name of the company (the legal entity) that is paid for inventory
+	Domain         string          `json:"domain,omitempty"`          // The business domain name of the entity represented by this seller
+	Comment        string          `json:"comment,omitempty"`         // Description of the seller
+	Ext            json.RawMessage `json:"ext,omitempty"`
+}
+
+// ParseSellersJSON parses a sellers.json file.
+func ParseSellersJSON(r io.Reader) (*SellersJSON, error) {
+	sj := new(SellersJSON)
+	if err := json.NewDecoder(r).Decode(sj); err != nil {
+		return nil, err
+	}
+	return sj, nil
+}
+
+// Find returns the seller with the given ID, or nil if unknown
+func (sj *SellersJSON) Find(sellerID string) *Seller {
+	for i := range sj.Sellers {
+		if string(sj.Sellers[i].SellerID) == sellerID {
+			return &sj.Sellers[i]
+		}
+	}
+	return nil
+}
@@ -12,3 +12,111 @@ type Source struct {
 	SupplyChain       *SupplyChain    `json:"schain,omitempty"` //This object represents both the links in the supply chain as well as an indicator whether or not the supply chain is complete. Details via the SupplyChain object (section 3.2.25)
 	Ext               json.RawMessage `json:"ext,omitempty"`    // Placeholder for exchange-specific extensions to OpenRTB.
 }
+
+// SupplyChainLocation determines where a supply chain is stored within the Source object.
+type SupplyChainLocation int
+
+// SupplyChainLocation values.
+const (
+	SupplyChainInSource    SupplyChainLocation = iota // source.schain, as defined by OpenRTB 2.6
+	SupplyChainInSourceExt                            // source.ext.schain, as used with OpenRTB 2.5
+)
+
+// GetSupplyChain returns the supply chain from source.schain or, if absent, from source.ext.schain.
+// Returns nil if neither is present.
+func (s *Source) GetSupplyChain() (*SupplyChain, error) {
+	if s.SupplyChain != nil {
+		return s.SupplyChain, nil
+	}
+
+	ext, err := s.extMap()
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := ext["schain"]
+	if !ok {
+		return nil, nil
+	}
+
+	sc := new(SupplyChain)
+	if err := json.Unmarshal(raw, sc); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// SetSupplyChain stores the supply chain at the given location and removes it
+// from the other one. Passing a nil chain removes it from both locations.
+func (s *Source) SetSupplyChain(sc *SupplyChain, loc SupplyChainLocation) error {
+	ext, err := s.extMap()
+	if err != nil {
+		return err
+	}
+
+	delete(ext, "schain")
+	s.SupplyChain = nil
+
+	if sc != nil && loc == SupplyChainInSourceExt {
+		raw, err := json.Marshal(sc)
+		if err != nil {
+			return err
+		}
+		ext["schain"] = raw
+	} else if sc != nil {
+		s.SupplyChain = sc
+	}
+	return s.setExtMap(ext)
+}
+
+// AppendSupplyChainNode appends a node to the supply chain of the source, creating the chain if
+// absent, and stores the result at the given location. A node which is already part of the chain
+// is not appended again. A newly created chain is marked as incomplete, as nothing is known
+// about the upstream entities.
+func (s *Source) AppendSupplyChainNode(node SupplyChainNode, loc SupplyChainLocation) error {
+	sc, err := s.GetSupplyChain()
+	if err != nil {
+		return err
+	}
+	if sc == nil {
+		sc = &SupplyChain{Version: SupplyChainVersion}
+	}
+
+	sc.AppendNode(node)
+	return s.SetSupplyChain(sc, loc)
+}
+
+// Validate the supply chain of the source, if present
+func (s *Source) Validate() error {
+	sc, err := s.GetSupplyChain()
+	if err != nil {
+		return err
+	}
+	if sc != nil {
+		return sc.Validate()
+	}
+	return nil
+}
+
+func (s *Source) extMap() (map[string]json.RawMessage, error) {
+	ext := make(map[string]json.RawMessage)
+	if len(s.Ext) != 0 {
+		if err := json.Unmarshal(s.Ext, &ext); err != nil {
+			return nil, err
+		}
+	}
+	return ext, nil
+}
+
+func (s *Source) setExtMap(ext map[string]json.RawMessage) error {
+	if len(ext) == 0 {
+		s.Ext = nil
+		return nil
+	}
+
+	raw, err := json.Marshal(ext)
+	if err != nil {
+		return err
+	}
+	s.Ext = raw
+	return nil
+}
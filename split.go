@@ -0,0 +1,194 @@
+package openrtb
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Reassembly errors
+var (
+	ErrSplitResponseID = errors.New("openrtb: bid response ID does not match a split request")
+	ErrSplitImpID      = errors.New("openrtb: bid impression ID does not match the split request")
+	ErrSplitCurrency   = errors.New("openrtb: split responses use different currencies")
+)
+
+// Splitter splits requests for bidders which accept only a single impression or a
+// single media type per request.
+type Splitter struct {
+	Impressions bool // One request per impression
+	MediaTypes  bool // One request per media type, impressions offering several types are included in each
+}
+
+// SplitRequest is a request derived from the original request by a Splitter.
+type SplitRequest struct {
+	*BidRequest
+	Key       string    // Suffix of the derived ID: the impression ID and/or the media type, joined by "-"
+	MediaType MediaType // Media type of the impressions, 0 if not split by media type
+}
+
+// Split is the result of splitting a request.
+type Split struct {
+	Request  *BidRequest // Original request
+	Requests []SplitRequest
+}
+
+// Split returns the derived requests, deep copies of the original request with its
+// impressions split up. The ID of a derived request is the original ID and its key, joined
+// by "-", e.g. "req-imp1-video", so that it is stable across repeated splits. Keys which would
+// repeat the ID of another derived request, e.g. for duplicate impression IDs, are suffixed
+// with "-" and a counter starting at 2. Impression IDs are not changed.
+func (s *Splitter) Split(req *BidRequest) *Split {
+	sp := &Split{Request: req}
+
+	if !s.Impressions {
+		if !s.MediaTypes {
+			sp.add("", 0, req.Impressions)
+			return sp
+		}
+		for _, t := range []MediaType{MediaTypeBanner, MediaTypeVideo, MediaTypeAudio, MediaTypeNative} {
+			var imps []Impression
+			for i := range req.Impressions {
+				if req.Impressions[i].Offers(t) {
+					imps = append(imps, req.Impressions[i])
+				}
+			}
+			if len(imps) != 0 {
+				sp.add(t.String(), t, imps)
+			}
+		}
+		return sp
+	}
+
+	for i := range req.Impressions {
+		imp := req.Impressions[i : i+1]
+		if !s.MediaTypes {
+			sp.add(imp[0].ID, 0, imp)
+			continue
+		}
+		for _, t := range imp[0].MediaTypes() {
+			sp.add(imp[0].ID+"-"+t.String(), t, imp)
+		}
+	}
+	return sp
+}
+
+func (sp *Split) add(key string, t MediaType, imps []Impression) {
+	tmp := *sp.Request
+	tmp.Impressions = nil
+	req := tmp.Clone()
+
+	if key != "" {
+		unique := key
+		for n := 2; sp.lookup(req.ID+"-"+unique) != nil; n++ {
+			unique = key + "-" + strconv.Itoa(n)
+		}
+		key = unique
+		req.ID += "-" + key
+	}
+	req.Impressions = make([]Impression, len(imps))
+	for i := range imps {
+		imps[i].copyTo(&req.Impressions[i])
+		if t != 0 {
+			req.Impressions[i].only(t)
+		}
+	}
+	sp.Requests = append(sp.Requests, SplitRequest{BidRequest: req, Key: key, MediaType: t})
+}
+
+func (sp *Split) lookup(id string) *SplitRequest {
+	for i := range sp.Requests {
+		if sp.Requests[i].ID == id {
+			return &sp.Requests[i]
+		}
+	}
+	return nil
+}
+
+// Reassemble combines the responses to the derived requests into a response to the original
+// request. Bids are grouped by seat and refer to the impression IDs of the original request,
+// bids without a markup type get the media type of their derived request. Bid IDs repeated
+// across responses are suffixed with "-" and the key of the derived request, and a counter
+// starting at 2 if that is taken as well. Other response attributes are taken from the first
+// response.
+func (sp *Split) Reassemble(responses []*BidResponse) (*BidResponse, error) {
+	out := &BidResponse{ID: sp.Request.ID}
+	seats := make(map[string]int)
+	ids := make(map[string]bool)
+	first := true
+
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		d := sp.lookup(resp.ID)
+		if d == nil {
+			return nil, ErrSplitResponseID
+		}
+
+		if first {
+			out.Currency, out.BidID, out.CustomData, out.NBR = resp.Currency, resp.BidID, resp.CustomData, resp.NBR
+			out.Ext = append(resp.Ext[:0:0], resp.Ext...)
+			first = false
+		} else if currencyOf(resp.Currency) != currencyOf(out.Currency) {
+			return nil, ErrSplitCurrency
+		}
+
+		for _, sb := range resp.SeatBids {
+			if len(sb.Bids) == 0 {
+				continue
+			}
+			n, ok := seats[sb.Seat]
+			if !ok {
+				n = len(out.SeatBids)
+				seats[sb.Seat] = n
+				out.SeatBids = append(out.SeatBids, SeatBid{Seat: sb.Seat, Ext: append(sb.Ext[:0:0], sb.Ext...)})
+			}
+			msb := &out.SeatBids[n]
+			if sb.Group == 1 {
+				msb.Group = 1
+			}
+
+			for i := range sb.Bids {
+				if !d.hasImp(sb.Bids[i].ImpID) {
+					return nil, ErrSplitImpID
+				}
+
+				var bid Bid
+				sb.Bids[i].copyTo(&bid)
+				if bid.MarkupType == 0 {
+					bid.MarkupType = d.MediaType
+				}
+				if ids[bid.ID] {
+					id := bid.ID + "-" + d.Key
+					unique := id
+					for n := 2; ids[unique]; n++ {
+						unique = id + "-" + strconv.Itoa(n)
+					}
+					bid.ID = unique
+				}
+				ids[bid.ID] = true
+				msb.Bids = append(msb.Bids, bid)
+			}
+		}
+	}
+	if len(out.SeatBids) != 0 {
+		out.NBR = 0
+	}
+	return out, nil
+}
+
+func (d *SplitRequest) hasImp(id string) bool {
+	for i := range d.Impressions {
+		if d.Impressions[i].ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func currencyOf(cur string) string {
+	if cur == "" {
+		return DefaultCurrency
+	}
+	return cur
+}
@@ -0,0 +1,141 @@
+package openrtb
+
+import (
+	"reflect"
+	"testing"
+)
+
+// splitIDs returns the IDs of the derived requests and their impression IDs.
+func splitIDs(sp *Split) [][]string {
+	var out [][]string
+	for _, d := range sp.Requests {
+		ids := []string{d.ID}
+		for _, imp := range d.Impressions {
+			ids = append(ids, imp.ID)
+		}
+		out = append(out, ids)
+	}
+	return out
+}
+
+func TestSplitter_Split(t *testing.T) {
+	req := &BidRequest{ID: "req", TMax: 100, Impressions: []Impression{
+		{ID: "1", Banner: &Banner{}, Video: &Video{}},
+		{ID: "2", Banner: &Banner{}},
+		{ID: "3", Native: &Native{}},
+	}}
+
+	for _, tc := range []struct {
+		name     string
+		splitter Splitter
+		exp      [][]string
+	}{
+		{"none", Splitter{}, [][]string{{"req", "1", "2", "3"}}},
+		{"impressions", Splitter{Impressions: true}, [][]string{{"req-1", "1"}, {"req-2", "2"}, {"req-3", "3"}}},
+		{"media types", Splitter{MediaTypes: true}, [][]string{{"req-banner", "1", "2"}, {"req-video", "1"}, {"req-native", "3"}}},
+		{"both", Splitter{Impressions: true, MediaTypes: true}, [][]string{{"req-1-banner", "1"}, {"req-1-video", "1"}, {"req-2-banner", "2"}, {"req-3-native", "3"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sp := tc.splitter.Split(req)
+			if got := splitIDs(sp); !reflect.DeepEqual(got, tc.exp) {
+				t.Fatalf("expected %v, got %v", tc.exp, got)
+			}
+			for _, d := range sp.Requests {
+				if d.TMax != 100 || d.ID != "req-"+d.Key && d.Key != "" {
+					t.Errorf("unexpected request %+v", d.BidRequest)
+				}
+				for _, imp := range d.Impressions {
+					if types := imp.MediaTypes(); d.MediaType != 0 && !reflect.DeepEqual(types, []MediaType{d.MediaType}) {
+						t.Errorf("%s: expected only %v, got %v", d.ID, d.MediaType, types)
+					}
+				}
+			}
+		})
+	}
+	if req.ID != "req" || len(req.Impressions) != 3 || req.Impressions[0].Video == nil {
+		t.Fatalf("request was modified: %+v", req)
+	}
+}
+
+func TestSplitter_Split_duplicateIDs(t *testing.T) {
+	req := &BidRequest{ID: "req", Impressions: []Impression{
+		{ID: "1", Video: &Video{}},
+		{ID: "1", Video: &Video{}},
+		{ID: "1-2", Video: &Video{}},
+		{ID: "1", Video: &Video{}},
+	}}
+
+	sp := (&Splitter{Impressions: true}).Split(req)
+	exp := [][]string{{"req-1", "1"}, {"req-1-2", "1"}, {"req-1-2-2", "1-2"}, {"req-1-3", "1"}}
+	if got := splitIDs(sp); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+
+	sp = (&Splitter{Impressions: true, MediaTypes: true}).Split(req)
+	exp = [][]string{{"req-1-video", "1"}, {"req-1-video-2", "1"}, {"req-1-2-video", "1-2"}, {"req-1-video-3", "1"}}
+	if got := splitIDs(sp); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+
+	// responses are matched to their own request
+	resp, err := sp.Reassemble([]*BidResponse{
+		{ID: "req-1-video-2", SeatBids: []SeatBid{{Bids: []Bid{{ID: "a", ImpID: "1"}}}}},
+		{ID: "req-1-2-video", SeatBids: []SeatBid{{Bids: []Bid{{ID: "b", ImpID: "1-2"}}}}},
+	})
+	if err != nil || len(resp.SeatBids) != 1 || len(resp.SeatBids[0].Bids) != 2 {
+		t.Fatalf("unexpected response %+v, %v", resp, err)
+	}
+}
+
+func TestSplit_Reassemble(t *testing.T) {
+	req := &BidRequest{ID: "req", Impressions: []Impression{
+		{ID: "1", Banner: &Banner{}},
+		{ID: "2", Video: &Video{}},
+	}}
+	sp := (&Splitter{Impressions: true, MediaTypes: true}).Split(req)
+
+	resp, err := sp.Reassemble([]*BidResponse{
+		{ID: "req-1-banner", Currency: "USD", BidID: "x", SeatBids: []SeatBid{
+			{Seat: "s1", Bids: []Bid{{ID: "b", ImpID: "1"}, {ID: "b-2-video", ImpID: "1"}}},
+			{Seat: "s2"},
+		}},
+		nil,
+		{ID: "req-2-video", SeatBids: []SeatBid{
+			{Seat: "s1", Group: 1, Bids: []Bid{{ID: "b", ImpID: "2", MarkupType: MediaTypeBanner}}},
+			{Seat: "s3", Bids: []Bid{{ID: "c", ImpID: "2"}}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &BidResponse{ID: "req", Currency: "USD", BidID: "x", SeatBids: []SeatBid{
+		{Seat: "s1", Group: 1, Bids: []Bid{
+			{ID: "b", ImpID: "1", MarkupType: MediaTypeBanner},
+			{ID: "b-2-video", ImpID: "1", MarkupType: MediaTypeBanner},
+			{ID: "b-2-video-2", ImpID: "2", MarkupType: MediaTypeBanner},
+		}},
+		{Seat: "s3", Bids: []Bid{{ID: "c", ImpID: "2", MarkupType: MediaTypeVideo}}},
+	}}
+	if !reflect.DeepEqual(resp, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, resp)
+	}
+
+	// no-bids keep the reason of the first response
+	resp, err = sp.Reassemble([]*BidResponse{{ID: "req-2-video", NBR: NBRTechnicalError}, {ID: "req-1-banner"}})
+	if err != nil || resp.NBR != NBRTechnicalError || len(resp.SeatBids) != 0 {
+		t.Fatalf("unexpected response %+v, %v", resp, err)
+	}
+
+	for _, tc := range []struct {
+		responses []*BidResponse
+		err       error
+	}{
+		{[]*BidResponse{{ID: "req"}}, ErrSplitResponseID},
+		{[]*BidResponse{{ID: "req-1-banner", SeatBids: []SeatBid{{Bids: []Bid{{ID: "a", ImpID: "2"}}}}}}, ErrSplitImpID},
+		{[]*BidResponse{{ID: "req-1-banner"}, {ID: "req-2-video", Currency: "EUR"}}, ErrSplitCurrency},
+	} {
+		if _, err := sp.Reassemble(tc.responses); err != tc.err {
+			t.Errorf("expected %v, got %v", tc.err, err)
+		}
+	}
+}
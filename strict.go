@@ -0,0 +1,61 @@
+package openrtb
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Strict decoding errors
+var (
+	ErrStrictUnknownKey   = errors.New("openrtb: unknown key")
+	ErrStrictDuplicateKey = errors.New("openrtb: duplicate key")
+	ErrStrictEnum         = errors.New("openrtb: enum value out of range")
+)
+
+// Violation is a deviation from the specification found by strict decoding. Err is one of
+// the ErrStrict errors, ErrJSONUnexpected for wrong types or ErrJSONOutOfRange.
+type Violation struct {
+	Path string // JSON path of the value or key, e.g. "imp[0].banner.w"
+	Err  error
+}
+
+func (v Violation) Error() string {
+	if v.Path == "" {
+		return v.Err.Error()
+	}
+	return v.Err.Error() + " at " + v.Path
+}
+
+func (v Violation) Unwrap() error { return v.Err }
+
+// Violations is the error returned by DecodeStrict.
+type Violations []Violation
+
+func (vs Violations) Error() string {
+	switch len(vs) {
+	case 0:
+		return "openrtb: no violations"
+	case 1:
+		return vs[0].Error()
+	}
+	return vs[0].Error() + " (and " + strconv.Itoa(len(vs)-1) + " more)"
+}
+
+// DecodeStrict decodes an object from JSON data like DecodeJSON, for certifying partners
+// against the specification. It reports unknown keys outside of ext objects, including
+// keys which only match case-insensitively, duplicate keys, values of the wrong type and
+// enum values which are neither declared nor exchange-specific (500+).
+//
+// Invalid JSON is returned as is, all other deviations are collected and returned as
+// Violations, while the object is decoded as far as possible.
+func DecodeStrict(data []byte, v Object) error {
+	r := jsonReader{data: data, track: true, strict: true}
+	v.decodeJSON(&r)
+	if err := r.finish(); err != nil {
+		return err
+	}
+	if len(r.violations) != 0 {
+		return r.violations
+	}
+	return nil
+}
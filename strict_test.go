@@ -0,0 +1,94 @@
+package openrtb
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		data       string
+		violations Violations
+	}{
+		{"conforming", `{"id":"1","imp":[{"id":"1","banner":{"w":300}}],"ext":{"any":1}}`, nil},
+		{"unknown key", `{"id":"1","foo":1,"imp":[{"id":"1","banner":{"wmax":1}}]}`, Violations{
+			{"foo", ErrStrictUnknownKey},
+			{"imp[0].banner.wmax", ErrStrictUnknownKey},
+		}},
+		{"case-insensitive key", `{"ID":"1","tmax":100}`, Violations{
+			{"ID", ErrStrictUnknownKey},
+		}},
+		{"duplicate key", `{"id":"1","tmax":100,"imp":[{"id":"1","id":"2"}],"tmax":200}`, Violations{
+			{"imp[0].id", ErrStrictDuplicateKey},
+			{"tmax", ErrStrictDuplicateKey},
+		}},
+		{"wrong type", `{"id":1,"tmax":"100"}`, Violations{
+			{"id", ErrJSONUnexpected},
+			{"tmax", ErrJSONUnexpected},
+		}},
+		{"out of range", `{"id":"1","at":99999999999999999999}`, Violations{
+			{"at", ErrJSONOutOfRange},
+		}},
+		{"enum", `{"id":"1","at":3,"imp":[{"id":"1","banner":{"pos":8,"api":[3,500,99]}}]}`, Violations{
+			{"imp[0].banner.pos", ErrStrictEnum},
+			{"imp[0].banner.api[2]", ErrStrictEnum},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DecodeStrict([]byte(tc.data), new(BidRequest))
+			if tc.violations == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var vs Violations
+			if !errors.As(err, &vs) {
+				t.Fatalf("expected violations, got %v", err)
+			}
+			if !reflect.DeepEqual(vs, tc.violations) {
+				t.Fatalf("expected %v, got %v", tc.violations, vs)
+			}
+		})
+	}
+}
+
+func TestDecodeStrict_markupType(t *testing.T) {
+	var bid Bid
+	err := DecodeStrict([]byte(`{"id":"1","impid":"1","price":1,"mtype":9}`), &bid)
+	if vs, ok := err.(Violations); !ok || !reflect.DeepEqual(vs, Violations{{"mtype", ErrStrictEnum}}) {
+		t.Fatalf("expected %v at mtype, got %v", ErrStrictEnum, err)
+	}
+	if bid.MarkupType != 9 || bid.Price != 1 {
+		t.Fatalf("expected the bid to be decoded, got %+v", bid)
+	}
+
+	for _, mtype := range []string{"1", "4", "500"} {
+		if err := DecodeStrict([]byte(`{"id":"1","impid":"1","price":1,"mtype":`+mtype+`}`), new(Bid)); err != nil {
+			t.Errorf("mtype %s: %v", mtype, err)
+		}
+	}
+}
+
+func TestDecodeStrict_invalid(t *testing.T) {
+	err := DecodeStrict([]byte(`{"id":"1","foo":`), new(BidRequest))
+	var vs Violations
+	if err == nil || errors.As(err, &vs) {
+		t.Fatalf("expected syntax error, got %v", err)
+	}
+}
+
+func TestViolations_Error(t *testing.T) {
+	vs := Violations{{"foo", ErrStrictUnknownKey}, {"", ErrStrictEnum}}
+	if s := vs.Error(); s != "openrtb: unknown key at foo (and 1 more)" {
+		t.Fatalf("unexpected message %q", s)
+	}
+	if s := vs[1:].Error(); s != "openrtb: enum value out of range" {
+		t.Fatalf("unexpected message %q", s)
+	}
+	if !errors.Is(vs[0], ErrStrictUnknownKey) {
+		t.Fatal("expected violation to unwrap")
+	}
+}
@@ -0,0 +1,302 @@
+package openrtb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Fetcher errors
+var (
+	ErrFileNotFound = errors.New("openrtb: file not found")
+	ErrFileTooLarge = errors.New("openrtb: file exceeds the maximum size")
+	ErrFileURL      = errors.New("openrtb: invalid file URL")
+)
+
+// Fetcher retrieves ads.txt, app-ads.txt and sellers.json files by URL.
+type Fetcher interface {
+	// Fetch returns the contents at the given URL, or ErrFileNotFound if it doesn't exist.
+	Fetch(ctx context.Context, rawURL string) ([]byte, error)
+}
+
+// FetcherFunc is a function implementing Fetcher.
+type FetcherFunc func(ctx context.Context, rawURL string) ([]byte, error)
+
+// Fetch implements Fetcher
+func (f FetcherFunc) Fetch(ctx context.Context, rawURL string) ([]byte, error) { return f(ctx, rawURL) }
+
+// HTTPFetcher fetches files over HTTP(S).
+type HTTPFetcher struct {
+	Client  *http.Client // HTTP client to use, defaults to http.DefaultClient
+	MaxSize int64        // Maximum accepted file size, defaults to 10MB
+}
+
+// Fetch implements Fetcher
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	maxSize := f.MaxSize
+	if maxSize <= 0 {
+		maxSize = 10 << 20
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrFileNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("openrtb: fetching %s failed with status %d", rawURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err == nil && int64(len(data)) > maxSize {
+		return nil, ErrFileTooLarge
+	}
+	return data, err
+}
+
+// DirFetcher reads files from a local directory, laid out as <dir>/<host>/<path>,
+// e.g. <dir>/example.com/ads.txt. URLs with a host that is not a DNS name, or with a path
+// leaving the host's directory, are rejected with ErrFileURL.
+type DirFetcher string
+
+// Fetch implements Fetcher
+func (d DirFetcher) Fetch(_ context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	host := strings.ToLower(u.Hostname())
+	if !isDNSName(host) {
+		return nil, ErrFileURL
+	}
+
+	dir := filepath.Join(string(d), host)
+	name := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+u.Path)))
+	if rel, err := filepath.Rel(dir, name); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, ErrFileURL
+	}
+
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, ErrFileNotFound
+	}
+	return data, err
+}
+
+// SupplyAuthStatus is the authorization status of a supply chain hop.
+type SupplyAuthStatus int
+
+// SupplyAuthStatus values.
+const (
+	SupplyAuthUnknown      SupplyAuthStatus = iota // ads.txt could not be retrieved
+	SupplyAuthDirect                               // Authorized by a DIRECT ads.txt record
+	SupplyAuthReseller                             // Authorized by a RESELLER ads.txt record
+	SupplyAuthUnauthorized                         // Not listed in ads.txt or in the sellers.json of the advertising system
+)
+
+func (s SupplyAuthStatus) String() string {
+	switch s {
+	case SupplyAuthDirect:
+		return "DIRECT"
+	case SupplyAuthReseller:
+		return "RESELLER"
+	case SupplyAuthUnauthorized:
+		return "UNAUTHORIZED"
+	}
+	return "UNKNOWN"
+}
+
+// SupplyHopAuth is the authorization result for a single supply chain hop.
+type SupplyHopAuth struct {
+	Node   SupplyChainNode  // The checked node
+	Status SupplyAuthStatus // Authorization status according to ads.txt and sellers.json
+	Seller *Seller          // The matching sellers.json entry, if available
+}
+
+// SupplyAuthChecker verifies the supply path of bid requests against the ads.txt or app-ads.txt
+// file of the publisher and the sellers.json files of the advertising systems involved.
+type SupplyAuthChecker struct {
+	Fetcher Fetcher // Fetcher used to retrieve files, required
+
+	// ExchangeDomain is the canonical domain of the exchange issuing requests without a
+	// supply chain; such requests are checked as a single hop of this domain and Publisher.ID.
+	ExchangeDomain string
+
+	// AppDomain resolves the developer domain hosting the app-ads.txt file of an app. It defaults
+	// to App.Domain or App.Publisher.Domain. Apps are usually identified by their store listing
+	// only, so callers checking app requests must provide a resolver which looks up the developer
+	// URL of the listing via App.Bundle or App.StoreURL; without it such requests are reported as
+	// SupplyAuthUnknown.
+	AppDomain func(ctx context.Context, app *App) (string, error)
+}
+
+// Check returns the authorization status of each hop of the request's supply chain.
+// Fetch failures result in SupplyAuthUnknown, an error is only returned if the context is done.
+func (c *SupplyAuthChecker) Check(ctx context.Context, req *BidRequest) ([]SupplyHopAuth, error) {
+	nodes, err := c.supplyNodes(req)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, path, err := c.publisherDomain(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var adsTxt *AdsTxt
+	if domain != "" {
+		if adsTxt, err = c.fetchAdsTxt(ctx, domain, path); err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	sellers := make(map[string]*SellersJSON)
+	hops := make([]SupplyHopAuth, 0, len(nodes))
+	for _, node := range nodes {
+		hop := SupplyHopAuth{Node: node}
+
+		if adsTxt != nil {
+			hop.Status = SupplyAuthUnauthorized
+			if rec := adsTxt.Find(node.ASI, node.SID); rec != nil && rec.Relationship == AdsTxtDirect {
+				hop.Status = SupplyAuthDirect
+			} else if rec != nil {
+				hop.Status = SupplyAuthReseller
+			}
+		}
+
+		asi := strings.ToLower(node.ASI)
+		sj, ok := sellers[asi]
+		if !ok {
+			if sj, err = c.fetchSellersJSON(ctx, asi); err != nil && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			sellers[asi] = sj
+		}
+		if sj != nil {
+			if hop.Seller = sj.Find(node.SID); hop.Seller == nil {
+				hop.Status = SupplyAuthUnauthorized
+			}
+		}
+
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
+
+func (c *SupplyAuthChecker) supplyNodes(req *BidRequest) ([]SupplyChainNode, error) {
+	if req.Source != nil {
+		sc, err := req.Source.GetSupplyChain()
+		if err != nil {
+			return nil, err
+		}
+		if sc != nil && len(sc.Node) != 0 {
+			return sc.Node, nil
+		}
+	}
+
+	var pub *Publisher
+	if req.Site != nil {
+		pub = req.Site.Publisher
+	} else if req.App != nil {
+		pub = req.App.Publisher
+	}
+	if c.ExchangeDomain == "" || pub == nil || pub.ID == "" {
+		return nil, nil
+	}
+	return []SupplyChainNode{{ASI: c.ExchangeDomain, SID: pub.ID, HP: 1}}, nil
+}
+
+// publisherDomain returns the domain and the file name of the publisher's authorized sellers list.
+func (c *SupplyAuthChecker) publisherDomain(ctx context.Context, req *BidRequest) (string, string, error) {
+	if site := req.Site; site != nil {
+		domain := site.Domain
+		if domain == "" && site.Publisher != nil {
+			domain = site.Publisher.Domain
+		}
+		if domain == "" && site.Page != "" {
+			if u, err := url.Parse(site.Page); err == nil {
+				domain = u.Hostname()
+			}
+		}
+		return adsTxtHost(domain), "/ads.txt", nil
+	}
+
+	if app := req.App; app != nil {
+		var domain string
+		if c.AppDomain != nil {
+			var err error
+			if domain, err = c.AppDomain(ctx, app); err != nil && ctx.Err() != nil {
+				return "", "", ctx.Err()
+			}
+		} else if app.Domain != "" {
+			domain = app.Domain
+		} else if app.Publisher != nil {
+			domain = app.Publisher.Domain
+		}
+		return adsTxtHost(domain), "/app-ads.txt", nil
+	}
+	return "", "", nil
+}
+
+func (c *SupplyAuthChecker) fetchAdsTxt(ctx context.Context, domain, path string) (*AdsTxt, error) {
+	data, err := c.Fetcher.Fetch(ctx, "https://"+domain+path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdsTxt(bytes.NewReader(data))
+}
+
+func (c *SupplyAuthChecker) fetchSellersJSON(ctx context.Context, domain string) (*SellersJSON, error) {
+	data, err := c.Fetcher.Fetch(ctx, "https://"+domain+"/sellers.json")
+	if err != nil {
+		return nil, err
+	}
+	return ParseSellersJSON(bytes.NewReader(data))
+}
+
+// adsTxtHost returns the host serving the ads.txt file of a domain: the lower-cased domain
+// without a "www." prefix. Other subdomains are not reduced to the root domain, as that would
+// require the public suffix list; they are checked against their own ads.txt file.
+func adsTxtHost(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	return strings.TrimPrefix(domain, "www.")
+}
+
+// isDNSName reports whether the host is a valid DNS name: dot-separated labels of 1 to 63
+// letters, digits and hyphens, not starting or ending with a hyphen.
+func isDNSName(host string) bool {
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package openrtb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDirFetcher_Fetch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "example.com"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.com", "ads.txt"), []byte("ads"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	f := DirFetcher(dir)
+	if data, err := f.Fetch(ctx, "https://Example.com/ads.txt"); err != nil || string(data) != "ads" {
+		t.Fatalf("unexpected result %q, %v", data, err)
+	}
+	if data, err := f.Fetch(ctx, "https://example.com/../secret.txt"); err != ErrFileNotFound {
+		t.Fatalf("expected ErrFileNotFound, got %q, %v", data, err)
+	}
+	if _, err := f.Fetch(ctx, "https://example.com/sellers.json"); err != ErrFileNotFound {
+		t.Fatalf("expected ErrFileNotFound, got %v", err)
+	}
+	for _, u := range []string{"https://../secret.txt", "https://./secret.txt", "file:///secret.txt", "https://-x.com/ads.txt", "https://a_b.com/ads.txt"} {
+		if data, err := f.Fetch(ctx, u); err != ErrFileURL {
+			t.Errorf("%s: expected ErrFileURL, got %q, %v", u, data, err)
+		}
+	}
+}
+
+func TestHTTPFetcher_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ads.txt":
+			w.Write([]byte("0123456789"))
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	f := &HTTPFetcher{MaxSize: 10}
+	if data, err := f.Fetch(ctx, srv.URL+"/ads.txt"); err != nil || string(data) != "0123456789" {
+		t.Fatalf("unexpected result %q, %v", data, err)
+	}
+	if _, err := f.Fetch(ctx, srv.URL+"/sellers.json"); err != ErrFileNotFound {
+		t.Fatalf("expected ErrFileNotFound, got %v", err)
+	}
+	if _, err := f.Fetch(ctx, srv.URL+"/error"); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+
+	f.MaxSize = 9
+	if data, err := f.Fetch(ctx, srv.URL+"/ads.txt"); err != ErrFileTooLarge {
+		t.Fatalf("expected ErrFileTooLarge, got %q, %v", data, err)
+	}
+}
+
+func TestSupplyAuthChecker_Check(t *testing.T) {
+	files := map[string]string{
+		"https://publisher.com/ads.txt":      "exchange1.com, 1234, DIRECT\nexchange2.com, abcd, RESELLER\n",
+		"https://exchange1.com/sellers.json": `{"version":"1.0","sellers":[{"seller_id":"1234","seller_type":"PUBLISHER"}]}`,
+		"https://exchange2.com/sellers.json": `{"version":"1.0","sellers":[{"seller_id":"other","seller_type":"PUBLISHER"}]}`,
+		"https://developer.com/app-ads.txt":  "exchange1.com, 1234, RESELLER\n",
+	}
+	c := &SupplyAuthChecker{
+		Fetcher: FetcherFunc(func(_ context.Context, u string) ([]byte, error) {
+			if data, ok := files[u]; ok {
+				return []byte(data), nil
+			}
+			return nil, ErrFileNotFound
+		}),
+		ExchangeDomain: "exchange1.com",
+	}
+	ctx := context.Background()
+
+	req := &BidRequest{
+		Site: &Site{Inventory: Inventory{Domain: "www.Publisher.com"}},
+		Source: &Source{Ext: []byte(`{"schain":{"ver":"1.0","complete":1,"nodes":[` +
+			`{"asi":"exchange1.com","sid":"1234","hp":1},{"asi":"exchange2.com","sid":"abcd","hp":1},{"asi":"exchange3.com","sid":"x","hp":1}]}}`)},
+	}
+	hops, err := c.Check(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []SupplyAuthStatus{SupplyAuthDirect, SupplyAuthUnauthorized, SupplyAuthUnauthorized}
+	if len(hops) != len(exp) {
+		t.Fatalf("expected %d hops, got %d", len(exp), len(hops))
+	}
+	for i, hop := range hops {
+		if hop.Status != exp[i] {
+			t.Errorf("hop %d: expected %s, got %s", i, exp[i], hop.Status)
+		}
+	}
+	if hops[0].Seller == nil || hops[1].Seller != nil {
+		t.Errorf("unexpected sellers %+v, %+v", hops[0].Seller, hops[1].Seller)
+	}
+
+	app := &BidRequest{App: &App{Inventory: Inventory{Publisher: &Publisher{ID: "1234"}}, Bundle: "com.example.app"}}
+	if hops, err := c.Check(ctx, app); err != nil || len(hops) != 1 || hops[0].Status != SupplyAuthUnknown {
+		t.Fatalf("unexpected result without app domain %+v, %v", hops, err)
+	}
+	c.AppDomain = func(_ context.Context, app *App) (string, error) { return "developer.com", nil }
+	if hops, err := c.Check(ctx, app); err != nil || len(hops) != 1 || hops[0].Status != SupplyAuthReseller {
+		t.Fatalf("unexpected result with app domain %+v, %v", hops, err)
+	}
+}
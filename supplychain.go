@@ -2,6 +2,10 @@ package openrtb
 
 import (
 	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // This object is composed of a set of nodes where each node represents a specific entity that participates in
@@ -25,3 +29,208 @@ type SupplyChainNode struct {
 	HP        int             `json:"hp,omitempty"`     // Indicates whether this node will be involved in the flow of payment for the inventory. When set to 1, the advertising system in the asi field pays the seller in the sid field, who is responsible for paying the previous node in the chain. When set to 0, this node is not involved in the flow of payment for the inventory.
 	Ext       json.RawMessage `json:"ext,omitempty"`
 }
+
+// Validation errors
+var (
+	ErrInvalidSupplyChainString = errors.New("openrtb: invalid supply chain string")
+)
+
+// String returns the compact string representation of the supply chain, as used in HTTP headers
+// and URL query parameters, e.g. "1.0,1!exchange1.com,1234,1,bid-request-1,publisher,publisher.com".
+// All values are URL-encoded, so they may safely contain ',' and '!'.
+func (sc *SupplyChain) String() string {
+	var b strings.Builder
+	b.WriteString(escapeSupplyChain(sc.Version))
+	b.WriteByte(',')
+	b.WriteString(strconv.Itoa(sc.Complete))
+	for i := range sc.Node {
+		b.WriteByte('!')
+		sc.Node[i].writeTo(&b)
+	}
+	return b.String()
+}
+
+func (n *SupplyChainNode) writeTo(b *strings.Builder) {
+	fields := []string{
+		escapeSupplyChain(n.ASI),
+		escapeSupplyChain(n.SID),
+		strconv.Itoa(n.HP),
+		escapeSupplyChain(n.RequestId),
+		escapeSupplyChain(n.Name),
+		escapeSupplyChain(n.Domain),
+		escapeSupplyChain(string(n.Ext)),
+	}
+
+	// trailing optional fields may be omitted
+	last := len(fields)
+	for last > 3 && fields[last-1] == "" {
+		last--
+	}
+	b.WriteString(strings.Join(fields[:last], ","))
+}
+
+// ParseSupplyChain parses the compact string representation of a supply chain.
+func ParseSupplyChain(s string) (*SupplyChain, error) {
+	parts := strings.Split(s, "!")
+
+	head := strings.Split(parts[0], ",")
+	if len(head) != 2 {
+		return nil, ErrInvalidSupplyChainString
+	}
+
+	version, err := url.PathUnescape(head[0])
+	if err != nil {
+		return nil, ErrInvalidSupplyChainString
+	}
+	complete, err := strconv.Atoi(head[1])
+	if err != nil {
+		return nil, ErrInvalidSupplyChainString
+	}
+
+	sc := &SupplyChain{Version: version, Complete: complete}
+	for _, part := range parts[1:] {
+		node, err := parseSupplyChainNode(part)
+		if err != nil {
+			return nil, err
+		}
+		sc.Node = append(sc.Node, *node)
+	}
+	return sc, nil
+}
+
+func parseSupplyChainNode(s string) (*SupplyChainNode, error) {
+	fields := strings.Split(s, ",")
+	if len(fields) < 3 || len(fields) > 7 {
+		return nil, ErrInvalidSupplyChainString
+	}
+
+	vals := make([]string, 7)
+	for i, f := range fields {
+		v, err := url.PathUnescape(f)
+		if err != nil {
+			return nil, ErrInvalidSupplyChainString
+		}
+		vals[i] = v
+	}
+
+	hp, err := strconv.Atoi(vals[2])
+	if err != nil {
+		return nil, ErrInvalidSupplyChainString
+	}
+
+	node := &SupplyChainNode{
+		ASI:       vals[0],
+		SID:       vals[1],
+		HP:        hp,
+		RequestId: vals[3],
+		Name:      vals[4],
+		Domain:    vals[5],
+	}
+	if vals[6] != "" {
+		node.Ext = json.RawMessage(vals[6])
+	}
+	return node, nil
+}
+
+// escapeSupplyChain URL-encodes all characters except the unreserved ones of RFC 3986.
+func escapeSupplyChain(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if !isUnreserved(s[i]) {
+			n++
+		}
+	}
+	if n == 0 {
+		return s
+	}
+
+	b := make([]byte, 0, len(s)+2*n)
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; isUnreserved(c) {
+			b = append(b, c)
+		} else {
+			b = append(b, '%', hex[c>>4], hex[c&15])
+		}
+	}
+	return string(b)
+}
+
+func isUnreserved(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~'
+}
+
+// Validation errors
+var (
+	ErrInvalidSupplyChainVersion  = errors.New("openrtb: supply chain version unsupported")
+	ErrInvalidSupplyChainComplete = errors.New("openrtb: supply chain complete flag must be 0 or 1")
+	ErrInvalidSupplyChainNoNodes  = errors.New("openrtb: supply chain has no nodes")
+	ErrInvalidSupplyChainLoop     = errors.New("openrtb: supply chain contains a node more than once")
+	ErrInvalidSupplyChainNoASI    = errors.New("openrtb: supply chain node is missing asi")
+	ErrInvalidSupplyChainNoSID    = errors.New("openrtb: supply chain node is missing sid")
+	ErrInvalidSupplyChainHP       = errors.New("openrtb: supply chain node hp must be 1")
+)
+
+// SupplyChainVersion is the supported version of the SupplyChain object
+const SupplyChainVersion = "1.0"
+
+// Validate the supply chain
+func (sc *SupplyChain) Validate() error {
+	if sc.Version != SupplyChainVersion {
+		return ErrInvalidSupplyChainVersion
+	} else if sc.Complete != 0 && sc.Complete != 1 {
+		return ErrInvalidSupplyChainComplete
+	} else if len(sc.Node) == 0 {
+		return ErrInvalidSupplyChainNoNodes
+	}
+
+	for i := range sc.Node {
+		node := sc.Node[i]
+		if err := (&node).Validate(); err != nil {
+			return err
+		}
+		if sc.indexOf(&node) != i {
+			return ErrInvalidSupplyChainLoop
+		}
+	}
+	return nil
+}
+
+// Validate required attributes
+func (n *SupplyChainNode) Validate() error {
+	if n.ASI == "" {
+		return ErrInvalidSupplyChainNoASI
+	} else if n.SID == "" {
+		return ErrInvalidSupplyChainNoSID
+	} else if n.HP != 1 {
+		return ErrInvalidSupplyChainHP
+	}
+	return nil
+}
+
+// Contains returns true if the chain already contains a node of the same
+// advertising system and seller ID.
+func (sc *SupplyChain) Contains(node *SupplyChainNode) bool {
+	return sc.indexOf(node) > -1
+}
+
+func (sc *SupplyChain) indexOf(node *SupplyChainNode) int {
+	for i, n := range sc.Node {
+		if strings.EqualFold(n.ASI, node.ASI) && n.SID == node.SID {
+			return i
+		}
+	}
+	return -1
+}
+
+// AppendNode appends a node to the end of the chain, unless the chain already contains it.
+// Returns true if the node was appended. The complete flag is left unchanged, as an intermediary
+// can only extend a chain, not complete it.
+func (sc *SupplyChain) AppendNode(node SupplyChainNode) bool {
+	if sc.Contains(&node) {
+		return false
+	}
+	sc.Node = append(sc.Node, node)
+	return true
+}
@@ -0,0 +1,108 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Examples from the SupplyChain object specification.
+func TestParseSupplyChain(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp *SupplyChain
+	}{
+		{
+			s: "1.0,1!exchange1.com,1234,1,bid-request-1,publisher,publisher.com",
+			exp: &SupplyChain{Version: "1.0", Complete: 1, Node: []SupplyChainNode{
+				{ASI: "exchange1.com", SID: "1234", HP: 1, RequestId: "bid-request-1", Name: "publisher", Domain: "publisher.com"},
+			}},
+		},
+		{
+			s: "1.0,1!exchange1.com,1234,1,bid-request-1,publisher,publisher.com!exchange2.com,abcd,1,bid-request-2,intermediary,intermediary.com",
+			exp: &SupplyChain{Version: "1.0", Complete: 1, Node: []SupplyChainNode{
+				{ASI: "exchange1.com", SID: "1234", HP: 1, RequestId: "bid-request-1", Name: "publisher", Domain: "publisher.com"},
+				{ASI: "exchange2.com", SID: "abcd", HP: 1, RequestId: "bid-request-2", Name: "intermediary", Domain: "intermediary.com"},
+			}},
+		},
+		{
+			s: "1.0,1!exchange1.com,1234,1,,,",
+			exp: &SupplyChain{Version: "1.0", Complete: 1, Node: []SupplyChainNode{
+				{ASI: "exchange1.com", SID: "1234", HP: 1},
+			}},
+		},
+		{
+			s: "1.0,1!exchange1.com,1234%21abcd,1,bid-request-1,publisher%2c%20Inc.,publisher.com!exchange2.com,abcd,1,bid-request-2,intermediary,intermediary.com",
+			exp: &SupplyChain{Version: "1.0", Complete: 1, Node: []SupplyChainNode{
+				{ASI: "exchange1.com", SID: "1234!abcd", HP: 1, RequestId: "bid-request-1", Name: "publisher, Inc.", Domain: "publisher.com"},
+				{ASI: "exchange2.com", SID: "abcd", HP: 1, RequestId: "bid-request-2", Name: "intermediary", Domain: "intermediary.com"},
+			}},
+		},
+		{
+			s: "1.0,0!exchange1.com,1234,1,,,,%7B%22k%22%3A1%7D",
+			exp: &SupplyChain{Version: "1.0", Node: []SupplyChainNode{
+				{ASI: "exchange1.com", SID: "1234", HP: 1, Ext: json.RawMessage(`{"k":1}`)},
+			}},
+		},
+	} {
+		sc, err := ParseSupplyChain(tc.s)
+		if err != nil {
+			t.Errorf("%s: %v", tc.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(sc, tc.exp) {
+			t.Errorf("%s: expected %+v, got %+v", tc.s, tc.exp, sc)
+			continue
+		}
+
+		rt, err := ParseSupplyChain(sc.String())
+		if err != nil {
+			t.Errorf("%s: %v", sc.String(), err)
+		} else if !reflect.DeepEqual(rt, sc) {
+			t.Errorf("%s: round-trip expected %+v, got %+v", tc.s, sc, rt)
+		}
+	}
+}
+
+func TestSupplyChain_String(t *testing.T) {
+	sc := &SupplyChain{Version: "1.0", Complete: 1, Node: []SupplyChainNode{
+		{ASI: "exchange1.com", SID: "1234!abcd", HP: 1, RequestId: "bid-request-1", Name: "publisher, Inc.", Domain: "publisher.com"},
+		{ASI: "exchange2.com", SID: "abcd", HP: 1},
+	}}
+	if exp, got := "1.0,1!exchange1.com,1234%21abcd,1,bid-request-1,publisher%2C%20Inc.,publisher.com!exchange2.com,abcd,1", sc.String(); got != exp {
+		t.Fatalf("expected %s, got %s", exp, got)
+	}
+}
+
+func TestParseSupplyChain_invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"1.0",
+		"1.0,x",
+		"1.0,1!exchange1.com,1234",
+		"1.0,1!exchange1.com,1234,x",
+		"1.0,1!exchange1.com,1234,1,a,b,c,d,e",
+		"1.0,1!exchange1.com,12%zz,1",
+	} {
+		if _, err := ParseSupplyChain(s); err != ErrInvalidSupplyChainString {
+			t.Errorf("%q: expected ErrInvalidSupplyChainString, got %v", s, err)
+		}
+	}
+}
+
+func TestSupplyChainNode_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		node SupplyChainNode
+		err  error
+	}{
+		{SupplyChainNode{ASI: "exchange1.com", SID: "1234", HP: 1}, nil},
+		{SupplyChainNode{SID: "1234", HP: 1}, ErrInvalidSupplyChainNoASI},
+		{SupplyChainNode{ASI: "exchange1.com", HP: 1}, ErrInvalidSupplyChainNoSID},
+		{SupplyChainNode{ASI: "exchange1.com", SID: "1234"}, ErrInvalidSupplyChainHP},
+		{SupplyChainNode{ASI: "exchange1.com", SID: "1234", HP: 2}, ErrInvalidSupplyChainHP},
+	} {
+		if err := tc.node.Validate(); err != tc.err {
+			t.Errorf("%+v: expected %v, got %v", tc.node, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package openrtb
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Validation errors
+var (
+	ErrInvalidTCFConsent = errors.New("openrtb: invalid TCF consent string")
+	ErrTruncatedBits     = errors.New("openrtb: truncated bit-encoded string")
+)
+
+// TCFConsent is the decoded core segment of an IAB Europe Transparency and Consent Framework v2 consent string,
+// as carried in User.Consent or in the TCF EU v2 section of a GPP string.
+type TCFConsent struct {
+	Version             int       // Version of the TC string format, always 2
+	Created             time.Time // When the TC string was created
+	LastUpdated         time.Time // When the TC string was last updated
+	CMPID               int       // Consent Management Platform ID
+	CMPVersion          int       // Consent Management Platform version
+	ConsentScreen       int       // CMP screen number on which consent was given
+	ConsentLanguage     string    // Two-letter ISO 639-1 language code of the consent screen
+	VendorListVersion   int       // Version of the Global Vendor List used
+	PolicyVersion       int       // Version of the TCF policy used
+	IsServiceSpecific   bool      // Whether the signals are service-specific or global
+	UseNonStandardTexts bool      // Whether the CMP used non-standard texts
+	PurposeOneTreatment bool      // Whether Purpose 1 was disclosed differently (not applicable in this jurisdiction)
+	PublisherCC         string    // Two-letter ISO 3166-1 country code of the publisher
+
+	specialFeatures uint16
+	purposesConsent uint32
+	purposesLI      uint32
+	vendorsConsent  []bool
+	vendorsLI       []bool
+}
+
+// ParseTCFConsent decodes the core segment of a TCF v2 consent string. Optional segments
+// (disclosed vendors, publisher TC) are ignored.
+func ParseTCFConsent(s string) (*TCFConsent, error) {
+	if i := strings.IndexByte(s, '.'); i > -1 {
+		s = s[:i]
+	}
+
+	r, err := newBitReader(s)
+	if err != nil {
+		return nil, ErrInvalidTCFConsent
+	}
+
+	c := new(TCFConsent)
+	if err := c.decode(r); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *TCFConsent) decode(r *bitReader) error {
+	c.Version = r.int(6)
+	if r.err == nil && c.Version != 2 {
+		return ErrInvalidTCFConsent
+	}
+
+	c.Created = r.deciseconds()
+	c.LastUpdated = r.deciseconds()
+	c.CMPID = r.int(12)
+	c.CMPVersion = r.int(12)
+	c.ConsentScreen = r.int(6)
+	c.ConsentLanguage = r.letters(2)
+	c.VendorListVersion = r.int(12)
+	c.PolicyVersion = r.int(6)
+	c.IsServiceSpecific = r.bool()
+	c.UseNonStandardTexts = r.bool()
+	c.specialFeatures = uint16(r.int(12))
+	c.purposesConsent = uint32(r.int(24))
+	c.purposesLI = uint32(r.int(24))
+	c.PurposeOneTreatment = r.bool()
+	c.PublisherCC = r.letters(2)
+	c.vendorsConsent = r.vendors()
+	c.vendorsLI = r.vendors()
+	return r.err
+}
+
+// SpecialFeatureOptIn returns true if the user opted in to the given special feature (1-12)
+func (c *TCFConsent) SpecialFeatureOptIn(id int) bool {
+	return id > 0 && id <= 12 && c.specialFeatures&(1<<(12-id)) != 0
+}
+
+// PurposeConsent returns true if the user consented to the given purpose (1-24)
+func (c *TCFConsent) PurposeConsent(id int) bool {
+	return id > 0 && id <= 24 && c.purposesConsent&(1<<(24-id)) != 0
+}
+
+// PurposeLegitimateInterest returns true if legitimate interest was established for the given purpose (1-24)
+func (c *TCFConsent) PurposeLegitimateInterest(id int) bool {
+	return id > 0 && id <= 24 && c.purposesLI&(1<<(24-id)) != 0
+}
+
+// VendorConsent returns true if the user consented to the given vendor ID
+func (c *TCFConsent) VendorConsent(id int) bool {
+	return id > 0 && id < len(c.vendorsConsent) && c.vendorsConsent[id]
+}
+
+// VendorLegitimateInterest returns true if legitimate interest was established for the given vendor ID
+func (c *TCFConsent) VendorLegitimateInterest(id int) bool {
+	return id > 0 && id < len(c.vendorsLI) && c.vendorsLI[id]
+}
+
+// bitReader reads big-endian bit fields from a base64url encoded string, as used by the
+// TCF and GPP formats. The first error is sticky, all subsequent reads return zero values.
+type bitReader struct {
+	b   []byte // 6-bit values
+	pos int
+	err error
+}
+
+func newBitReader(s string) (*bitReader, error) {
+	s = strings.TrimRight(s, "=")
+	if s == "" {
+		return nil, ErrTruncatedBits
+	}
+
+	b := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			b[i] = c - 'A'
+		case c >= 'a' && c <= 'z':
+			b[i] = c - 'a' + 26
+		case c >= '0' && c <= '9':
+			b[i] = c - '0' + 52
+		case c == '-' || c == '+':
+			b[i] = 62
+		case c == '_' || c == '/':
+			b[i] = 63
+		default:
+			return nil, errors.New("openrtb: invalid base64url character")
+		}
+	}
+	return &bitReader{b: b}, nil
+}
+
+func (r *bitReader) len() int { return len(r.b) * 6 }
+
+func (r *bitReader) bit() int {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos >= r.len() {
+		r.err = ErrTruncatedBits
+		return 0
+	}
+	v := r.b[r.pos/6] >> (5 - r.pos%6) & 1
+	r.pos++
+	return int(v)
+}
+
+func (r *bitReader) bool() bool { return r.bit() == 1 }
+
+func (r *bitReader) int(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		v = v<<1 | r.bit()
+	}
+	return v
+}
+
+func (r *bitReader) int64(n int) int64 {
+	var v int64
+	for i := 0; i < n; i++ {
+		v = v<<1 | int64(r.bit())
+	}
+	return v
+}
+
+func (r *bitReader) deciseconds() time.Time {
+	ds := r.int64(36)
+	if ds == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ds * 100).UTC()
+}
+
+func (r *bitReader) letters(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = 'A' + byte(r.int(6))
+	}
+	return string(b)
+}
+
+// fibonacci reads a Fibonacci-coded integer, terminated by two consecutive 1 bits.
+func (r *bitReader) fibonacci() int {
+	v, a, b, prev := 0, 1, 2, 0
+	for r.err == nil {
+		bit := r.bit()
+		if bit == 1 && prev == 1 {
+			return v
+		}
+		if bit == 1 {
+			v += a
+		}
+		prev = bit
+		a, b = b, a+b
+	}
+	return 0
+}
+
+// vendors reads a TCF vendor section, encoded either as a bit field or as ranges.
+func (r *bitReader) vendors() []bool {
+	max := r.int(16)
+	isRange := r.bool()
+	if r.err != nil {
+		return nil
+	}
+
+	set := make([]bool, max+1)
+	if !isRange {
+		for id := 1; id <= max; id++ {
+			set[id] = r.bool()
+		}
+		return set
+	}
+
+	for n := r.int(12); n > 0 && r.err == nil; n-- {
+		isARange := r.bool()
+		start := r.int(16)
+		end := start
+		if isARange {
+			end = r.int(16)
+		}
+		for id := start; id <= end && id <= max; id++ {
+			if id > 0 {
+				set[id] = true
+			}
+		}
+	}
+	return set
+}
@@ -0,0 +1,49 @@
+package openrtb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTCFConsent(t *testing.T) {
+	c, err := ParseTCFConsent("CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2022, 4, 20, 22, 0, 0, 0, time.UTC)
+	if c.Version != 2 || !c.Created.Equal(created) || !c.LastUpdated.Equal(created) {
+		t.Fatalf("unexpected version or dates %+v", c)
+	}
+	if c.CMPID != 31 || c.CMPVersion != 640 || c.ConsentScreen != 1 || c.ConsentLanguage != "EN" {
+		t.Fatalf("unexpected CMP %+v", c)
+	}
+	if c.VendorListVersion != 126 || c.PolicyVersion != 2 || !c.IsServiceSpecific || c.PublisherCC != "DE" {
+		t.Fatalf("unexpected policy %+v", c)
+	}
+	if c.PurposeConsent(1) || c.VendorConsent(1) || c.SpecialFeatureOptIn(1) {
+		t.Fatal("expected no consent")
+	}
+}
+
+func TestParseTCFConsent_purposes(t *testing.T) {
+	c, err := ParseTCFConsent("COvFyGBOvFyGBAbAAAENAPCAAOAAAAAAAAAAAAAAAAAA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := 1; id <= 24; id++ {
+		if exp := id <= 3; c.PurposeConsent(id) != exp {
+			t.Errorf("purpose %d: expected consent %v", id, exp)
+		}
+	}
+	if c.CMPID != 27 || c.VendorListVersion != 15 {
+		t.Fatalf("unexpected CMP %+v", c)
+	}
+}
+
+func TestParseTCFConsent_invalid(t *testing.T) {
+	for _, s := range []string{"", "C", "CPXxRfAP", "CPXxRf!PXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"} {
+		if _, err := ParseTCFConsent(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
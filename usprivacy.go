@@ -0,0 +1,72 @@
+package openrtb
+
+import (
+	"errors"
+)
+
+// Validation errors
+var (
+	ErrInvalidUSPrivacy = errors.New("openrtb: invalid US privacy string")
+)
+
+// USPrivacyFlag is a single signal of a US Privacy (CCPA) string.
+type USPrivacyFlag byte
+
+// USPrivacyFlag values.
+const (
+	USPrivacyYes           USPrivacyFlag = 'Y'
+	USPrivacyNo            USPrivacyFlag = 'N'
+	USPrivacyNotApplicable USPrivacyFlag = '-'
+)
+
+func (f USPrivacyFlag) valid() bool {
+	return f == USPrivacyYes || f == USPrivacyNo || f == USPrivacyNotApplicable
+}
+
+// USPrivacy is the decoded form of the 4-character US Privacy string (e.g. "1YNN") communicated in
+// Regulations.UsPrivacy. Refer to the IAB CCPA Compliance Framework for more information.
+type USPrivacy struct {
+	Version int           // Version of the US Privacy string specification, currently 1
+	Notice  USPrivacyFlag // Explicit notice of the opportunity to opt out has been provided
+	OptOut  USPrivacyFlag // The user has opted out of the sale of personal information
+	LSPA    USPrivacyFlag // The publisher is a signatory to the IAB Limited Service Provider Agreement
+}
+
+// ParseUSPrivacy parses a US Privacy string.
+func ParseUSPrivacy(s string) (*USPrivacy, error) {
+	if len(s) != 4 || s[0] < '1' || s[0] > '9' {
+		return nil, ErrInvalidUSPrivacy
+	}
+
+	u := &USPrivacy{
+		Version: int(s[0] - '0'),
+		Notice:  USPrivacyFlag(upper(s[1])),
+		OptOut:  USPrivacyFlag(upper(s[2])),
+		LSPA:    USPrivacyFlag(upper(s[3])),
+	}
+	if u.Version != 1 || !u.Notice.valid() || !u.OptOut.valid() || !u.LSPA.valid() {
+		return nil, ErrInvalidUSPrivacy
+	}
+	return u, nil
+}
+
+// String returns the encoded US Privacy string
+func (u *USPrivacy) String() string {
+	return string([]byte{byte('0' + u.Version), byte(u.Notice), byte(u.OptOut), byte(u.LSPA)})
+}
+
+// NoticeGiven returns true if explicit notice has been provided to the user
+func (u *USPrivacy) NoticeGiven() bool { return u.Notice == USPrivacyYes }
+
+// IsOptOut returns true if the user has opted out of the sale of personal information
+func (u *USPrivacy) IsOptOut() bool { return u.OptOut == USPrivacyYes }
+
+// IsLSPACovered returns true if the transaction is covered by the Limited Service Provider Agreement
+func (u *USPrivacy) IsLSPACovered() bool { return u.LSPA == USPrivacyYes }
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
@@ -0,0 +1,34 @@
+package openrtb
+
+import "testing"
+
+func TestParseUSPrivacy(t *testing.T) {
+	for _, tc := range []struct {
+		s              string
+		notice, optOut bool
+		lspa           bool
+	}{
+		{"1YNN", true, false, false},
+		{"1YYY", true, true, true},
+		{"1NYN", false, true, false},
+		{"1---", false, false, false},
+		{"1yyn", true, true, false},
+	} {
+		u, err := ParseUSPrivacy(tc.s)
+		if err != nil {
+			t.Errorf("%s: %v", tc.s, err)
+			continue
+		}
+		if u.NoticeGiven() != tc.notice || u.IsOptOut() != tc.optOut || u.IsLSPACovered() != tc.lspa {
+			t.Errorf("%s: unexpected flags %+v", tc.s, u)
+		}
+	}
+}
+
+func TestParseUSPrivacy_invalid(t *testing.T) {
+	for _, s := range []string{"", "1YN", "1YNNN", "2YNN", "1YXN"} {
+		if _, err := ParseUSPrivacy(s); err != ErrInvalidUSPrivacy {
+			t.Errorf("%q: expected ErrInvalidUSPrivacy, got %v", s, err)
+		}
+	}
+}
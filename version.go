@@ -0,0 +1,107 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// ErrUnsupportedVersion is returned when converting requests to unknown OpenRTB versions.
+var ErrUnsupportedVersion = errors.New("openrtb: unsupported OpenRTB version")
+
+// ConvertVersion moves the attributes which OpenRTB 2.6 promoted from extensions between their
+// 2.5 and 2.6 locations, so that a bidder implementing the given version ("2.5" or "2.6") finds them:
+// source.schain, regs.gdpr, regs.us_privacy, user.consent and user.eids.
+func (req *BidRequest) ConvertVersion(version string) error {
+	var toExt bool
+	switch version {
+	case "2.5":
+		toExt = true
+	case "2.6":
+	default:
+		return ErrUnsupportedVersion
+	}
+
+	if src := req.Source; src != nil {
+		loc := SupplyChainInSource
+		if toExt {
+			loc = SupplyChainInSourceExt
+		}
+		sc, err := src.GetSupplyChain()
+		if err != nil {
+			return err
+		}
+		if sc != nil {
+			if err := src.SetSupplyChain(sc, loc); err != nil {
+				return err
+			}
+		}
+	}
+	if regs := req.Regulations; regs != nil {
+		if err := moveExt(&regs.Ext, "gdpr", &regs.GDPR, toExt); err != nil {
+			return err
+		}
+		if err := moveExt(&regs.Ext, "us_privacy", &regs.UsPrivacy, toExt); err != nil {
+			return err
+		}
+	}
+	if user := req.User; user != nil {
+		if err := moveExt(&user.Ext, "consent", &user.Consent, toExt); err != nil {
+			return err
+		}
+		if err := moveExt(&user.Ext, "eids", &user.Eids, toExt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveExt moves a value between an attribute and a key of its object's ext. Empty values are not
+// moved, a value present at both locations is taken from the destination.
+func moveExt(ext *json.RawMessage, key string, field interface{}, toExt bool) error {
+	m := make(map[string]json.RawMessage)
+	if len(*ext) != 0 {
+		if err := json.Unmarshal(*ext, &m); err != nil {
+			return err
+		}
+	}
+
+	fv := reflect.ValueOf(field).Elem()
+	empty := fv.IsZero() || (fv.Kind() == reflect.Slice && fv.Len() == 0)
+
+	if toExt {
+		if empty {
+			return nil
+		}
+		if _, ok := m[key]; !ok {
+			raw, err := json.Marshal(field)
+			if err != nil {
+				return err
+			}
+			m[key] = raw
+		}
+		fv.Set(reflect.Zero(fv.Type()))
+	} else {
+		raw, ok := m[key]
+		if !ok {
+			return nil
+		}
+		if empty {
+			if err := json.Unmarshal(raw, field); err != nil {
+				return err
+			}
+		}
+		delete(m, key)
+	}
+
+	if len(m) == 0 {
+		*ext = nil
+		return nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	*ext = data
+	return nil
+}
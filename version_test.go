@@ -0,0 +1,90 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBidRequest_ConvertVersion(t *testing.T) {
+	req26 := `{"id":"1",` +
+		`"source":{"schain":{"complete":1,"nodes":[{"asi":"a.com","sid":"1","hp":1}],"ver":"1.0"}},` +
+		`"regs":{"gdpr":1,"us_privacy":"1YNN"},` +
+		`"user":{"consent":"CO","eids":[{"source":"id.com","uids":[{"id":"x"}]}]}}`
+	req25 := `{"id":"1",` +
+		`"source":{"ext":{"schain":{"complete":1,"nodes":[{"asi":"a.com","sid":"1","hp":1}],"ver":"1.0"}}},` +
+		`"regs":{"ext":{"gdpr":1,"us_privacy":"1YNN"}},` +
+		`"user":{"ext":{"consent":"CO","eids":[{"source":"id.com","uids":[{"id":"x"}]}]}}}`
+
+	for _, tc := range []struct {
+		name    string
+		version string
+		data    string
+		exp     string
+	}{
+		{"to 2.5", "2.5", req26, req25},
+		{"to 2.6", "2.6", req25, req26},
+		{"2.5 unchanged", "2.5", req25, req25},
+		{"2.6 unchanged", "2.6", req26, req26},
+		{
+			name:    "other ext members",
+			version: "2.5",
+			data:    `{"id":"1","regs":{"gdpr":1,"ext":{"dsa":{"required":1}}}}`,
+			exp:     `{"id":"1","regs":{"ext":{"dsa":{"required":1},"gdpr":1}}}`,
+		},
+		{
+			name:    "destination wins",
+			version: "2.5",
+			data:    `{"id":"1","regs":{"gdpr":1,"ext":{"gdpr":0}},"user":{"consent":"A","ext":{"consent":"B"}}}`,
+			exp:     `{"id":"1","regs":{"ext":{"gdpr":0}},"user":{"ext":{"consent":"B"}}}`,
+		},
+		{
+			name:    "destination wins 2.6",
+			version: "2.6",
+			data:    `{"id":"1","regs":{"gdpr":1,"ext":{"gdpr":0,"x":1}}}`,
+			exp:     `{"id":"1","regs":{"gdpr":1,"ext":{"x":1}}}`,
+		},
+		{
+			name:    "empty values",
+			version: "2.5",
+			data:    `{"id":"1","regs":{},"user":{"eids":[]},"source":{}}`,
+			exp:     `{"id":"1","regs":{},"user":{},"source":{}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := new(BidRequest)
+			if err := req.DecodeJSON([]byte(tc.data)); err != nil {
+				t.Fatal(err)
+			}
+			if err := req.ConvertVersion(tc.version); err != nil {
+				t.Fatal(err)
+			}
+			got, err := req.AppendJSON(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp := new(BidRequest)
+			if err := exp.DecodeJSON([]byte(tc.exp)); err != nil {
+				t.Fatal(err)
+			}
+			expJSON, _ := exp.AppendJSON(nil)
+			assertJSONEqual(t, expJSON, got)
+		})
+	}
+}
+
+func TestBidRequest_ConvertVersion_errors(t *testing.T) {
+	if err := new(BidRequest).ConvertVersion("3.0"); err != ErrUnsupportedVersion {
+		t.Fatalf("expected %v, got %v", ErrUnsupportedVersion, err)
+	}
+
+	for _, req := range []*BidRequest{
+		{Regulations: &Regulations{Ext: json.RawMessage(`[]`)}},
+		{Regulations: &Regulations{Ext: json.RawMessage(`{"gdpr":"x"}`)}},
+		{User: &User{Ext: json.RawMessage(`{"eids":{}}`)}},
+		{Source: &Source{Ext: json.RawMessage(`{"schain":1}`)}},
+	} {
+		if err := req.ConvertVersion("2.6"); err == nil {
+			t.Errorf("expected error for %+v", req)
+		}
+	}
+}
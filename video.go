@@ -27,7 +27,7 @@ type Video struct {
 	Height          int                 `json:"h,omitempty"`              // Height of the player in pixels
 	PoDid           int                 `json:"podid,omitempty"`          // Unique identifier indicating that an impression opportunity belongs to a video ad pod. If multiple impression opportunities within a bid request share the same podid, this indicates that those impression opportunities belong to the same video ad pod
 	PodSeq          int                 `json:"podseq,omitempty"`         // The sequence (position) of the video ad pod within a content     stream
-	RqdDurs         int                 `json:"rqddurs,omitempty"`        // Precise acceptable durations for video creatives in seconds. This field specifically targets the Live TV use case where non-exact ad durations would result in undesirable ‘dead air’. This field is mutually exclusive with minduration and maxduration; if rqddurs is specified, minduration and maxduration must not be specified and vice versa
+	RqdDurs         []int               `json:"rqddurs,omitempty"`        // Precise acceptable durations for video creatives in seconds. This field specifically targets the Live TV use case where non-exact ad durations would result in undesirable ‘dead air’. This field is mutually exclusive with minduration and maxduration; if rqddurs is specified, minduration and maxduration must not be specified and vice versa. A single number, as decoded into the former int type, is accepted by DecodeLenient with CoerceSingleArrays
 	Placement       VideoPlacement      `json:"placement,omitempty"`      // Video placement type
 	Linearity       VideoLinearity      `json:"linearity,omitempty"`      // Indicates whether the ad impression is linear or non-linear
 	Skip            int                 `json:"skip,omitempty"`           // Indicates if the player will allow the video to be skipped, where 0 = no, 1 = yes.
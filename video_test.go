@@ -0,0 +1,30 @@
+package openrtb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVideo_RqdDurs(t *testing.T) {
+	var v Video
+	if err := v.DecodeJSON([]byte(`{"mimes":["video/mp4"],"rqddurs":[15,30]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v.RqdDurs, []int{15, 30}) {
+		t.Fatalf("unexpected durations %v", v.RqdDurs)
+	}
+
+	// a single number, as sent for the former int type
+	data := []byte(`{"mimes":["video/mp4"],"rqddurs":15}`)
+	if err := new(Video).DecodeJSON(data); err == nil {
+		t.Fatal("expected error")
+	}
+	var a Audio
+	applied, err := DecodeLenient([]byte(`{"mimes":["audio/mp4"],"rqddurs":15}`), &a, CoerceSingleArrays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a.RqdDurs, []int{15}) || len(applied) != 1 || applied[0].Path != "rqddurs" {
+		t.Fatalf("unexpected durations %v, coercions %v", a.RqdDurs, applied)
+	}
+}
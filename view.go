@@ -0,0 +1,311 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// View errors
+var (
+	ErrInvalidPath  = errors.New("openrtb: invalid path")
+	ErrPathNotFound = errors.New("openrtb: path not found")
+)
+
+// RequestView provides access to attributes of a JSON encoded bid request without decoding
+// all of it, e.g. for routing decisions. Only the attributes leading to a requested one are
+// decoded, so type errors elsewhere remain undetected until the full request is materialized.
+//
+// Paths are dot-separated attribute names with array indices in brackets, e.g.
+// "imp[0].banner.format[1].w". Like json.Unmarshal, names are matched case-insensitively
+// and the last of duplicate attributes wins.
+//
+// The top-level attributes are indexed on first use, so that accessors don't rescan the
+// request, syntax errors fail all accessors. A view must not be used concurrently.
+type RequestView struct {
+	data []byte
+	req  *BidRequest
+	err  error
+
+	members []viewMember
+	indexed bool
+	membErr error
+}
+
+type viewMember struct {
+	key   string
+	value []byte
+}
+
+// NewRequestView returns a view over the JSON data of a request, the data must not be modified.
+func NewRequestView(data []byte) *RequestView {
+	return &RequestView{data: data}
+}
+
+// Bytes returns the underlying data.
+func (v *RequestView) Bytes() []byte { return v.data }
+
+// ImpressionRef contains the routing attributes of an impression.
+type ImpressionRef struct {
+	ID    string
+	TagID string
+}
+
+// ID returns the request ID, or an empty string if it is missing or invalid.
+func (v *RequestView) ID() string {
+	s, _ := v.String("id")
+	return s
+}
+
+// TMax returns the maximum response time in milliseconds, or 0 if it is missing or invalid.
+func (v *RequestView) TMax() int {
+	n, _ := v.Int("tmax")
+	return n
+}
+
+// Impressions returns the IDs and tag IDs of the impressions.
+func (v *RequestView) Impressions() []ImpressionRef {
+	data, err := v.Raw("imp")
+	if err != nil {
+		return nil
+	}
+
+	var refs []ImpressionRef
+	r := jsonReader{data: data}
+	for ok := r.firstElem(); ok; ok = r.nextElem() {
+		elem := r.raw()
+		var ref ImpressionRef
+		ref.ID, _ = viewString(lookupKey(elem, "id"))
+		ref.TagID, _ = viewString(lookupKey(elem, "tagid"))
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// SiteDomain returns site.domain, or an empty string if it is missing or invalid.
+func (v *RequestView) SiteDomain() string {
+	s, _ := v.String("site.domain")
+	return s
+}
+
+// AppBundle returns app.bundle, or an empty string if it is missing or invalid.
+func (v *RequestView) AppBundle() string {
+	s, _ := v.String("app.bundle")
+	return s
+}
+
+// Country returns device.geo.country, or an empty string if it is missing or invalid.
+func (v *RequestView) Country() string {
+	s, _ := v.String("device.geo.country")
+	return s
+}
+
+// Has returns true if the path exists.
+func (v *RequestView) Has(path string) bool {
+	_, err := v.Raw(path)
+	return err == nil
+}
+
+// Raw returns the JSON value at the given path.
+func (v *RequestView) Raw(path string) (json.RawMessage, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data := v.data
+	for i, seg := range segs {
+		if i == 0 && seg.index < 0 {
+			data, err = v.member(seg.key)
+		} else if seg.index < 0 {
+			data, err = lookupKey(data, seg.key)
+		} else {
+			data, err = lookupIndex(data, seg.index)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(segs) == 0 {
+		r := jsonReader{data: data}
+		if data = r.raw(); r.err != nil {
+			return nil, r.err
+		}
+	}
+	return data, nil
+}
+
+// String returns the string at the given path, null results in an empty string.
+func (v *RequestView) String(path string) (string, error) {
+	return viewString(v.Raw(path))
+}
+
+// Int returns the integer at the given path, null results in 0.
+func (v *RequestView) Int(path string) (int, error) {
+	data, err := v.Raw(path)
+	if err != nil {
+		return 0, err
+	}
+	r := jsonReader{data: data}
+	n, _ := r.int(0)
+	return int(n), r.err
+}
+
+// Float returns the number at the given path, null results in 0.
+func (v *RequestView) Float(path string) (float64, error) {
+	data, err := v.Raw(path)
+	if err != nil {
+		return 0, err
+	}
+	r := jsonReader{data: data}
+	n, _ := r.float(64)
+	return n, r.err
+}
+
+// Len returns the number of elements of the array at the given path, null results in 0.
+func (v *RequestView) Len(path string) (int, error) {
+	data, err := v.Raw(path)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	r := jsonReader{data: data}
+	if r.null() {
+		return 0, nil
+	}
+	for ok := r.firstElem(); ok; ok = r.nextElem() {
+		r.skip()
+		n++
+	}
+	return n, r.err
+}
+
+// BidRequest decodes the full request. The result is cached, subsequent calls return the same request.
+func (v *RequestView) BidRequest() (*BidRequest, error) {
+	if v.req == nil && v.err == nil {
+		req := new(BidRequest)
+		if v.err = req.DecodeJSON(v.data); v.err == nil {
+			v.req = req
+		}
+	}
+	return v.req, v.err
+}
+
+// member returns the value of a top-level member, the last one matching key case-insensitively.
+func (v *RequestView) member(key string) ([]byte, error) {
+	if !v.indexed {
+		v.indexed = true
+		r := jsonReader{data: v.data}
+		for k, ok := r.firstKey(); ok; k, ok = r.nextKey() {
+			v.members = append(v.members, viewMember{key: string(k), value: r.raw()})
+		}
+		if v.membErr = r.err; v.membErr != nil {
+			v.members = nil
+		}
+	}
+	if v.membErr != nil {
+		return nil, v.membErr
+	}
+
+	for i := len(v.members) - 1; i >= 0; i-- {
+		if m := &v.members[i]; m.key == key || strings.EqualFold(m.key, key) {
+			return m.value, nil
+		}
+	}
+	return nil, ErrPathNotFound
+}
+
+func viewString(data []byte, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	var s string
+	r := jsonReader{data: data}
+	r.string(&s)
+	return s, r.err
+}
+
+// lookupKey returns the value of an object member, the last one matching key case-insensitively.
+func lookupKey(data []byte, key string) ([]byte, error) {
+	var value []byte
+	r := jsonReader{data: data}
+	for k, ok := r.firstKey(); ok; k, ok = r.nextKey() {
+		if string(k) == key || bytes.EqualFold(k, []byte(key)) {
+			value = r.raw()
+		} else {
+			r.skip()
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	} else if value == nil {
+		return nil, ErrPathNotFound
+	}
+	return value, nil
+}
+
+// lookupIndex returns an array element, the rest of the array is not parsed.
+func lookupIndex(data []byte, index int) ([]byte, error) {
+	r := jsonReader{data: data}
+	if r.null() {
+		return nil, ErrPathNotFound
+	}
+	i := 0
+	for ok := r.firstElem(); ok; ok = r.nextElem() {
+		if i == index {
+			return r.raw(), r.err
+		}
+		r.skip()
+		i++
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return nil, ErrPathNotFound
+}
+
+type pathSegment struct {
+	key   string
+	index int // array index, -1 for object members
+}
+
+// parsePath splits paths like "imp[0].banner.w" into segments.
+func parsePath(path string) ([]pathSegment, error) {
+	var segs []pathSegment
+	for path != "" {
+		if path[0] == '[' {
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, ErrInvalidPath
+			}
+			n, err := strconv.Atoi(path[1:end])
+			if err != nil || n < 0 {
+				return nil, ErrInvalidPath
+			}
+			segs = append(segs, pathSegment{index: n})
+			path = path[end+1:]
+		} else {
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, ErrInvalidPath
+			}
+			segs = append(segs, pathSegment{key: path[:end], index: -1})
+			path = path[end:]
+		}
+
+		if strings.HasPrefix(path, ".") {
+			if path = path[1:]; path == "" {
+				return nil, ErrInvalidPath
+			}
+		} else if path != "" && path[0] != '[' {
+			return nil, ErrInvalidPath
+		}
+	}
+	return segs, nil
+}
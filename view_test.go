@@ -0,0 +1,102 @@
+package openrtb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequestView(t *testing.T) {
+	v := NewRequestView([]byte(`{
+		"id": "req",
+		"tmax": 120,
+		"imp": [{"id": "1", "tagid": "top", "banner": {"format": [{"w": 300, "h": 250}, {"w": 728, "h": 90}]}}, {"id": "2"}],
+		"site": {"domain": "example.com"},
+		"device": {"geo": {"country": "DEU"}},
+		"bcat": null,
+		"ID": "last",
+		"ext": {"a.b": 1.5}
+	}`))
+
+	if v.ID() != "last" || v.TMax() != 120 || v.SiteDomain() != "example.com" || v.AppBundle() != "" || v.Country() != "DEU" {
+		t.Fatalf("unexpected attributes %q %d %q %q %q", v.ID(), v.TMax(), v.SiteDomain(), v.AppBundle(), v.Country())
+	}
+	if refs := v.Impressions(); !reflect.DeepEqual(refs, []ImpressionRef{{ID: "1", TagID: "top"}, {ID: "2"}}) {
+		t.Fatalf("unexpected impressions %+v", refs)
+	}
+	if !v.Has("imp[1].id") || v.Has("imp[2]") || v.Has("app") || !v.Has("bcat") {
+		t.Fatal("unexpected Has results")
+	}
+
+	if n, err := v.Int("imp[0].banner.format[1].w"); err != nil || n != 728 {
+		t.Fatalf("expected 728, got %d, %v", n, err)
+	}
+	if n, err := v.Len("imp[0].banner.format"); err != nil || n != 2 {
+		t.Fatalf("expected 2, got %d, %v", n, err)
+	}
+	if n, err := v.Len("bcat"); err != nil || n != 0 {
+		t.Fatalf("expected 0, got %d, %v", n, err)
+	}
+	if f, err := v.Float("ext[0]"); err != ErrJSONUnexpected {
+		t.Fatalf("expected %v, got %v, %v", ErrJSONUnexpected, f, err)
+	}
+	if data, err := v.Raw("site"); err != nil || string(data) != `{"domain": "example.com"}` {
+		t.Fatalf("unexpected site %s, %v", data, err)
+	}
+
+	for _, tc := range []struct {
+		path string
+		err  error
+	}{
+		{"app.bundle", ErrPathNotFound},
+		{"imp[5].id", ErrPathNotFound},
+		{"site.domain.x", ErrJSONUnexpected},
+		{"imp.id", ErrJSONUnexpected},
+		{"imp[", ErrInvalidPath},
+		{"imp[-1]", ErrInvalidPath},
+		{"imp..id", ErrInvalidPath},
+		{"imp.", ErrInvalidPath},
+		{"imp[0]id", ErrInvalidPath},
+	} {
+		if _, err := v.Raw(tc.path); err != tc.err {
+			t.Errorf("%s: expected %v, got %v", tc.path, tc.err, err)
+		}
+	}
+
+	req, err := v.BidRequest()
+	if err != nil || req.ID != "last" || len(req.Impressions) != 2 {
+		t.Fatalf("unexpected request %+v, %v", req, err)
+	}
+	if again, _ := v.BidRequest(); again != req {
+		t.Fatal("expected cached request")
+	}
+}
+
+func TestRequestView_malformed(t *testing.T) {
+	for _, data := range []string{
+		``,
+		`[]`,
+		`{"id":"1",`,
+		`{"id" "1"}`,
+		`{"id":"1"]`,
+	} {
+		v := NewRequestView([]byte(data))
+		if _, err := v.Raw("id"); err == nil || err == ErrPathNotFound {
+			t.Errorf("%s: expected syntax error, got %v", data, err)
+		}
+		if v.ID() != "" || v.Impressions() != nil {
+			t.Errorf("%s: expected no attributes", data)
+		}
+		if _, err := v.BidRequest(); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+
+	// type errors are only detected by the accessed attributes and the full request
+	v := NewRequestView([]byte(`{"id":"1","tmax":"x"}`))
+	if v.ID() != "1" || v.TMax() != 0 {
+		t.Fatalf("unexpected attributes %q %d", v.ID(), v.TMax())
+	}
+	if _, err := v.BidRequest(); err == nil {
+		t.Fatal("expected error")
+	}
+}